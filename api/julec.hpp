@@ -96,6 +96,7 @@ inline std::ostream &operator<<(std::ostream &_Stream,
 
 
 #include "defer.hpp"
+#include "trace.hpp"
 #include "typedef.hpp"
 #include "atomicity.hpp"
 #include "ref.hpp"
@@ -217,8 +218,10 @@ str_jt __julec_to_str(const _Obj_t &_Obj) noexcept {
     return ( str_jt( _stream.str() ) );
 }
 
-inline void JULEC_ID(panic)(const trait_jt<JULEC_ID(Error)> &_Error)
-{ throw ( _Error ); }
+inline void JULEC_ID(panic)(const trait_jt<JULEC_ID(Error)> &_Error) {
+    __JULEC_TRACE_CAPTURE();
+    throw ( _Error );
+}
 
 template<typename _Obj_t>
 void JULEC_ID(panic)(const _Obj_t &_Expr) {
@@ -230,6 +233,7 @@ void JULEC_ID(panic)(const _Obj_t &_Expr) {
     };
     struct panic_error _error;
     _error._message = __julec_to_str ( _Expr );
+    __JULEC_TRACE_CAPTURE();
     throw ( trait_jt<JULEC_ID(Error)> ( _error ) ) ;
 }
 
@@ -237,6 +241,7 @@ void __julec_terminate_handler(void) noexcept {
     try { std::rethrow_exception( std::current_exception() ); }
     catch (trait_jt<JULEC_ID(Error)> _error) {
         std::cout << "panic: " << _error.get().error() << std::endl;
+        __JULEC_TRACE_PRINT( std::cout );
         std::exit( __JULEC_EXIT_PANIC );
     }
 }
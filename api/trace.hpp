@@ -0,0 +1,78 @@
+// Copyright 2022 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+#ifndef __JULEC_TRACE_HPP
+#define __JULEC_TRACE_HPP
+
+// Shadow call stack for Jule-level panic traces.
+// Enabled by __JULEC_PANIC_TRACE, macros are empty otherwise.
+
+#ifdef __JULEC_PANIC_TRACE
+
+#include <vector>
+
+struct trace_frame {
+public:
+    const char *_fn;
+    const char *_file;
+    int _row;
+    int _column;
+};
+
+// Frames of current thread.
+thread_local std::vector<trace_frame> __julec_trace_stack;
+// Frames of current thread at last panic.
+thread_local std::vector<trace_frame> __julec_trace_panic;
+
+struct trace_guard {
+public:
+    trace_guard(const char *_Fn, const char *_File,
+                const int _Row, const int _Column) noexcept
+    { __julec_trace_stack.push_back( trace_frame{ _Fn, _File, _Row, _Column } ); }
+
+    ~trace_guard(void) noexcept
+    { __julec_trace_stack.pop_back(); }
+};
+
+inline void __julec_trace_at(const int _Row, const int _Column) noexcept {
+    if (__julec_trace_stack.empty())
+    { return; }
+    trace_frame &_frame{ __julec_trace_stack.back() };
+    _frame._row = _Row;
+    _frame._column = _Column;
+}
+
+inline void __julec_trace_capture(void) noexcept
+{ __julec_trace_panic = __julec_trace_stack; }
+
+void __julec_trace_print(std::ostream &_Stream) noexcept {
+    if (__julec_trace_panic.empty())
+    { return; }
+    _Stream << "\ntrace:\n";
+    for (auto _it{ __julec_trace_panic.rbegin() };
+         _it != __julec_trace_panic.rend(); ++_it) {
+        _Stream << "    " << _it->_fn << '\n'
+                << "        " << _it->_file
+                << ':' << _it->_row
+                << ':' << _it->_column << '\n';
+    }
+}
+
+#define __JULEC_TRACE_FN(_FN, _FILE, _ROW, _COLUMN) \
+    trace_guard __julec_trace_guard{ _FN, _FILE, _ROW, _COLUMN }
+#define __JULEC_TRACE_AT(_ROW, _COLUMN) \
+    ( __julec_trace_at( _ROW, _COLUMN ) )
+#define __JULEC_TRACE_CAPTURE() ( __julec_trace_capture() )
+#define __JULEC_TRACE_PRINT(_STREAM) ( __julec_trace_print( _STREAM ) )
+
+#else
+
+#define __JULEC_TRACE_FN(_FN, _FILE, _ROW, _COLUMN)
+#define __JULEC_TRACE_AT(_ROW, _COLUMN)
+#define __JULEC_TRACE_CAPTURE()
+#define __JULEC_TRACE_PRINT(_STREAM)
+
+#endif // #ifdef __JULEC_PANIC_TRACE
+
+#endif // #ifndef __JULEC_TRACE_HPP
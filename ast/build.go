@@ -17,6 +17,17 @@ import (
 type Builder struct {
 	wg  sync.WaitGroup
 	pub bool
+	env *jule.Env
+
+	// Count of errors which is belongs to a poisoned node.
+	attributed int
+
+	// Count of errors of lexer which is belongs to a poisoned node.
+	lex_attributed int
+
+	// Byte offsets of errors of lexer.
+	// Nodes which have tokens at these offsets are poisoned.
+	LexErrors []int
 
 	Tree   []models.Object
 	Errors []julelog.CompilerLog
@@ -24,27 +35,73 @@ type Builder struct {
 	Pos    int
 }
 
-// NewBuilder instance.
-func NewBuilder(t []lex.Token) *Builder {
+// poison reports errors pushed since errors and attributed
+// are not belongs to any nested poisoned node, and attributes them.
+func (b *Builder) poison(errors, attributed int) bool {
+	n := len(b.Errors) - errors
+	poisoned := n > b.attributed-attributed
+	b.attributed = attributed + n
+	return poisoned
+}
+
+// poison_lex reports tokens have errors of lexer and no nested node
+// is poisoned by errors of lexer since attributed, and attributes them.
+// Unbalanced ranges are reported by both opening and closing tokens,
+// so errors of lexer in outer node are cascaded if nested node has any.
+func (b *Builder) poison_lex(toks []lex.Token, attributed int) bool {
+	if len(toks) == 0 {
+		return false
+	}
+	start, end := toks[0].Offset, toks[len(toks)-1].EndOffset
+	n := len(b.LexErrors)
+	for i := 0; i < len(b.LexErrors); i++ {
+		offset := b.LexErrors[i]
+		if offset >= start && offset < end {
+			b.LexErrors = append(b.LexErrors[:i], b.LexErrors[i+1:]...)
+			i--
+		}
+	}
+	n -= len(b.LexErrors)
+	poisoned := n > 0 && b.lex_attributed == attributed
+	b.lex_attributed += n
+	return poisoned
+}
+
+// drop_errors removes errors pushed since errors and attributed.
+// Errors of nodes which have errors of lexer are cascaded.
+func (b *Builder) drop_errors(errors, attributed int) {
+	b.Errors = b.Errors[:errors]
+	b.attributed = attributed
+}
+
+// NewBuilder instance for environment.
+func NewBuilder(env *jule.Env, t []lex.Token) *Builder {
 	b := new(Builder)
+	b.env = env
 	b.Tokens = t
 	b.Pos = 0
 	return b
 }
 
-func compilerErr(t lex.Token, key string, args ...any) julelog.CompilerLog {
+func compilerErr(env *jule.Env, t lex.Token, key string, args ...any) julelog.CompilerLog {
+	row, column := t.End()
 	return julelog.CompilerLog{
-		Type:    julelog.ERR,
-		Row:     t.Row,
-		Column:  t.Column,
-		Path:    t.File.Path(),
-		Message: jule.GetError(key, args...),
+		Type:      julelog.ERR,
+		Row:       t.Row,
+		Column:    t.Column,
+		EndRow:    row,
+		EndColumn: column,
+		Path:      t.File.Path(),
+		File:      t.File,
+		Message:   env.GetError(key, args...),
+		Key:       key,
+		Args:      args,
 	}
 }
 
 // pusherr appends error by specified token.
 func (b *Builder) pusherr(t lex.Token, key string, args ...any) {
-	b.Errors = append(b.Errors, compilerErr(t, key, args...))
+	b.Errors = append(b.Errors, compilerErr(b.env, t, key, args...))
 }
 
 // Ended reports position is at end of tokens or not.
@@ -58,9 +115,9 @@ func (b *Builder) buildNode(toks []lex.Token) {
 	case lex.ID_USE:
 		b.Use(toks)
 	case lex.ID_FN, lex.ID_UNSAFE:
-		s := models.Statement{Token: t}
-		s.Data = b.Fn(toks, false, false, false)
-		b.Tree = append(b.Tree, models.Object{Token: s.Token, Data: s})
+		f := b.Fn(toks, false, false, false)
+		s := models.Statement{Token: t, Span: f.Span, Data: f}
+		b.Tree = append(b.Tree, models.Object{Token: s.Token, Span: s.Span, Data: s})
 	case lex.ID_CONST, lex.ID_LET, lex.ID_MUT:
 		b.GlobalVar(toks)
 	case lex.ID_TYPE:
@@ -90,7 +147,8 @@ func (b *Builder) buildNode(toks []lex.Token) {
 func (b *Builder) Build() {
 	for b.Pos != -1 && !b.Ended() {
 		toks := b.nextBuilderSt()
-		b.pub = toks[0].Id == lex.ID_PUB
+		pub_tok := toks[0]
+		b.pub = pub_tok.Id == lex.ID_PUB
 		if b.pub {
 			if len(toks) == 1 {
 				if b.Ended() {
@@ -102,7 +160,23 @@ func (b *Builder) Build() {
 				toks = toks[1:]
 			}
 		}
+		errors, attributed := len(b.Errors), b.attributed
+		lex_attributed := b.lex_attributed
+		n := len(b.Tree)
 		b.buildNode(toks)
+		poisoned := b.poison(errors, attributed)
+		if b.poison_lex(toks, lex_attributed) {
+			b.drop_errors(errors, attributed)
+			poisoned = true
+		}
+		for i := n; i < len(b.Tree); i++ {
+			obj := &b.Tree[i]
+			obj.Poisoned = poisoned
+			if pub_tok.Id == lex.ID_PUB {
+				// Declarations are begins with pub keyword.
+				obj.Span = pub_tok.Span().To(obj.Span)
+			}
+		}
 	}
 	b.Wait()
 }
@@ -112,6 +186,7 @@ func (b *Builder) Wait() { b.wg.Wait() }
 
 // TypeAlias builds AST model of type definition statement.
 func (b *Builder) TypeAlias(toks []lex.Token) (t models.TypeAlias) {
+	t.Span = lex.SpanOf(toks)
 	i := 1 // Initialize value is 1 for skip keyword.
 	if i >= len(toks) {
 		b.pusherr(toks[i-1], "invalid_syntax")
@@ -189,6 +264,7 @@ func (b *Builder) buildEnumItems(toks []lex.Token) []*models.EnumItem {
 			b.pusherr(item.Token, "invalid_syntax")
 		}
 		item.Id = item.Token.Kind
+		item.Span = item.Token.Span()
 		if i+1 >= len(toks) || toks[i+1].Id == lex.ID_COMMA {
 			if i+1 < len(toks) {
 				i++
@@ -207,6 +283,7 @@ func (b *Builder) buildEnumItems(toks []lex.Token) []*models.EnumItem {
 			continue
 		}
 		item.Expr = b.buildEnumItemExpr(&i, toks)
+		item.Span = item.Span.To(item.Expr.Span)
 		items = append(items, item)
 	}
 	return items
@@ -219,6 +296,7 @@ func (b *Builder) Enum(toks []lex.Token) {
 		b.pusherr(toks[0], "invalid_syntax")
 		return
 	}
+	kw := toks[0]
 	e.Token = toks[1]
 	if e.Token.Id != lex.ID_IDENT {
 		b.pusherr(e.Token, "invalid_syntax")
@@ -247,10 +325,11 @@ func (b *Builder) Enum(toks []lex.Token) {
 	} else if i < len(toks) {
 		b.pusherr(toks[i], "invalid_syntax")
 	}
+	e.Span = kw.Span().To(rangespan(toks, i, itemToks))
 	e.Pub = b.pub
 	b.pub = false
 	e.Items = b.buildEnumItems(itemToks)
-	b.Tree = append(b.Tree, models.Object{Token: e.Token, Data: e})
+	b.Tree = append(b.Tree, models.Object{Token: e.Token, Span: e.Span, Data: e})
 }
 
 // Comment builds AST model of comment.
@@ -258,8 +337,10 @@ func (b *Builder) Comment(t lex.Token) models.Object {
 	t.Kind = strings.TrimSpace(t.Kind[2:])
 	return models.Object{
 		Token: t,
+		Span:  t.Span(),
 		Data: models.Comment{
 			Token:   t,
+			Span:    t.Span(),
 			Content: t.Kind,
 		},
 	}
@@ -273,6 +354,7 @@ func (b *Builder) structFields(toks []lex.Token, cpp_linked bool) []*models.Var
 		if var_tokens[0].Id == lex.ID_COMMENT {
 			continue
 		}
+		first := var_tokens[0]
 		is_pub := var_tokens[0].Id == lex.ID_PUB
 		if is_pub {
 			if len(var_tokens) == 1 {
@@ -290,6 +372,7 @@ func (b *Builder) structFields(toks []lex.Token, cpp_linked bool) []*models.Var
 			var_tokens = var_tokens[1:]
 		}
 		v := b.Var(var_tokens, false, false)
+		v.Span = first.Span().To(v.Span)
 		v.Pub = is_pub
 		v.Mutable = is_mut
 		v.IsField = true
@@ -307,7 +390,9 @@ func (b *Builder) parse_struct(toks []lex.Token, cpp_linked bool) models.Struct
 		b.pusherr(toks[0], "invalid_syntax")
 		return s
 	}
+	kw := toks[0]
 	s.Token = toks[1]
+	s.Span = lex.SpanOf(toks)
 	if s.Token.Id != lex.ID_IDENT {
 		b.pusherr(s.Token, "invalid_syntax")
 	}
@@ -321,6 +406,7 @@ func (b *Builder) parse_struct(toks []lex.Token, cpp_linked bool) models.Struct
 	if i < len(toks) {
 		b.pusherr(toks[i], "invalid_syntax")
 	}
+	s.Span = kw.Span().To(rangespan(toks, i, bodyToks))
 	s.Fields = b.structFields(bodyToks, cpp_linked)
 	return s
 }
@@ -328,7 +414,7 @@ func (b *Builder) parse_struct(toks []lex.Token, cpp_linked bool) models.Struct
 // Struct builds AST model of structure.
 func (b *Builder) Struct(toks []lex.Token) {
 	s := b.parse_struct(toks, false)
-	b.Tree = append(b.Tree, models.Object{Token: s.Token, Data: s})
+	b.Tree = append(b.Tree, models.Object{Token: s.Token, Span: s.Span, Data: s})
 }
 
 func (b *Builder) traitFuncs(toks []lex.Token, trait_id string) []*models.Fn {
@@ -353,6 +439,7 @@ func (b *Builder) Trait(toks []lex.Token) {
 		b.pusherr(toks[0], "invalid_syntax")
 		return
 	}
+	kw := toks[0]
 	t.Token = toks[1]
 	if t.Token.Id != lex.ID_IDENT {
 		b.pusherr(t.Token, "invalid_syntax")
@@ -367,8 +454,9 @@ func (b *Builder) Trait(toks []lex.Token) {
 	if i < len(toks) {
 		b.pusherr(toks[i], "invalid_syntax")
 	}
+	t.Span = kw.Span().To(rangespan(toks, i, bodyToks))
 	t.Funcs = b.traitFuncs(bodyToks, t.Id)
-	b.Tree = append(b.Tree, models.Object{Token: t.Token, Data: t})
+	b.Tree = append(b.Tree, models.Object{Token: t.Token, Span: t.Span, Data: t})
 }
 
 func (b *Builder) implTraitFuncs(impl *models.Impl, toks []lex.Token) {
@@ -387,7 +475,7 @@ func (b *Builder) implTraitFuncs(impl *models.Impl, toks []lex.Token) {
 			f := b.get_method(fnToks)
 			f.Pub = true
 			b.setup_receiver(f, impl.Target.Kind)
-			impl.Tree = append(impl.Tree, models.Object{Token: f.Token, Data: f})
+			impl.Tree = append(impl.Tree, models.Object{Token: f.Token, Span: f.Span, Data: f})
 		default:
 			b.pusherr(tok, "invalid_syntax")
 			continue
@@ -412,10 +500,12 @@ func (b *Builder) implStruct(impl *models.Impl, toks []lex.Token) {
 		case lex.ID_TYPE:
 			impl.Tree = append(impl.Tree, models.Object{
 				Token: tok,
+				Span:  lex.SpanOf(fnToks),
 				Data:  b.Generics(fnToks),
 			})
 			continue
 		}
+		first := tok
 		if tok.Id == lex.ID_PUB {
 			pub = true
 			if len(fnToks) == 1 {
@@ -432,7 +522,11 @@ func (b *Builder) implStruct(impl *models.Impl, toks []lex.Token) {
 			f := b.get_method(fnToks)
 			f.Pub = pub
 			b.setup_receiver(f, impl.Base.Kind)
-			impl.Tree = append(impl.Tree, models.Object{Token: f.Token, Data: f})
+			impl.Tree = append(impl.Tree, models.Object{
+				Token: f.Token,
+				Span:  first.Span().To(f.Span),
+				Data:  f,
+			})
 		default:
 			b.pusherr(tok, "invalid_syntax")
 			continue
@@ -455,6 +549,7 @@ func (b *Builder) get_method(toks []lex.Token) *models.Fn {
 	}
 	f := new(models.Fn)
 	*f = b.Fn(toks, true, false, false)
+	f.Span = tok.Span().To(f.Span)
 	f.IsUnsafe = tok.Id == lex.ID_UNSAFE
 	if f.Block != nil {
 		f.Block.IsUnsafe = f.IsUnsafe
@@ -473,6 +568,7 @@ func (b *Builder) implFuncs(impl *models.Impl, toks []lex.Token) {
 // Impl builds AST model of impl statement.
 func (b *Builder) Impl(toks []lex.Token) {
 	tok := toks[0]
+	kw := tok
 	if len(toks) < 2 {
 		b.pusherr(tok, "invalid_syntax")
 		return
@@ -521,8 +617,9 @@ body:
 	if i < len(toks) {
 		b.pusherr(toks[i], "invalid_syntax")
 	}
+	impl.Span = kw.Span().To(rangespan(toks, i, bodyToks))
 	b.implFuncs(&impl, bodyToks)
-	b.Tree = append(b.Tree, models.Object{Token: impl.Base, Data: impl})
+	b.Tree = append(b.Tree, models.Object{Token: impl.Base, Span: impl.Span, Data: impl})
 }
 
 // link_fn builds AST model of cpp function link.
@@ -537,7 +634,8 @@ func (b *Builder) link_fn(toks []lex.Token) {
 	link.Token = tok
 	link.Link = new(models.Fn)
 	*link.Link = b.Fn(toks[1:], false, false, true)
-	b.Tree = append(b.Tree, models.Object{Token: tok, Data: link})
+	link.Span = tok.Span().To(link.Link.Span)
+	b.Tree = append(b.Tree, models.Object{Token: tok, Span: link.Span, Data: link})
 
 	b.pub = bpub
 }
@@ -554,7 +652,8 @@ func (b *Builder) link_var(toks []lex.Token) {
 	link.Token = tok
 	link.Link = new(models.Var)
 	*link.Link = b.Var(toks[1:], true, false)
-	b.Tree = append(b.Tree, models.Object{Token: tok, Data: link})
+	link.Span = tok.Span().To(link.Link.Span)
+	b.Tree = append(b.Tree, models.Object{Token: tok, Span: link.Span, Data: link})
 
 	b.pub = bpub
 }
@@ -570,7 +669,8 @@ func (b *Builder) link_struct(toks []lex.Token) {
 	var link models.CppLinkStruct
 	link.Token = tok
 	link.Link = b.parse_struct(toks[1:], true)
-	b.Tree = append(b.Tree, models.Object{Token: tok, Data: link})
+	link.Span = tok.Span().To(link.Link.Span)
+	b.Tree = append(b.Tree, models.Object{Token: tok, Span: link.Span, Data: link})
 
 	b.pub = bpub
 }
@@ -586,7 +686,8 @@ func (b *Builder) link_type_alias(toks []lex.Token) {
 	var link models.CppLinkAlias
 	link.Token = tok
 	link.Link = b.TypeAlias(toks[1:])
-	b.Tree = append(b.Tree, models.Object{Token: tok, Data: link})
+	link.Span = tok.Span().To(link.Link.Span)
+	b.Tree = append(b.Tree, models.Object{Token: tok, Span: link.Span, Data: link})
 
 	b.pub = bpub
 }
@@ -613,6 +714,27 @@ func (b *Builder) CppLink(toks []lex.Token) {
 	}
 }
 
+// tokspan returns span of tokens from start to end.
+// End is inclusive and clamped to tokens.
+func tokspan(toks []lex.Token, start, end int) lex.Span {
+	if start < 0 {
+		start = 0
+	}
+	if end >= len(toks) {
+		end = len(toks) - 1
+	}
+	if start > end {
+		return lex.Span{}
+	}
+	return lex.SpanOf(toks[start : end+1])
+}
+
+// rangespan returns span of range with braces which is ends before i.
+// Range should be returned by Range for i.
+func rangespan(toks []lex.Token, i int, rang []lex.Token) lex.Span {
+	return tokspan(toks, i-len(rang)-2, i-1)
+}
+
 func tokstoa(toks []lex.Token) string {
 	var str strings.Builder
 	for _, tok := range toks {
@@ -625,19 +747,20 @@ func tokstoa(toks []lex.Token) string {
 func (b *Builder) Use(toks []lex.Token) {
 	var use models.UseDecl
 	use.Token = toks[0]
+	use.Span = lex.SpanOf(toks)
 	if len(toks) < 2 {
 		b.pusherr(use.Token, "missing_use_path")
 		return
 	}
 	toks = toks[1:]
 	b.buildUseDecl(&use, toks)
-	b.Tree = append(b.Tree, models.Object{Token: use.Token, Data: use})
+	b.Tree = append(b.Tree, models.Object{Token: use.Token, Span: use.Span, Data: use})
 }
 
 func (b *Builder) getSelectors(toks []lex.Token) []lex.Token {
 	i := 0
 	toks = b.getrange(&i, lex.KND_LBRACE, lex.KND_RBRACE, &toks)
-	parts, errs := Parts(toks, lex.ID_COMMA, true)
+	parts, errs := Parts(b.env, toks, lex.ID_COMMA, true)
 	if len(errs) > 0 {
 		b.Errors = append(b.Errors, errs...)
 		return nil
@@ -672,7 +795,7 @@ func (b *Builder) buildUseCppDecl(use *models.UseDecl, toks []lex.Token) {
 
 func (b *Builder) buildUseDecl(use *models.UseDecl, toks []lex.Token) {
 	var path strings.Builder
-	path.WriteString(jule.STDLIB_PATH)
+	path.WriteString(b.env.StdlibPath)
 	path.WriteRune(os.PathSeparator)
 	tok := toks[0]
 	isStd := false
@@ -769,6 +892,7 @@ func (b *Builder) Attribute(toks []lex.Token) (a models.Attribute) {
 		return
 	}
 	a.Tag = tag.Kind
+	a.Span = a.Token.Span().To(tag.Span())
 	toks = toks[i+1:]
 	if len(toks) > 0 {
 		tok := toks[0]
@@ -826,6 +950,7 @@ func (b *Builder) fn_prototype(toks []lex.Token, i *int, method, anon bool) (f m
 	b.pub = false
 	if anon {
 		f.Id = jule.ANONYMOUS
+		f.IdTok = f.Token
 	} else {
 		tok := toks[*i]
 		if tok.Id != lex.ID_IDENT {
@@ -833,6 +958,7 @@ func (b *Builder) fn_prototype(toks []lex.Token, i *int, method, anon bool) (f m
 			ok = false
 		}
 		f.Id = tok.Kind
+		f.IdTok = tok
 		*i++
 	}
 	f.RetType.Type.Id = juletype.VOID
@@ -861,6 +987,7 @@ func (b *Builder) Fn(toks []lex.Token, method, anon, prototype bool) (f models.F
 	var ok bool
 	i := 0
 	f, ok = b.fn_prototype(toks, &i, method, anon)
+	f.Span = lex.SpanOf(toks)
 	if prototype {
 		if i+1 < len(toks) {
 			b.pusherr(toks[i+1], "invalid_syntax")
@@ -873,9 +1000,12 @@ func (b *Builder) Fn(toks []lex.Token, method, anon, prototype bool) (f models.F
 		b.pusherr(f.Token, "body_not_exist")
 		return
 	}
+	f.Span = tokspan(toks, 0, i-1)
 	blockToks := b.getrange(&i, lex.KND_LBRACE, lex.KND_RBRACE, &toks)
 	if blockToks != nil {
 		f.Block = b.Block(blockToks)
+		f.Block.Span = rangespan(toks, i, blockToks)
+		f.Span = f.Span.To(f.Block.Span)
 		f.Block.IsUnsafe = f.IsUnsafe
 		if i < len(toks) {
 			b.pusherr(toks[i], "invalid_syntax")
@@ -893,6 +1023,7 @@ func (b *Builder) generic(toks []lex.Token) models.GenericType {
 	}
 	var gt models.GenericType
 	gt.Token = toks[0]
+	gt.Span = lex.SpanOf(toks)
 	if gt.Token.Id != lex.ID_IDENT {
 		b.pusherr(gt.Token, "invalid_syntax")
 	}
@@ -911,7 +1042,7 @@ func (b *Builder) Generics(toks []lex.Token) []models.GenericType {
 	} else if i < len(toks) {
 		b.pusherr(toks[i], "invalid_syntax")
 	}
-	parts, errs := Parts(genericsToks, lex.ID_COMMA, true)
+	parts, errs := Parts(b.env, genericsToks, lex.ID_COMMA, true)
 	b.Errors = append(b.Errors, errs...)
 	generics := make([]models.GenericType, len(parts))
 	for i, part := range parts {
@@ -931,6 +1062,7 @@ func (b *Builder) TypeOrGenerics(toks []lex.Token) models.Object {
 			generics := b.Generics(toks)
 			return models.Object{
 				Token: tok,
+				Span:  lex.SpanOf(toks),
 				Data:  generics,
 			}
 		}
@@ -940,6 +1072,7 @@ func (b *Builder) TypeOrGenerics(toks []lex.Token) models.Object {
 	b.pub = false
 	return models.Object{
 		Token: t.Token,
+		Span:  t.Span,
 		Data:  t,
 	}
 }
@@ -953,6 +1086,7 @@ func (b *Builder) GlobalVar(toks []lex.Token) {
 	s := b.VarSt(&bs, true)
 	b.Tree = append(b.Tree, models.Object{
 		Token: s.Token,
+		Span:  s.Span,
 		Data:  s,
 	})
 }
@@ -961,6 +1095,7 @@ func (b *Builder) build_self(toks []lex.Token) (model models.Param) {
 	if len(toks) == 0 {
 		return
 	}
+	model.Span = lex.SpanOf(toks)
 	i := 0
 	if toks[i].Id == lex.ID_MUT {
 		model.Mutable = true
@@ -991,7 +1126,7 @@ func (b *Builder) build_self(toks []lex.Token) (model models.Param) {
 
 // Params builds AST model of function parameters.
 func (b *Builder) Params(toks []lex.Token, method, mustPure bool) []models.Param {
-	parts, errs := Parts(toks, lex.ID_COMMA, true)
+	parts, errs := Parts(b.env, toks, lex.ID_COMMA, true)
 	b.Errors = append(b.Errors, errs...)
 	if len(parts) == 0 {
 		return nil
@@ -1017,10 +1152,11 @@ func (b *Builder) checkParams(params *[]models.Param) {
 		if param.Id == lex.KND_SELF || param.Type.Token.Id != lex.ID_NA {
 			continue
 		}
-		if param.Token.Id == lex.ID_NA {
+		if param.Token.Id != lex.ID_IDENT {
 			b.pusherr(param.Token, "missing_type")
 		} else {
 			param.Type.Token = param.Token
+			param.Type.Span = param.Token.Span()
 			param.Type.Id = juletype.ID
 			param.Type.Kind = param.Type.Token.Kind
 			param.Type.Original = param.Type
@@ -1097,6 +1233,7 @@ func (b *Builder) paramType(param *models.Param, toks []lex.Token, mustPure bool
 func (b *Builder) pushParam(params *[]models.Param, toks []lex.Token, mustPure bool) {
 	var param models.Param
 	param.Token = toks[0]
+	param.Span = lex.SpanOf(toks)
 	if param.Token.Id == lex.ID_MUT {
 		param.Mutable = true
 		if len(toks) == 1 {
@@ -1161,11 +1298,16 @@ func (b *Builder) fnMultiTypeRet(toks []lex.Token, i *int) (t models.RetType, ok
 		}
 		t.Identifiers = append(t.Identifiers, param.Token)
 	}
-	if len(types) > 1 {
+	switch {
+	case len(types) > 1:
 		t.Type.MultiTyped = true
 		t.Type.Tag = types
-	} else {
+	case len(types) == 1:
 		t.Type = types[0]
+	default:
+		b.pusherr(tok, "missing_type")
+		t.Type.Id = juletype.VOID
+		t.Type.Kind = juletype.TYPE_MAP[t.Type.Id]
 	}
 	// Decrament for correct block parsing
 	*i--
@@ -1196,16 +1338,23 @@ func (b *Builder) FnRetDataType(toks []lex.Token, i *int) (t models.RetType, ok
 			return
 		}
 		*i++
+		start := *i
 		tok = toks[*i]
 		if tok.Id == lex.ID_BRACE {
 			switch tok.Kind {
 			case lex.KND_LPAREN:
-				return b.fnMultiTypeRet(toks, i)
+				t, ok = b.fnMultiTypeRet(toks, i)
+				t.Span = tokspan(toks, start, *i)
+				if t.Type.MultiTyped {
+					t.Type.Span = t.Span
+				}
+				return
 			case lex.KND_LBRACE:
 				return
 			}
 		}
 		t.Type, ok = b.DataType(toks, i, true)
+		t.Span = t.Type.Span
 		return
 	}
 	*i++
@@ -1224,8 +1373,17 @@ func (b *Builder) pushStToBlock(bs *block_st) {
 		}
 		bs.toks = bs.toks[:len(bs.toks)-1]
 	}
+	errors, attributed := len(b.Errors), b.attributed
+	lex_attributed := b.lex_attributed
+	toks := bs.toks
 	s := b.St(bs)
-	if s.Data == nil {
+	s.Poisoned = b.poison(errors, attributed)
+	if b.poison_lex(toks, lex_attributed) {
+		b.drop_errors(errors, attributed)
+		s.Poisoned = true
+	}
+	// Poisoned statements are kept for skipping checks of block.
+	if s.Data == nil && !s.Poisoned {
 		return
 	}
 	s.WithTerminator = bs.terminated
@@ -1240,6 +1398,9 @@ func setToNextSt(bs *block_st) {
 	}
 	*bs.srcToks = (*bs.srcToks)[bs.pos:]
 	bs.pos, bs.terminated = NextStPos(*bs.srcToks, 0)
+	if n := SyncPos((*bs.srcToks)[:bs.pos], false); n != -1 {
+		bs.pos, bs.terminated = n, false
+	}
 	if bs.terminated {
 		bs.toks = (*bs.srcToks)[:bs.pos-1]
 	} else {
@@ -1254,6 +1415,7 @@ func blockStFinished(bs *block_st) bool {
 // Block builds AST model of statements of code block.
 func (b *Builder) Block(toks []lex.Token) (block *models.Block) {
 	block = new(models.Block)
+	block.Span = lex.SpanOf(toks)
 	var bs block_st
 	bs.block = block
 	bs.srcToks = &toks
@@ -1304,6 +1466,7 @@ func (b *Builder) St(bs *block_st) (s models.Statement) {
 	case lex.ID_TYPE:
 		t := b.TypeAlias(bs.toks)
 		s.Token = t.Token
+		s.Span = t.Span
 		s.Data = t
 		return
 	case lex.ID_MATCH:
@@ -1326,6 +1489,7 @@ func (b *Builder) blockSt(toks []lex.Token) models.Statement {
 	is_unsafe := false
 	is_deferred := false
 	tok := toks[0]
+	first := tok
 	if tok.Id == lex.ID_UNSAFE {
 		is_unsafe = true
 		toks = toks[1:]
@@ -1352,6 +1516,7 @@ func (b *Builder) blockSt(toks []lex.Token) models.Statement {
 	}
 
 	i := 0
+	braced := toks
 	toks = Range(&i, lex.KND_LBRACE, lex.KND_RBRACE, toks)
 	if len(toks) == 0 {
 		b.pusherr(tok, "invalid_syntax")
@@ -1360,9 +1525,14 @@ func (b *Builder) blockSt(toks []lex.Token) models.Statement {
 		b.pusherr(toks[i], "invalid_syntax")
 	}
 	block := b.Block(toks)
+	block.Span = rangespan(braced, i, toks)
 	block.IsUnsafe = is_unsafe
 	block.Deferred = is_deferred
-	return models.Statement{Token: tok, Data: block}
+	return models.Statement{
+		Token: tok,
+		Span:  first.Span().To(block.Span),
+		Data:  block,
+	}
 }
 
 func (b *Builder) assignInfo(toks []lex.Token) (info AssignInfo) {
@@ -1408,9 +1578,11 @@ func (b *Builder) assignInfo(toks []lex.Token) (info AssignInfo) {
 }
 
 func (b *Builder) build_assign_left(toks []lex.Token) (l models.AssignLeft) {
+	l.Span = lex.SpanOf(toks)
 	l.Expr.Tokens = toks
 	if l.Expr.Tokens[0].Id == lex.ID_IDENT {
 		l.Var.Token = l.Expr.Tokens[0]
+		l.Var.Span = l.Span
 		l.Var.Id = l.Var.Token.Kind
 	}
 	l.Expr = b.Expr(l.Expr.Tokens)
@@ -1427,7 +1599,7 @@ func (b *Builder) assignLefts(parts [][]lex.Token) []models.AssignLeft {
 }
 
 func (b *Builder) assignExprs(toks []lex.Token) []models.Expr {
-	parts, errs := Parts(toks, lex.ID_COMMA, true)
+	parts, errs := Parts(b.env, toks, lex.ID_COMMA, true)
 	if len(errs) > 0 {
 		b.Errors = append(b.Errors, errs...)
 		return nil
@@ -1446,6 +1618,7 @@ func (b *Builder) AssignSt(toks []lex.Token) (s models.Statement, _ bool) {
 		return
 	}
 	s.Token = toks[0]
+	s.Span = assign.Span
 	s.Data = assign
 	return s, true
 }
@@ -1457,10 +1630,12 @@ func (b *Builder) AssignExpr(toks []lex.Token) (assign models.Assign, ok bool) {
 	}
 	switch toks[0].Id {
 	case lex.ID_LET:
-		return b.letDeclAssign(toks)
+		assign, ok = b.letDeclAssign(toks)
 	default:
-		return b.plainAssign(toks)
+		assign, ok = b.plainAssign(toks)
 	}
+	assign.Span = lex.SpanOf(toks)
+	return
 }
 
 func (b *Builder) letDeclAssign(toks []lex.Token) (assign models.Assign, ok bool) {
@@ -1484,7 +1659,7 @@ func (b *Builder) letDeclAssign(toks []lex.Token) (assign models.Assign, ok bool
 		i++
 		assign.Right = b.assignExprs(toks[i:])
 	}
-	parts, errs := Parts(rang, lex.ID_COMMA, true)
+	parts, errs := Parts(b.env, rang, lex.ID_COMMA, true)
 	if len(errs) > 0 {
 		b.Errors = append(b.Errors, errs...)
 		return
@@ -1503,7 +1678,15 @@ func (b *Builder) letDeclAssign(toks []lex.Token) (assign models.Assign, ok bool
 				continue
 			}
 		}
+		if p[0].Id != lex.ID_IDENT {
+			b.pusherr(p[0], "invalid_syntax")
+			continue
+		}
 		l := b.build_assign_left(p)
+		if mutable {
+			l.Span = tok.Span().To(l.Span)
+			l.Var.Span = l.Span
+		}
 		l.Var.Mutable = mutable
 		l.Var.New = !juleapi.IsIgnoreId(l.Var.Id)
 		l.Var.SetterTok = assign.Setter
@@ -1519,7 +1702,7 @@ func (b *Builder) plainAssign(toks []lex.Token) (assign models.Assign, ok bool)
 	}
 	ok = true
 	assign.Setter = info.Setter
-	parts, errs := Parts(info.Left, lex.ID_COMMA, true)
+	parts, errs := Parts(b.env, info.Left, lex.ID_COMMA, true)
 	if len(errs) > 0 {
 		b.Errors = append(b.Errors, errs...)
 		return
@@ -1548,13 +1731,15 @@ func (b *Builder) IdSt(bs *block_st) (s models.Statement, ok bool) {
 func (b *Builder) LabelSt(bs *block_st) models.Statement {
 	var l models.Label
 	l.Token = bs.toks[0]
+	l.Span = lex.SpanOf(bs.toks[:2])
 	l.Label = l.Token.Kind
 	if len(bs.toks) > 2 {
 		bs.nextToks = bs.toks[2:]
 	}
 	return models.Statement{
 		Token: l.Token,
-		Data: l,
+		Span:  l.Span,
+		Data:  l,
 	}
 }
 
@@ -1563,8 +1748,10 @@ func (b *Builder) ExprSt(bs *block_st) models.Statement {
 	expr := models.ExprStatement{
 		Expr: b.Expr(bs.toks),
 	}
+	expr.Span = expr.Expr.Span
 	return models.Statement{
 		Token: bs.toks[0],
+		Span:  expr.Span,
 		Data:  expr,
 	}
 }
@@ -1572,6 +1759,7 @@ func (b *Builder) ExprSt(bs *block_st) models.Statement {
 // Args builds AST model of arguments.
 func (b *Builder) Args(toks []lex.Token, targeting bool) *models.Args {
 	args := new(models.Args)
+	args.Span = lex.SpanOf(toks)
 	last := 0
 	brace_n := 0
 	for i, tok := range toks {
@@ -1608,6 +1796,7 @@ func (b *Builder) pushArg(args *models.Args, targeting bool, toks []lex.Token, e
 	}
 	var arg models.Arg
 	arg.Token = toks[0]
+	arg.Span = lex.SpanOf(toks)
 	if targeting && arg.Token.Id == lex.ID_IDENT {
 		if len(toks) > 1 {
 			tok := toks[1]
@@ -1628,7 +1817,7 @@ func (b *Builder) varBegin(v *models.Var, i *int, toks []lex.Token) {
 	case lex.ID_LET:
 		// Initialize 1 for skip the let keyword
 		*i++
-		if toks[*i].Id == lex.ID_MUT {
+		if *i < len(toks) && toks[*i].Id == lex.ID_MUT {
 			v.Mutable = true
 			// Skip the mut keyword
 			*i++
@@ -1693,6 +1882,7 @@ func (b *Builder) varTypeNExpr(v *models.Var, toks []lex.Token, i int, expr bool
 func (b *Builder) Var(toks []lex.Token, begin, expr bool) (v models.Var) {
 	v.Pub = b.pub
 	b.pub = false
+	v.Span = lex.SpanOf(toks)
 	i := 0
 	v.Token = toks[i]
 	if begin {
@@ -1725,20 +1915,22 @@ func (b *Builder) Var(toks []lex.Token, begin, expr bool) (v models.Var) {
 func (b *Builder) VarSt(bs *block_st, expr bool) models.Statement {
 	v := b.Var(bs.toks, true, expr)
 	v.Owner = bs.block
-	return models.Statement{Token: v.Token, Data: v}
+	return models.Statement{Token: v.Token, Span: v.Span, Data: v}
 }
 
 // CommentSt builds AST model of comment statement.
 func (b *Builder) CommentSt(tok lex.Token) (s models.Statement) {
 	s.Token = tok
+	s.Span = tok.Span()
 	tok.Kind = strings.TrimSpace(tok.Kind[2:])
-	s.Data = models.Comment{Content: tok.Kind}
+	s.Data = models.Comment{Token: tok, Span: s.Span, Content: tok.Kind}
 	return
 }
 
 func (b *Builder) ConcurrentCallSt(toks []lex.Token) (s models.Statement) {
 	var cc models.ConcurrentCall
 	cc.Token = toks[0]
+	cc.Span = lex.SpanOf(toks)
 	toks = toks[1:]
 	if len(toks) == 0 {
 		b.pusherr(cc.Token, "missing_expr")
@@ -1749,23 +1941,27 @@ func (b *Builder) ConcurrentCallSt(toks []lex.Token) (s models.Statement) {
 	}
 	cc.Expr = b.Expr(toks)
 	s.Token = cc.Token
+	s.Span = cc.Span
 	s.Data = cc
 	return
 }
 
 func (b *Builder) Fallthrough(toks []lex.Token) (s models.Statement) {
 	s.Token = toks[0]
+	s.Span = lex.SpanOf(toks)
 	if len(toks) > 1 {
 		b.pusherr(toks[1], "invalid_syntax")
 	}
 	s.Data = models.Fallthrough{
 		Token: s.Token,
+		Span:  s.Span,
 	}
 	return
 }
 
 func (b *Builder) GotoSt(toks []lex.Token) (s models.Statement) {
 	s.Token = toks[0]
+	s.Span = lex.SpanOf(toks)
 	if len(toks) == 1 {
 		b.pusherr(s.Token, "missing_goto_label")
 		return
@@ -1779,6 +1975,7 @@ func (b *Builder) GotoSt(toks []lex.Token) (s models.Statement) {
 	}
 	var gt models.Goto
 	gt.Token = s.Token
+	gt.Span = s.Span
 	gt.Label = idTok.Kind
 	s.Data = gt
 	return
@@ -1788,11 +1985,13 @@ func (b *Builder) GotoSt(toks []lex.Token) (s models.Statement) {
 func (b *Builder) RetSt(toks []lex.Token) models.Statement {
 	var ret models.Ret
 	ret.Token = toks[0]
+	ret.Span = lex.SpanOf(toks)
 	if len(toks) > 1 {
 		ret.Expr = b.Expr(toks[1:])
 	}
 	return models.Statement{
 		Token: ret.Token,
+		Span:  ret.Span,
 		Data:  ret,
 	}
 }
@@ -1800,11 +1999,12 @@ func (b *Builder) RetSt(toks []lex.Token) models.Statement {
 func (b *Builder) getWhileIterProfile(toks []lex.Token) models.IterWhile {
 	return models.IterWhile{
 		Expr: b.Expr(toks),
+		Span: lex.SpanOf(toks),
 	}
 }
 
 func (b *Builder) getForeachVarsToks(toks []lex.Token) [][]lex.Token {
-	vars, errs := Parts(toks, lex.ID_COMMA, true)
+	vars, errs := Parts(b.env, toks, lex.ID_COMMA, true)
 	b.Errors = append(b.Errors, errs...)
 	return vars
 }
@@ -1814,6 +2014,7 @@ func (b *Builder) getVarProfile(toks []lex.Token) (v models.Var) {
 		return
 	}
 	v.Token = toks[0]
+	v.Span = lex.SpanOf(toks)
 	if v.Token.Id == lex.ID_MUT {
 		v.Mutable = true
 		if len(toks) == 1 {
@@ -1914,7 +2115,9 @@ func (b *Builder) getIterProfile(toks []lex.Token, errtok lex.Token) models.Iter
 		case lex.ID_IN:
 			varToks := toks[:i]
 			exprToks := toks[i+1:]
-			return b.getForeachIterProfile(varToks, exprToks, tok)
+			foreach := b.getForeachIterProfile(varToks, exprToks, tok)
+			foreach.Span = lex.SpanOf(toks)
+			return foreach
 		}
 	}
 	return b.getWhileIterProfile(toks)
@@ -1937,6 +2140,7 @@ func (b *Builder) getWhileNextIterProfile(bs *block_st) (s models.Statement) {
 	profile := models.IterWhile{}
 	if len(bs.toks) > 0 {
 		profile.Expr = b.Expr(bs.toks)
+		profile.Span = profile.Expr.Span
 	}
 	if blockStFinished(bs) {
 		b.pusherr(iter.Token, "invalid_syntax")
@@ -1946,6 +2150,7 @@ func (b *Builder) getWhileNextIterProfile(bs *block_st) (s models.Statement) {
 	st_toks := BlockExpr(bs.toks)
 	if len(st_toks) > 0 {
 		profile.Next = b.next_st(st_toks)
+		profile.Span = profile.Span.To(profile.Next.Span)
 	}
 	i := len(st_toks)
 	blockToks := b.getrange(&i, lex.KND_LBRACE, lex.KND_RBRACE, &bs.toks)
@@ -1957,8 +2162,10 @@ func (b *Builder) getWhileNextIterProfile(bs *block_st) (s models.Statement) {
 		b.pusherr(bs.toks[i], "invalid_syntax")
 	}
 	iter.Block = b.Block(blockToks)
+	iter.Block.Span = rangespan(bs.toks, i, blockToks)
+	iter.Span = iter.Token.Span().To(iter.Block.Span)
 	iter.Profile = profile
-	return models.Statement{Token: iter.Token, Data: iter}
+	return models.Statement{Token: iter.Token, Span: iter.Span, Data: iter}
 }
 
 func (b *Builder) commonIterProfile(toks []lex.Token) (s models.Statement) {
@@ -1983,7 +2190,9 @@ func (b *Builder) commonIterProfile(toks []lex.Token) (s models.Statement) {
 		b.pusherr(toks[i], "invalid_syntax")
 	}
 	iter.Block = b.Block(blockToks)
-	return models.Statement{Token: iter.Token, Data: iter}
+	iter.Block.Span = rangespan(toks, i, blockToks)
+	iter.Span = iter.Token.Span().To(iter.Block.Span)
+	return models.Statement{Token: iter.Token, Span: iter.Span, Data: iter}
 }
 
 func (b *Builder) IterExpr(bs *block_st) models.Statement {
@@ -1993,7 +2202,8 @@ func (b *Builder) IterExpr(bs *block_st) models.Statement {
 	return b.commonIterProfile(bs.toks)
 }
 
-func (b *Builder) caseexprs(toks *[]lex.Token, caseIsDefault bool) []models.Expr {
+func (b *Builder) caseexprs(c *models.Case, toks *[]lex.Token) []models.Expr {
+	caseIsDefault := c.Token.Id == lex.ID_DEFAULT
 	var exprs []models.Expr
 	pushExpr := func(toks []lex.Token, tok lex.Token) {
 		if caseIsDefault {
@@ -2034,7 +2244,11 @@ func (b *Builder) caseexprs(toks *[]lex.Token, caseIsDefault bool) []models.Expr
 			return exprs
 		}
 	}
-	b.pusherr((*toks)[0], "invalid_syntax")
+	if len(*toks) == 0 {
+		b.pusherr(c.Token, "invalid_syntax")
+	} else {
+		b.pusherr((*toks)[0], "invalid_syntax")
+	}
 	*toks = nil
 	return nil
 }
@@ -2067,10 +2281,12 @@ func (b *Builder) caseblock(toks *[]lex.Token) *models.Block {
 
 func (b *Builder) getcase(toks *[]lex.Token) models.Case {
 	var c models.Case
+	case_toks := *toks
 	c.Token = (*toks)[0]
 	*toks = (*toks)[1:]
-	c.Exprs = b.caseexprs(toks, c.Token.Id == lex.ID_DEFAULT)
+	c.Exprs = b.caseexprs(&c, toks)
 	c.Block = b.caseblock(toks)
+	c.Span = lex.SpanOf(case_toks[:len(case_toks)-len(*toks)])
 	return c
 }
 
@@ -2114,6 +2330,8 @@ func (b *Builder) MatchCase(toks []lex.Token) (s models.Statement) {
 		b.pusherr(m.Token, "body_not_exist")
 		return
 	}
+	m.Span = m.Token.Span().To(rangespan(toks, i, blockToks))
+	s.Span = m.Span
 	m.Cases, m.Default = b.cases(blockToks)
 	for i := range m.Cases {
 		c := &m.Cases[i]
@@ -2148,6 +2366,8 @@ func (b *Builder) if_expr(bs *block_st) *models.If {
 		b.pusherr(model.Token, "body_not_exist")
 		return nil
 	}
+	block_span := rangespan(bs.toks, i, blockToks)
+	model.Span = model.Token.Span().To(block_span)
 	if i < len(bs.toks) {
 		if bs.toks[i].Id == lex.ID_ELSE {
 			bs.nextToks = bs.toks[i:]
@@ -2157,6 +2377,7 @@ func (b *Builder) if_expr(bs *block_st) *models.If {
 	}
 	model.Expr = b.Expr(exprToks)
 	model.Block = b.Block(blockToks)
+	model.Block.Span = block_span
 	return model
 }
 
@@ -2178,6 +2399,8 @@ func (b *Builder) conditional_default(bs *block_st) *models.Else {
 		b.pusherr(bs.toks[i], "invalid_syntax")
 	}
 	model.Block = b.Block(blockToks)
+	model.Block.Span = rangespan(bs.toks, i, blockToks)
+	model.Span = model.Token.Span().To(model.Block.Span)
 	return model
 }
 
@@ -2200,9 +2423,14 @@ node:
 	setToNextSt(bs)
 	if bs.toks[0].Id == lex.ID_ELSE {
 		if len(bs.toks) > 1 && bs.toks[1].Id == lex.ID_IF {
+			else_tok := bs.toks[0]
 			bs.toks = bs.toks[1:] // Remove else token
 			elif := b.if_expr(bs)
-			c.Elifs = append(c.Elifs, elif)
+			// Errors of else-if are reported, it is not appended.
+			if elif != nil {
+				elif.Span = else_tok.Span().To(elif.Span)
+				c.Elifs = append(c.Elifs, elif)
+			}
 			goto node
 		}
 		c.Default = b.conditional_default(bs)
@@ -2212,6 +2440,14 @@ node:
 	}
 
 end:
+	c.Span = c.If.Span
+	for _, elif := range c.Elifs {
+		c.Span = c.Span.To(elif.Span)
+	}
+	if c.Default != nil {
+		c.Span = c.Span.To(c.Default.Span)
+	}
+	s.Span = c.Span
 	s.Data = c
 	return
 }
@@ -2220,6 +2456,7 @@ end:
 func (b *Builder) BreakSt(toks []lex.Token) models.Statement {
 	var breakAST models.Break
 	breakAST.Token = toks[0]
+	breakAST.Span = lex.SpanOf(toks)
 	if len(toks) > 1 {
 		if toks[1].Id != lex.ID_IDENT {
 			b.pusherr(toks[1], "invalid_syntax")
@@ -2232,6 +2469,7 @@ func (b *Builder) BreakSt(toks []lex.Token) models.Statement {
 	}
 	return models.Statement{
 		Token: breakAST.Token,
+		Span:  breakAST.Span,
 		Data:  breakAST,
 	}
 }
@@ -2240,6 +2478,7 @@ func (b *Builder) BreakSt(toks []lex.Token) models.Statement {
 func (b *Builder) ContinueSt(toks []lex.Token) models.Statement {
 	var continueAST models.Continue
 	continueAST.Token = toks[0]
+	continueAST.Span = lex.SpanOf(toks)
 	if len(toks) > 1 {
 		if toks[1].Id != lex.ID_IDENT {
 			b.pusherr(toks[1], "invalid_syntax")
@@ -2250,13 +2489,14 @@ func (b *Builder) ContinueSt(toks []lex.Token) models.Statement {
 			}
 		}
 	}
-	return models.Statement{Token: continueAST.Token, Data:  continueAST}
+	return models.Statement{Token: continueAST.Token, Span: continueAST.Span, Data:  continueAST}
 }
 
 // Expr builds AST model of expression.
 func (b *Builder) Expr(toks []lex.Token) (e models.Expr) {
 	e.Op = b.build_expr_op(toks)
 	e.Tokens = toks
+	e.Span = lex.SpanOf(toks)
 	return
 }
 
@@ -2265,12 +2505,15 @@ func (b *Builder) build_binop_expr(toks []lex.Token) any {
 	if i != -1 {
 		return b.build_binop(toks)
 	}
-	return models.BinopExpr{Tokens: toks}
+	return models.BinopExpr{Tokens: toks, Span: lex.SpanOf(toks)}
 }
 
 func (b *Builder) build_binop(toks []lex.Token) models.Binop {
-	op := models.Binop{}
+	op := models.Binop{Span: lex.SpanOf(toks)}
 	i := b.find_lowest_precedenced_operator(toks)
+	if i == 0 || i+1 == len(toks) {
+		b.pusherr(toks[i], "missing_expr")
+	}
 	op.L = b.build_binop_expr(toks[:i])
 	op.R = b.build_binop_expr(toks[i+1:])
 	op.Op = toks[i]
@@ -2363,6 +2606,10 @@ func (b *Builder) skipSt(i *int, toks *[]lex.Token) []lex.Token {
 	start := *i
 	*i, _ = NextStPos(*toks, start)
 	stoks := (*toks)[start:*i]
+	if n := SyncPos(stoks, true); n != -1 {
+		*i = start + n
+		stoks = stoks[:n]
+	}
 	if stoks[len(stoks)-1].Id == lex.ID_SEMICOLON {
 		if len(stoks) == 1 {
 			return b.skipSt(i, toks)
@@ -5,6 +5,7 @@ import "github.com/julelang/jule/lex"
 // Arg is AST model of argument.
 type Arg struct {
 	Token    lex.Token
+	Span     lex.Span
 	TargetId string
 	Expr     Expr
 	CastType *Type
@@ -1,5 +1,7 @@
 package models
 
+import "github.com/julelang/jule/lex"
+
 // Argument base.
 type Args struct {
 	Src                      []Arg
@@ -7,4 +9,5 @@ type Args struct {
 	Generics                 []Type
 	DynamicGenericAnnotation bool
 	NeedsPureType            bool
+	Span                     lex.Span
 }
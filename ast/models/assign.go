@@ -12,6 +12,7 @@ type AssignLeft struct {
 	Var    Var
 	Expr   Expr
 	Ignore bool
+	Span   lex.Span
 }
 
 func (as AssignLeft) String() string {
@@ -27,6 +28,7 @@ func (as AssignLeft) String() string {
 // Assign is assignment AST model.
 type Assign struct {
 	Setter      lex.Token
+	Span        lex.Span
 	Left        []AssignLeft
 	Right       []Expr
 	IsExpr      bool
@@ -5,6 +5,7 @@ import "github.com/julelang/jule/lex"
 // Attribute is attribtue AST model.
 type Attribute struct {
 	Token lex.Token
+	Span  lex.Span
 	Tag   string
 }
 
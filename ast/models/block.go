@@ -2,7 +2,8 @@ package models
 
 import (
 	"strings"
-	"sync/atomic"
+
+	"github.com/julelang/jule/lex"
 )
 
 // Block is code block.
@@ -17,24 +18,27 @@ type Block struct {
 	// If block is the root block, has all labels and gotos of all sub blocks.
 	Gotos  *Gotos
 	Labels *Labels
+	Span   lex.Span
 }
 
-func (b Block) String() string {
-	AddIndent()
+func (b Block) String(w *Writer) string {
+	w.AddIndent()
 	s := ""
 	if b.Deferred {
 		s = "__JULEC_DEFER("
-	}
-	s += ParseBlock(b)
-	DoneIndent()
-	if b.Deferred {
+		w.enter_macro()
+		s += ParseBlock(b, w)
+		w.exit_macro()
 		s += ");"
+	} else {
+		s += ParseBlock(b, w)
 	}
+	w.DoneIndent()
 	return s
 }
 
 // ParseBlock to cpp.
-func ParseBlock(b Block) string {
+func ParseBlock(b Block, w *Writer) string {
 	// Space count per indent.
 	var cpp strings.Builder
 	cpp.WriteByte('{')
@@ -43,32 +47,14 @@ func ParseBlock(b Block) string {
 			continue
 		}
 		cpp.WriteByte('\n')
-		cpp.WriteString(IndentString())
-		cpp.WriteString(s.String())
+		cpp.WriteString(w.IndentString())
+		cpp.WriteString(w.LineDirective(s.Token))
+		cpp.WriteString(w.TraceAt(s.Token))
+		cpp.WriteString(s.String(w))
 	}
 	cpp.WriteByte('\n')
-	indent := strings.Repeat(Indentation, int(Indent-1))
+	indent := strings.Repeat(w.Indentation, w.indent-1)
 	cpp.WriteString(indent)
 	cpp.WriteByte('}')
 	return cpp.String()
 }
-
-// Indent is indention count.
-// This should be manuplate atomic.
-var Indent uint32 = 0
-
-// Indentation.
-var Indentation = "\t"
-
-// IndentString returns indent space of current block.
-func IndentString() string {
-	return strings.Repeat(Indentation, int(Indent))
-}
-
-// AddIndent adds new indent to IndentString.
-func AddIndent() { atomic.AddUint32(&Indent, 1) }
-
-// DoneIndent removes last indent from IndentString.
-func DoneIndent() {
-	atomic.SwapUint32(&Indent, atomic.LoadUint32(&Indent)-1)
-}
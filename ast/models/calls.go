@@ -8,9 +8,13 @@ import (
 // ConcurrentCall is the AST model of concurrent calls.
 type ConcurrentCall struct {
 	Token  lex.Token
+	Span   lex.Span
 	Expr Expr
 }
 
-func (cc ConcurrentCall) String() string {
-	return juleapi.ToConcurrentCall(cc.Expr.String())
+func (cc ConcurrentCall) String(w *Writer) string {
+	w.enter_macro()
+	expr := cc.Expr.String()
+	w.exit_macro()
+	return juleapi.ToConcurrentCall(expr)
 }
@@ -9,6 +9,7 @@ import (
 // Comment is the AST model of just comment lines.
 type Comment struct {
 	Token   lex.Token
+	Span    lex.Span
 	Content string
 }
 
@@ -9,29 +9,31 @@ import (
 // If is the AST model of if expression.
 type If struct {
 	Token lex.Token
+	Span  lex.Span
 	Expr  Expr
 	Block *Block
 }
 
-func (i If) String() string {
+func (i If) String(w *Writer) string {
 	var cpp strings.Builder
 	cpp.WriteString("if (")
 	cpp.WriteString(i.Expr.String())
 	cpp.WriteString(") ")
-	cpp.WriteString(i.Block.String())
+	cpp.WriteString(i.Block.String(w))
 	return cpp.String()
 }
 
 // Else is the AST model of else blocks.
 type Else struct {
 	Token lex.Token
+	Span  lex.Span
 	Block *Block
 }
 
-func (e Else) String() string {
+func (e Else) String(w *Writer) string {
 	var cpp strings.Builder
 	cpp.WriteString("else ")
-	cpp.WriteString(e.Block.String())
+	cpp.WriteString(e.Block.String(w))
 	return cpp.String()
 }
 
@@ -40,18 +42,19 @@ type Conditional struct {
 	If    *If
 	Elifs []*If
 	Default  *Else
+	Span  lex.Span
 }
 
-func (c Conditional) String() string {
+func (c Conditional) String(w *Writer) string {
 	var cpp strings.Builder
-	cpp.WriteString(c.If.String())
+	cpp.WriteString(c.If.String(w))
 	for _, elif := range c.Elifs {
 		cpp.WriteString(" else ")
-		cpp.WriteString(elif.String())
+		cpp.WriteString(elif.String(w))
 	}
 	if c.Default != nil {
 		cpp.WriteByte(' ')
-		cpp.WriteString(c.Default.String())
+		cpp.WriteString(c.Default.String(w))
 	}
 	return cpp.String()
 }
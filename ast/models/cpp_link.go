@@ -5,23 +5,27 @@ import "github.com/julelang/jule/lex"
 // CppLinkFn is linked function AST model.
 type CppLinkFn struct {
 	Token lex.Token
+	Span  lex.Span
 	Link  *Fn
 }
 
 // CppLinkVar is linked variable AST model.
 type CppLinkVar struct {
 	Token lex.Token
+	Span  lex.Span
 	Link  *Var
 }
 
 // CppLinkStruct is linked structure AST model.
 type CppLinkStruct struct {
 	Token lex.Token
+	Span  lex.Span
 	Link  Struct
 }
 
 // CppLinkAlias is linked type alias AST model.
 type CppLinkAlias struct {
 	Token lex.Token
+	Span  lex.Span
 	Link  TypeAlias
 }
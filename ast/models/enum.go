@@ -10,6 +10,7 @@ import (
 // EnumItem is the AST model of enumerator items.
 type EnumItem struct {
 	Token   lex.Token
+	Span    lex.Span
 	Id      string
 	Expr    Expr
 	ExprTag any
@@ -27,6 +28,7 @@ func (ei EnumItem) String() string {
 type Enum struct {
 	Pub   bool
 	Token   lex.Token
+	Span    lex.Span
 	Id    string
 	Type  Type
 	Items []*EnumItem
@@ -44,20 +46,20 @@ func (e *Enum) ItemById(id string) *EnumItem {
 	return nil
 }
 
-func (e Enum) String() string {
+func (e Enum) String(w *Writer) string {
 	var cpp strings.Builder
 	cpp.WriteString("enum ")
 	cpp.WriteString(juleapi.OutId(e.Id, e.Token.File))
 	cpp.WriteByte(':')
 	cpp.WriteString(e.Type.String())
 	cpp.WriteString(" {\n")
-	AddIndent()
+	w.AddIndent()
 	for _, item := range e.Items {
-		cpp.WriteString(IndentString())
+		cpp.WriteString(w.IndentString())
 		cpp.WriteString(item.String())
 		cpp.WriteString(",\n")
 	}
-	DoneIndent()
+	w.DoneIndent()
 	cpp.WriteString("};")
 	return cpp.String()
 }
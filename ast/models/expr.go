@@ -5,13 +5,15 @@ import "github.com/julelang/jule/lex"
 // Expression AST model for binop.
 type BinopExpr struct {
 	Tokens []lex.Token
+	Span   lex.Span
 }
 
 // Binop is AST model of the binary operation.
 type Binop struct {
-	L  any
-	R  any
-	Op lex.Token
+	L    any
+	R    any
+	Op   lex.Token
+	Span lex.Span
 }
 
 // Expr is AST model of expression.
@@ -19,6 +21,7 @@ type Expr struct {
 	Tokens []lex.Token
 	Op     any
 	Model  IExprModel
+	Span   lex.Span
 }
 
 func (e *Expr) IsNotBinop() bool {
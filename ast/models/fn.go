@@ -13,10 +13,13 @@ type Fn struct {
 	Pub           bool
 	IsUnsafe      bool
 	Token         lex.Token
+	IdTok         lex.Token // Token of identifier, same with Token if anonymous.
+	Span          lex.Span
 	Id            string
 	Generics      []*GenericType
 	Combines      *[][]Type
 	Attributes    []Attribute
+	Allows        []string // Lints of allow pragmas.
 	Params        []Param
 	RetType       RetType
 	Block         *Block
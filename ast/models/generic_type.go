@@ -10,6 +10,7 @@ import (
 // GenericType is the AST model of generic data-type.
 type GenericType struct {
 	Token lex.Token
+	Span  lex.Span
 	Id    string
 }
 
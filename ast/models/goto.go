@@ -15,6 +15,7 @@ type Gotos []*Goto
 // Label is the AST model of labels.
 type Label struct {
 	Token lex.Token
+	Span  lex.Span
 	Label string
 	Index int
 	Used  bool
@@ -28,6 +29,7 @@ func (l Label) String() string {
 // Goto is the AST model of goto statements.
 type Goto struct {
 	Token lex.Token
+	Span  lex.Span
 	Label string
 	Index int
 	Block *Block
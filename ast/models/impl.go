@@ -5,6 +5,7 @@ import "github.com/julelang/jule/lex"
 // Impl is the AST model of impl statement.
 type Impl struct {
 	Base  lex.Token
+	Span  lex.Span
 	Target Type
 	Tree   []Object
 }
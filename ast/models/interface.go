@@ -17,10 +17,15 @@ type Genericable interface {
 
 // IterProfile interface for iteration profiles.
 type IterProfile interface {
-	String(i *Iter) string
+	String(i *Iter, w *Writer) string
 }
 
 // IExprModel for special expression model to cpp string.
 type IExprModel interface {
 	String() string
 }
+
+// IWriterModel for models to cpp string with writer of generation.
+type IWriterModel interface {
+	String(w *Writer) string
+}
@@ -10,6 +10,7 @@ import (
 // Break is the AST model of break statement.
 type Break struct {
 	Token      lex.Token
+	Span       lex.Span
 	LabelToken lex.Token
 	Label      string
 }
@@ -21,6 +22,7 @@ func (b Break) String() string {
 // Continue is the AST model of break statement.
 type Continue struct{
 	Token     lex.Token
+	Span      lex.Span
 	LoopLabel lex.Token
 	Label     string
 }
@@ -32,6 +34,7 @@ func (c Continue) String() string {
 // Iter is the AST model of iterations.
 type Iter struct {
 	Token   lex.Token
+	Span    lex.Span
 	Block   *Block
 	Parent  *Block
 	Profile IterProfile
@@ -66,14 +69,14 @@ func (i *Iter) NextLabel() string {
 	return cpp.String()
 }
 
-func (i *Iter) infinityString() string {
+func (i *Iter) infinityString(w *Writer) string {
 	var cpp strings.Builder
-	indent := IndentString()
+	indent := w.IndentString()
 	begin := i.BeginLabel()
 	cpp.WriteString(begin)
 	cpp.WriteString(":;\n")
 	cpp.WriteString(indent)
-	cpp.WriteString(i.Block.String())
+	cpp.WriteString(i.Block.String(w))
 	cpp.WriteByte('\n')
 	cpp.WriteString(indent)
 	cpp.WriteString(i.NextLabel())
@@ -88,9 +91,9 @@ func (i *Iter) infinityString() string {
 	return cpp.String()
 }
 
-func (i Iter) String() string {
+func (i Iter) String(w *Writer) string {
 	if i.Profile == nil {
-		return i.infinityString()
+		return i.infinityString(w)
 	}
-	return i.Profile.String(&i)
+	return i.Profile.String(&i, w)
 }
@@ -9,15 +9,15 @@ import (
 )
 
 type foreach_setter interface {
-	setup_vars(key_a, key_b Var) string
-	next_steps(ket_a, key_b Var, begin string) string
+	setup_vars(key_a, key_b Var, w *Writer) string
+	next_steps(ket_a, key_b Var, begin string, w *Writer) string
 }
 
 type index_setter struct {}
 
-func (index_setter) setup_vars(key_a, key_b Var) string {
+func (index_setter) setup_vars(key_a, key_b Var, w *Writer) string {
 	var cpp strings.Builder
-	indent := IndentString()
+	indent := w.IndentString()
 	if !juleapi.IsIgnoreId(key_a.Id) {
 		if key_a.New {
 			cpp.WriteString(key_a.String())
@@ -39,9 +39,9 @@ func (index_setter) setup_vars(key_a, key_b Var) string {
 	return cpp.String()
 }
 
-func (index_setter) next_steps(key_a, key_b Var, begin string) string {
+func (index_setter) next_steps(key_a, key_b Var, begin string, w *Writer) string {
 	var cpp strings.Builder
-	indent := IndentString()
+	indent := w.IndentString()
 	cpp.WriteString("++__julec_foreach_begin;\n")
 	cpp.WriteString(indent)
 	cpp.WriteString("if (__julec_foreach_begin != __julec_foreach_end) { ")
@@ -62,9 +62,9 @@ func (index_setter) next_steps(key_a, key_b Var, begin string) string {
 
 type map_setter struct {}
 
-func (map_setter) setup_vars(key_a, key_b Var) string {
+func (map_setter) setup_vars(key_a, key_b Var, w *Writer) string {
 	var cpp strings.Builder
-	indent := IndentString()
+	indent := w.IndentString()
 	if !juleapi.IsIgnoreId(key_a.Id) {
 		if key_a.New {
 			cpp.WriteString(key_a.String())
@@ -86,9 +86,9 @@ func (map_setter) setup_vars(key_a, key_b Var) string {
 	return cpp.String()
 }
 
-func (map_setter) next_steps(key_a, key_b Var, begin string) string {
+func (map_setter) next_steps(key_a, key_b Var, begin string, w *Writer) string {
 	var cpp strings.Builder
-	indent := IndentString()
+	indent := w.IndentString()
 	cpp.WriteString("++__julec_foreach_begin;\n")
 	cpp.WriteString(indent)
 	cpp.WriteString("if (__julec_foreach_begin != __julec_foreach_end) { ")
@@ -113,57 +113,58 @@ type IterForeach struct {
 	InToken    lex.Token
 	Expr     Expr
 	ExprType Type
+	Span     lex.Span
 }
 
-func (f IterForeach) String(i *Iter) string {
+func (f IterForeach) String(i *Iter, w *Writer) string {
 	switch f.ExprType.Id {
 	case juletype.STR, juletype.SLICE, juletype.ARRAY:
-		return f.IterationString(i, index_setter{})
+		return f.IterationString(i, index_setter{}, w)
 	case juletype.MAP:
-		return f.IterationString(i, map_setter{})
+		return f.IterationString(i, map_setter{}, w)
 	}
 	return ""
 }
 
-func (f *IterForeach) IterationString(i *Iter, setter foreach_setter) string {
+func (f *IterForeach) IterationString(i *Iter, setter foreach_setter, w *Writer) string {
 	var cpp strings.Builder
 	cpp.WriteString("{\n")
-	AddIndent()
-	indent := IndentString()
+	w.AddIndent()
+	indent := w.IndentString()
 	cpp.WriteString(indent)
 	cpp.WriteString("auto __julec_foreach_expr = ")
 	cpp.WriteString(f.Expr.String())
 	cpp.WriteString(";\n")
 	cpp.WriteString(indent)
 	cpp.WriteString("if (__julec_foreach_expr.begin() != __julec_foreach_expr.end()) {\n")
-	AddIndent()
-	indent = IndentString()
+	w.AddIndent()
+	indent = w.IndentString()
 	cpp.WriteString(indent)
 	cpp.WriteString("auto __julec_foreach_begin = __julec_foreach_expr.begin();\n")
 	cpp.WriteString(indent)
 	cpp.WriteString("const auto __julec_foreach_end = __julec_foreach_expr.end();\n")
 	cpp.WriteString(indent)
-	cpp.WriteString(setter.setup_vars(f.KeyA, f.KeyB))
+	cpp.WriteString(setter.setup_vars(f.KeyA, f.KeyB, w))
 	begin := i.BeginLabel()
 	cpp.WriteString(begin)
 	cpp.WriteString(":;\n")
 	cpp.WriteString(indent)
-	cpp.WriteString(i.Block.String())
+	cpp.WriteString(i.Block.String(w))
 	cpp.WriteByte('\n')
 	cpp.WriteString(indent)
 	cpp.WriteString(i.NextLabel())
 	cpp.WriteString(":;\n")
 	cpp.WriteString(indent)
-	cpp.WriteString(setter.next_steps(f.KeyA, f.KeyB, begin))
+	cpp.WriteString(setter.next_steps(f.KeyA, f.KeyB, begin, w))
 	cpp.WriteString(indent)
 	cpp.WriteString(i.EndLabel())
 	cpp.WriteString(":;")
 	cpp.WriteByte('\n')
-	DoneIndent()
-	cpp.WriteString(IndentString())
+	w.DoneIndent()
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString("}\n")
-	DoneIndent()
-	cpp.WriteString(IndentString())
+	w.DoneIndent()
+	cpp.WriteString(w.IndentString())
 	cpp.WriteByte('}')
 	return cpp.String()
 }
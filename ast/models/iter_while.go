@@ -1,38 +1,43 @@
 package models
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/julelang/jule/lex"
+)
 
 // IterWhile is while iteration profile.
 type IterWhile struct {
 	Expr Expr
 	Next Statement
+	Span lex.Span
 }
 
-func (w IterWhile) String(i *Iter) string {
+func (iw IterWhile) String(i *Iter, w *Writer) string {
 	var cpp strings.Builder
-	indent := IndentString()
+	indent := w.IndentString()
 	begin := i.BeginLabel()
 	next := i.NextLabel()
 	end := i.EndLabel()
 	cpp.WriteString(begin)
 	cpp.WriteString(":;\n")
 	cpp.WriteString(indent)
-	if !w.Expr.IsEmpty() {
+	if !iw.Expr.IsEmpty() {
 		cpp.WriteString("if (!(")
-		cpp.WriteString(w.Expr.String())
+		cpp.WriteString(iw.Expr.String())
 		cpp.WriteString(")) { goto ")
 		cpp.WriteString(end)
 		cpp.WriteString("; }\n")
 		cpp.WriteString(indent)
 	}
-	cpp.WriteString(i.Block.String())
+	cpp.WriteString(i.Block.String(w))
 	cpp.WriteByte('\n')
 	cpp.WriteString(indent)
 	cpp.WriteString(next)
 	cpp.WriteString(":;\n")
 	cpp.WriteString(indent)
-	if w.Next.Data != nil {
-		cpp.WriteString(w.Next.String())
+	if iw.Next.Data != nil {
+		cpp.WriteString(iw.Next.String(w))
 		cpp.WriteByte('\n')
 		cpp.WriteString(indent)
 	}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/julelang/jule/lex"
+)
+
+// LineDirective returns #line directive of token with indentation
+// for the next line.
+//
+// Special cases are;
+//
+//	w.LineDirective(tok) -> returns empty string if line directives are disabled
+//	w.LineDirective(tok) -> returns empty string if token has not file
+//	w.LineDirective(tok) -> returns empty string if generating macro argument
+func (w *Writer) LineDirective(tok lex.Token) string {
+	if !w.LineDirectives || tok.File == nil || tok.Row < 1 ||
+		w.macro_depth > 0 {
+		return ""
+	}
+	var cpp strings.Builder
+	cpp.WriteString("#line ")
+	cpp.WriteString(strconv.Itoa(tok.Row))
+	cpp.WriteByte(' ')
+	cpp.WriteString(strconv.Quote(tok.File.Path()))
+	cpp.WriteByte('\n')
+	cpp.WriteString(w.IndentString())
+	return cpp.String()
+}
+
+// line_reset is the placeholder of directives that maps lines back
+// to the generated file. Line of generated file is not known
+// until the code of unit is completed.
+const line_reset = "#line __JULEC_GENERATED_LINE__"
+
+// LineReset returns directive with indentation for the next line,
+// which maps following lines back to the generated file.
+// Directive is a placeholder until it is resolved by ResolveLineResets.
+//
+// Special cases are;
+//
+//	w.LineReset() -> returns empty string if line directives are disabled
+//	w.LineReset() -> returns empty string if generating macro argument
+func (w *Writer) LineReset() string {
+	if !w.LineDirectives || w.macro_depth > 0 {
+		return ""
+	}
+	return line_reset + "\n" + w.IndentString()
+}
+
+// ResolveLineResets returns code with #line directives of generated
+// file at path instead of placeholders of LineReset.
+func ResolveLineResets(code string, path string) string {
+	if !strings.Contains(code, line_reset) {
+		return code
+	}
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != line_reset {
+			continue
+		}
+		indent := line[:len(line)-len(trimmed)]
+		// Directive sets line number of the next line.
+		lines[i] = indent + "#line " + strconv.Itoa(i+2) + " " + strconv.Quote(path)
+	}
+	return strings.Join(lines, "\n")
+}
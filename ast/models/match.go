@@ -9,6 +9,7 @@ import (
 
 type Fallthrough struct {
 	Token lex.Token
+	Span  lex.Span
 	Case  *Case
 }
 
@@ -23,6 +24,7 @@ func (f Fallthrough) String() string {
 // Case the AST model of case.
 type Case struct {
 	Token lex.Token
+	Span  lex.Span
 	Exprs []Expr
 	Block *Block
 	Match *Match
@@ -47,7 +49,7 @@ func (c *Case) EndLabel() string {
 	return cpp.String()
 }
 
-func (c *Case) String(matchExpr string) string {
+func (c *Case) String(matchExpr string, w *Writer) string {
 	endlabel := c.EndLabel()
 	var cpp strings.Builder
 	if len(c.Exprs) > 0 {
@@ -67,19 +69,19 @@ func (c *Case) String(matchExpr string) string {
 		cpp.WriteString("; }\n")
 	}
 	if len(c.Block.Tree) > 0 {
-		cpp.WriteString(IndentString())
+		cpp.WriteString(w.IndentString())
 		cpp.WriteString(c.BeginLabel())
 		cpp.WriteString(":;\n")
-		cpp.WriteString(IndentString())
-		cpp.WriteString(c.Block.String())
+		cpp.WriteString(w.IndentString())
+		cpp.WriteString(c.Block.String(w))
 		cpp.WriteByte('\n')
-		cpp.WriteString(IndentString())
+		cpp.WriteString(w.IndentString())
 		cpp.WriteString("goto ")
 		cpp.WriteString(c.Match.EndLabel())
 		cpp.WriteString(";")
 		cpp.WriteByte('\n')
 	}
-	cpp.WriteString(IndentString())
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString(endlabel)
 	cpp.WriteString(":;")
 	return cpp.String()
@@ -88,59 +90,60 @@ func (c *Case) String(matchExpr string) string {
 // Match the AST model of match-case.
 type Match struct {
 	Token    lex.Token
+	Span     lex.Span
 	Expr     Expr
 	ExprType Type
 	Default  *Case
 	Cases    []Case
 }
 
-func (m *Match) MatchExprString() string {
+func (m *Match) MatchExprString(w *Writer) string {
 	if len(m.Cases) == 0 {
 		if m.Default != nil {
-			return m.Default.String("")
+			return m.Default.String("", w)
 		}
 		return ""
 	}
 	var cpp strings.Builder
 	cpp.WriteString("{\n")
-	AddIndent()
-	cpp.WriteString(IndentString())
+	w.AddIndent()
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString(m.ExprType.String())
 	cpp.WriteString(" expr{")
 	cpp.WriteString(m.Expr.String())
 	cpp.WriteString("};\n")
-	cpp.WriteString(IndentString())
+	cpp.WriteString(w.IndentString())
 	if len(m.Cases) > 0 {
-		cpp.WriteString(m.Cases[0].String("expr"))
+		cpp.WriteString(m.Cases[0].String("expr", w))
 		for _, c := range m.Cases[1:] {
 			cpp.WriteByte('\n')
-			cpp.WriteString(IndentString())
-			cpp.WriteString(c.String("expr"))
+			cpp.WriteString(w.IndentString())
+			cpp.WriteString(c.String("expr", w))
 		}
 	}
 	if m.Default != nil {
-		cpp.WriteString(m.Default.String(""))
+		cpp.WriteString(m.Default.String("", w))
 	}
 	cpp.WriteByte('\n')
-	DoneIndent()
-	cpp.WriteString(IndentString())
+	w.DoneIndent()
+	cpp.WriteString(w.IndentString())
 	cpp.WriteByte('}')
 	return cpp.String()
 }
 
-func (m *Match) MatchBoolString() string {
+func (m *Match) MatchBoolString(w *Writer) string {
 	var cpp strings.Builder
 	if len(m.Cases) > 0 {
-		cpp.WriteString(m.Cases[0].String(""))
+		cpp.WriteString(m.Cases[0].String("", w))
 		for _, c := range m.Cases[1:] {
 			cpp.WriteByte('\n')
-			cpp.WriteString(IndentString())
-			cpp.WriteString(c.String(""))
+			cpp.WriteString(w.IndentString())
+			cpp.WriteString(c.String("", w))
 		}
 	}
 	if m.Default != nil {
 		cpp.WriteByte('\n')
-		cpp.WriteString(m.Default.String(""))
+		cpp.WriteString(m.Default.String("", w))
 		cpp.WriteByte('\n')
 	}
 	return cpp.String()
@@ -155,15 +158,15 @@ func (m *Match) EndLabel() string {
 	return cpp.String()
 }
 
-func (m Match) String() string {
+func (m Match) String(w *Writer) string {
 	var cpp strings.Builder
 	if m.Expr.Model != nil {
-		cpp.WriteString(m.MatchExprString())
+		cpp.WriteString(m.MatchExprString(w))
 	} else {
-		cpp.WriteString(m.MatchBoolString())
+		cpp.WriteString(m.MatchBoolString(w))
 	}
 	cpp.WriteByte('\n')
-	cpp.WriteString(IndentString())
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString(m.EndLabel())
 	cpp.WriteString(":;")
 	return cpp.String()
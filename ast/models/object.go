@@ -4,6 +4,8 @@ import "github.com/julelang/jule/lex"
 
 // Object is an element of AST.
 type Object struct {
-	Token lex.Token
-	Data  any
+	Token    lex.Token
+	Span     lex.Span
+	Data     any
+	Poisoned bool // Has syntax errors.
 }
@@ -11,6 +11,7 @@ import (
 // Param is function parameter AST model.
 type Param struct {
 	Token    lex.Token
+	Span     lex.Span
 	Id       string
 	Variadic bool
 	Mutable  bool
@@ -11,6 +11,7 @@ import (
 type RetType struct {
 	Type        Type
 	Identifiers []lex.Token
+	Span        lex.Span
 }
 
 func (rt RetType) String() string {
@@ -66,6 +67,7 @@ func (rt *RetType) Vars(owner *Block) []*Var {
 // Ret is return statement AST model.
 type Ret struct {
 	Token lex.Token
+	Span  lex.Span
 	Expr  Expr
 }
 
@@ -10,17 +10,23 @@ import (
 // Statement is statement.
 type Statement struct {
 	Token          lex.Token
+	Span           lex.Span
 	Data           any
 	WithTerminator bool
+	Poisoned       bool // Has syntax errors.
 }
 
-func (s Statement) String() string {
+func (s Statement) String(w *Writer) string {
+	if m, ok := s.Data.(IWriterModel); ok {
+		return m.String(w)
+	}
 	return fmt.Sprint(s.Data)
 }
 
 // ExprStatement is AST model of expression statement in block.
 type ExprStatement struct {
 	Expr Expr
+	Span lex.Span
 }
 
 func (be ExprStatement) String() string {
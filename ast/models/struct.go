@@ -5,6 +5,7 @@ import "github.com/julelang/jule/lex"
 // Struct is the AST model of structures.
 type Struct struct {
 	Token      lex.Token
+	Span       lex.Span
 	Id         string
 	Pub        bool
 	Fields     []*Var
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/julelang/jule/lex"
+)
+
+// TraceFrame is the shadow call stack frame of function.
+// Pushed at entry of function and popped at exit.
+type TraceFrame struct {
+	Token lex.Token
+	Fn    string
+}
+
+func (tf TraceFrame) String() string {
+	var cpp strings.Builder
+	cpp.WriteString("__JULEC_TRACE_FN(")
+	cpp.WriteString(strconv.Quote(tf.Fn))
+	cpp.WriteString(", ")
+	if tf.Token.File != nil {
+		cpp.WriteString(strconv.Quote(tf.Token.File.Path()))
+	} else {
+		cpp.WriteString(`""`)
+	}
+	cpp.WriteString(", ")
+	cpp.WriteString(strconv.Itoa(tf.Token.Row))
+	cpp.WriteString(", ")
+	cpp.WriteString(strconv.Itoa(tf.Token.Column))
+	cpp.WriteString(");")
+	return cpp.String()
+}
+
+// TraceAt returns position update of current shadow call stack frame
+// for statement of token.
+//
+// Special cases are;
+//
+//	w.TraceAt(tok) -> returns empty string if panic traces are disabled
+//	w.TraceAt(tok) -> returns empty string if token has not file
+func (w *Writer) TraceAt(tok lex.Token) string {
+	if !w.PanicTrace || tok.File == nil {
+		return ""
+	}
+	var cpp strings.Builder
+	cpp.WriteString("__JULEC_TRACE_AT(")
+	cpp.WriteString(strconv.Itoa(tok.Row))
+	cpp.WriteString(", ")
+	cpp.WriteString(strconv.Itoa(tok.Column))
+	cpp.WriteString(");")
+	return cpp.String()
+}
@@ -6,6 +6,7 @@ import "github.com/julelang/jule/lex"
 type Trait struct {
 	Pub   bool
 	Token lex.Token
+	Span  lex.Span
 	Id    string
 	Desc  string
 	Used  bool
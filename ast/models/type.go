@@ -24,6 +24,7 @@ type Type struct {
 	// Token used for usually *File comparisons.
 	// For this reason, you don't use token as value, identifier or etc.
 	Token         lex.Token
+	Span          lex.Span
 	Id            uint8
 	Original      any
 	Kind          string
@@ -12,6 +12,7 @@ type TypeAlias struct {
 	Owner   *Block
 	Pub     bool
 	Token   lex.Token
+	Span    lex.Span
 	Id      string
 	Type    Type
 	Desc    string
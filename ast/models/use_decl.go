@@ -5,6 +5,7 @@ import "github.com/julelang/jule/lex"
 // UseDecl is the AST model of use declaration.
 type UseDecl struct {
 	Token      lex.Token
+	Span       lex.Span
 	Path       string
 	Cpp        bool
 	LinkString string
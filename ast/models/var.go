@@ -14,6 +14,7 @@ type Var struct {
 	Pub       bool
 	Mutable   bool
 	Token     lex.Token
+	Span      lex.Span
 	SetterTok lex.Token
 	Id        string
 	Type      Type
@@ -0,0 +1,39 @@
+package models
+
+import "strings"
+
+// Writer is the state of cpp code generation of a compilation.
+// Compilations have own writers, so generations are not share any state.
+type Writer struct {
+	// LineDirectives enables #line directives of generated cpp code.
+	LineDirectives bool
+	// PanicTrace enables shadow call stack of generated cpp code
+	// for Jule-level panic traces.
+	PanicTrace bool
+	// Indentation.
+	Indentation string
+
+	// Indention count.
+	indent int
+	// Count of macro arguments currently generating.
+	// Preprocessor directives are not allowed in macro arguments.
+	macro_depth int
+}
+
+// NewWriter returns new writer with tab indentation.
+func NewWriter() *Writer { return &Writer{Indentation: "\t"} }
+
+// IndentString returns indent space of current block.
+func (w *Writer) IndentString() string {
+	return strings.Repeat(w.Indentation, w.indent)
+}
+
+// AddIndent adds new indent to IndentString.
+func (w *Writer) AddIndent() { w.indent++ }
+
+// DoneIndent removes last indent from IndentString.
+func (w *Writer) DoneIndent() { w.indent-- }
+
+func (w *Writer) enter_macro() { w.macro_depth++ }
+
+func (w *Writer) exit_macro() { w.macro_depth-- }
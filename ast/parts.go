@@ -2,6 +2,7 @@ package ast
 
 import (
 	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/pkg/jule"
 	"github.com/julelang/jule/pkg/julelog"
 )
 
@@ -10,6 +11,7 @@ import (
 // Special case is:
 //  Range(i, open, close, toks) = nil if *i > len(toks)
 //  Range(i, open, close, toks) = bil if (toks[i*]) Id != tokens.Brace && Kind != open
+//  Range(i, open, close, toks) = rest of toks if range is not closed
 func Range(i *int, open, close string, toks []lex.Token) []lex.Token {
 	if *i >= len(toks) {
 		return nil
@@ -33,6 +35,10 @@ func Range(i *int, open, close string, toks []lex.Token) []lex.Token {
 			brace_n--
 		}
 	}
+	// Not closed ranges are reported by lexer.
+	if brace_n != 0 {
+		return toks[start:]
+	}
 	return toks[start : *i-1]
 }
 
@@ -67,11 +73,12 @@ func RangeLast(toks []lex.Token) (cutted, cut []lex.Token) {
 }
 
 // Parts returns parts separated by given token identifier.
-// It's skips parentheses ranges.
+// It's skips parentheses ranges. Errors are built for environment.
 //
-// Special case is;
+// Special cases are;
 //  Parts(toks) = nil if len(toks) == 0
-func Parts(toks []lex.Token, id uint8, exprMust bool) ([][]lex.Token, []julelog.CompilerLog) {
+//  Parts(toks) = parts without empty parts if exprMust
+func Parts(env *jule.Env, toks []lex.Token, id uint8, exprMust bool) ([][]lex.Token, []julelog.CompilerLog) {
 	if len(toks) == 0 {
 		return nil, nil
 	}
@@ -94,9 +101,10 @@ func Parts(toks []lex.Token, id uint8, exprMust bool) ([][]lex.Token, []julelog.
 		}
 		if tok.Id == id {
 			if exprMust && i-last <= 0 {
-				errs = append(errs, compilerErr(tok, "missing_expr"))
+				errs = append(errs, compilerErr(env, tok, "missing_expr"))
+			} else {
+				parts = append(parts, toks[last:i])
 			}
-			parts = append(parts, toks[last:i])
 			last = i + 1
 		}
 	}
@@ -70,3 +70,89 @@ func NextStPos(toks []lex.Token, start int) (int, bool) {
 	}
 	return i, false
 }
+
+func is_balanced(toks []lex.Token) bool {
+	brace_n := 0
+	for _, tok := range toks {
+		if tok.Id != lex.ID_BRACE {
+			continue
+		}
+		switch tok.Kind {
+		case lex.KND_LBRACE, lex.KND_LBRACKET, lex.KND_LPAREN:
+			brace_n++
+		default:
+			brace_n--
+		}
+	}
+	return brace_n == 0
+}
+
+func is_row_begin(toks []lex.Token, i int) bool {
+	return i > 0 && toks[i-1].Row < toks[i].Row
+}
+
+// is_top_sync reports token is a synchronization point of
+// top-level definitions. Definitions are begins at first column.
+func is_top_sync(tok lex.Token) bool {
+	if tok.Column != 1 {
+		return false
+	}
+	switch tok.Id {
+	case lex.ID_FN, lex.ID_STRUCT, lex.ID_IMPL, lex.ID_TRAIT,
+		lex.ID_ENUM, lex.ID_USE, lex.ID_TYPE, lex.ID_PUB, lex.ID_CPP:
+		return true
+	}
+	return false
+}
+
+// is_block_sync reports token is a synchronization point of
+// statements of code blocks.
+func is_block_sync(tok lex.Token) bool {
+	switch tok.Id {
+	case lex.ID_LET, lex.ID_MUT, lex.ID_CONST, lex.ID_RET, lex.ID_IF,
+		lex.ID_ITER, lex.ID_MATCH, lex.ID_BREAK, lex.ID_CONTINUE,
+		lex.ID_GOTO, lex.ID_DEFER, lex.ID_CO, lex.ID_FALLTHROUGH:
+		return true
+	}
+	return false
+}
+
+// SyncPos reports position to resynchronize statement which is
+// has not balanced braces. A statement is broken before a
+// definition keyword at beginning of row, or after a semicolon
+// in a range.
+// For top-level statements, a closing brace at first column is
+// also ends statement.
+//
+// Special case is;
+//
+//	SyncPos(toks, top) -> returns -1 if statement is balanced or there is no synchronization point
+func SyncPos(toks []lex.Token, top bool) int {
+	if is_balanced(toks) {
+		return -1
+	}
+	brace_n := 0
+	for i, tok := range toks {
+		if tok.Id == lex.ID_BRACE {
+			switch tok.Kind {
+			case lex.KND_LBRACE, lex.KND_LBRACKET, lex.KND_LPAREN:
+				brace_n++
+			default:
+				brace_n--
+			}
+		}
+		switch {
+		case tok.Id == lex.ID_SEMICOLON && brace_n != 0:
+			return i + 1
+		case !is_row_begin(toks, i):
+			continue
+		case top && is_top_sync(tok):
+			return i
+		case top && tok.Id == lex.ID_BRACE && tok.Kind == lex.KND_RBRACE && tok.Column == 1:
+			return i + 1
+		case !top && is_block_sync(tok):
+			return i
+		}
+	}
+	return -1
+}
@@ -50,12 +50,14 @@ func (tb *type_builder) op(tok lex.Token) (imret bool) {
 func (tb *type_builder) function(tok lex.Token) {
 	tb.t.Token = tok
 	tb.t.Id = juletype.FN
+	start := *tb.i
 	f, proto_ok := tb.b.fn_prototype(tb.tokens, tb.i, false, true)
 	if !proto_ok {
 		tb.b.pusherr(tok, "invalid_type")
 		return
 	}
 	*tb.i--
+	f.Span = tokspan(tb.tokens, start, *tb.i)
 	tb.t.Tag = &f
 	tb.kind += f.TypeKind()
 	tb.ok = true
@@ -85,6 +87,10 @@ func (tb *type_builder) ident_end() {
 	tb.kind += "["
 	var genericsStr strings.Builder
 	parts := tb.ident_generics()
+	if len(parts) == 0 {
+		tb.b.pusherr(tok, "missing_generics")
+		return
+	}
 	generics := make([]models.Type, len(parts))
 	for i, part := range parts {
 		index := 0
@@ -118,7 +124,7 @@ func (tb *type_builder) ident_generics() [][]lex.Token {
 		}
 	}
 	tokens := tb.tokens[first+1 : *tb.i]
-	parts, errs := Parts(tokens, lex.ID_COMMA, true)
+	parts, errs := Parts(tb.b.env, tokens, lex.ID_COMMA, true)
 	tb.b.Errors = append(tb.b.Errors, errs...)
 	return parts
 }
@@ -208,6 +214,10 @@ func (tb *type_builder) array() (ok bool) {
 		return
 	}
 	_, exprToks := RangeLast(tb.tokens[:exprI])
+	// Size of array is missing.
+	if len(exprToks) < 3 {
+		return false
+	}
 	exprToks = exprToks[1 : len(exprToks)-1]
 	tok := exprToks[0]
 	if len(exprToks) == 1 && tok.Id == lex.ID_OP && tok.Kind == lex.KND_TRIPLE_DOT {
@@ -302,7 +312,10 @@ func (tb *type_builder) step() (imret bool) {
 }
 
 func (tb *type_builder) build() bool {
-	defer func() { tb.t.Original = *tb.t }()
+	defer func() {
+		tb.t.Span = tokspan(tb.tokens, tb.first, *tb.i)
+		tb.t.Original = *tb.t
+	}()
 	tb.first = *tb.i
 	for ; *tb.i < len(tb.tokens); *tb.i++ {
 		imret := tb.step()
@@ -5,46 +5,106 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
-	"time"
 
+	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/compiler"
 	"github.com/julelang/jule/documenter"
 	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/lsp"
 	"github.com/julelang/jule/parser"
 	"github.com/julelang/jule/pkg/jule"
 	"github.com/julelang/jule/pkg/juleapi"
 	"github.com/julelang/jule/pkg/juleio"
+	"github.com/julelang/jule/pkg/julelint"
+	"github.com/julelang/jule/pkg/julelog"
+	"github.com/julelang/jule/pkg/juleset"
 )
 
-const mode_transpile = "transpile"
-const mode_compile = "compile"
-
-const compiler_gcc = "gcc"
-const compiler_clang = "clang"
+const diagnostics_text = "text"
+const diagnostics_json = "json"
+const diagnostics_sarif = "sarif"
 
 const compiler_path_gcc = "g++"
 const compiler_path_clang = "clang++"
 
-var out_dir = "./dist"
-var language = "default"
-var mode = mode_compile
-var out_name = "ir.cpp"
+const default_out_dir = "./dist"
+const default_error_limit = 100
+
+// Sets by command-line inputs, settings file or defaults.
+// Command-line inputs have priority over the settings file.
+var out_dir = ""
+var out_name = ""
+var out_path = ""
+var language = ""
+var mode = ""
+var cpp_compiler = ""
+var cpp_compiler_path = ""
+var cxx_flags []string
+var ld_flags []string
+var libs []string
+var optimization = ""
+var debug_info *bool
+var line_directives *bool
+var panic_trace *bool
+var cpp_std = ""
+var tags []string
+var sanitizers []string
+var library = false // Entry point is not required.
+var target = ""
+var diagnostics = diagnostics_text
+var error_limit *int // Zero for no limit.
+var werror *bool
+
+// States of lints by settings file and command-line.
+// States of command-line are applied in order after the settings file.
+var lint_settings map[string]bool
+var lint_args []lint_arg
+
+// Lints that reported as errors by command-line.
+var lint_errors []string
+
+// Lint configuration of compilations.
+var lints *julelint.Config
+
+type lint_arg struct {
+	name  string
+	state bool
+}
+
+// Colored diagnostics output, enabled if stdout is a terminal.
+var color = is_terminal(os.Stdout) && is_terminal(os.Stderr) && os.Getenv("NO_COLOR") == ""
 
-// Sets by compiler or command-line inputs
-var compiler = ""
-var compiler_path = ""
+// Logs for machine-readable diagnostics output.
+// Printed at exit if diagnostics format is not text.
+var logs []julelog.CompilerLog
+
+// Exit codes.
+const exit_success = 0
+const exit_diagnostics = 1 // Jule source code has errors.
+const exit_usage = 2       // Invalid command-line usage or settings.
+const exit_io = 3          // Read or write failure.
+const exit_backend = 4     // C++ compiler failure.
 
 const CMD_HELP = "help"
 const CMD_VERSION = "version"
 const CMD_DOC = "doc"
 const CMD_BUG = "bug"
 const CMD_TOOL = "tool"
+const CMD_RUN = "run"
+const CMD_CHECK = "check"
+const CMD_EXPLAIN = "explain"
+const CMD_LSP = "lsp"
 
 var HELP_MAP = [...][2]string{
 	{CMD_HELP, "Show help"},
@@ -52,12 +112,16 @@ var HELP_MAP = [...][2]string{
 	{CMD_DOC, "Documentize Jule source code"},
 	{CMD_BUG, "Start a new bug report"},
 	{CMD_TOOL, "Tools for effective Jule"},
+	{CMD_RUN, "Compile and run Jule program"},
+	{CMD_CHECK, "Check Jule source code without compiling"},
+	{CMD_EXPLAIN, "Explain error code"},
+	{CMD_LSP, "Start language server over stdio"},
 }
 
-func help(cmd string) {
+func help(cmd string) int {
 	if cmd != "" {
 		println("This module can only be used as single!")
-		return
+		return exit_usage
 	}
 	max := len(HELP_MAP[0][0])
 	for _, k := range HELP_MAP {
@@ -75,39 +139,271 @@ func help(cmd string) {
 		sb.WriteByte('\n')
 	}
 	println(sb.String()[:sb.Len()-1])
+	return exit_success
 }
 
-func version(cmd string) {
+func version(cmd string) int {
 	if cmd != "" {
 		println("This module can only be used as single!")
-		return
+		return exit_usage
 	}
 	println("julec version", jule.VERSION)
+	return exit_success
 }
 
-func doc(cmd string) {
-	cmd = strings.TrimSpace(cmd)
+// doc documentizes all paths.
+// Returns exit code of last failed path if any path is failed.
+func doc(cmd string) int {
+	code := exit_success
+	cmd = strings.TrimSpace(parse_arguments(cmd))
 	paths := strings.SplitN(cmd, " ", -1)
 	for _, path := range paths {
 		path = strings.TrimSpace(path)
-		p := compile(path, false, true, true)
-		if p == nil {
+		opts, c := load(path)
+		if c != exit_success {
+			code = c
 			continue
 		}
-		if print_logs(p) {
-			fmt.Println(jule.GetError("doc_couldnt_generated", path))
+		opts.Mode = compiler.MODE_CHECK
+		opts.DefsOnly = true
+		r, c := compile(opts)
+		if c != exit_success {
+			code = c
 			continue
 		}
-		docjson, err := documenter.Doc(p)
+		if print_logs(r.Diagnostics) {
+			print_log(julelog.CompilerLog{
+				Type:    julelog.FLAT_ERR,
+				Message: jule.GetError("doc_couldnt_generated", path),
+				Key:     "doc_couldnt_generated",
+				Args:    []any{path},
+			})
+			code = exit_diagnostics
+			continue
+		}
+		docjson, err := documenter.Doc(r.Parser)
 		if err != nil {
 			fmt.Println(jule.GetError("error", err.Error()))
+			code = exit_diagnostics
 			continue
 		}
 		// Remove SrcExt from path
 		path = path[:len(path)-len(jule.SRC_EXT)]
 		path = filepath.Join(out_dir, path+jule.DOC_EXT)
-		write_output(path, docjson)
+		err = write_output(path, docjson)
+		if err != nil {
+			println(err.Error())
+			code = exit_io
+		}
+	}
+	return code
+}
+
+// find_package_files returns paths of useable
+// Jule source files of package directory for environment.
+func find_package_files(env *jule.Env, dir string) ([]string, error) {
+	infos, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, info := range infos {
+		name := info.Name()
+		if !info.IsDir() &&
+			strings.HasSuffix(name, jule.SRC_EXT) &&
+			juleio.IsPassFileAnnotation(env, name) {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	if len(paths) == 0 {
+		return nil, errors.New(jule.GetError("no_src_in_dir", dir))
 	}
+	return paths, nil
+}
+
+// defines_entry_point reports whether Jule source file defines entry point.
+func defines_entry_point(env *jule.Env, path string) bool {
+	f, err := juleio.Jopen(juleio.OS, path)
+	if err != nil {
+		return false
+	}
+	lexer := lex.NewLex(env, f)
+	toks := lexer.Lex()
+	// Errors are reported by parser.
+	if len(lexer.Logs) > 0 {
+		return false
+	}
+	b := ast.NewBuilder(env, toks)
+	b.Build()
+	for _, obj := range b.Tree {
+		st, ok := obj.Data.(models.Statement)
+		if !ok {
+			continue
+		}
+		if f, ok := st.Data.(models.Fn); ok && f.Id == jule.ENTRY_POINT {
+			return true
+		}
+	}
+	return false
+}
+
+// sort_entry_first returns paths with files which define entry point first.
+// Order of paths is kept otherwise.
+func sort_entry_first(env *jule.Env, paths []string) []string {
+	sorted := make([]string, 0, len(paths))
+	var others []string
+	for _, path := range paths {
+		if defines_entry_point(env, path) {
+			sorted = append(sorted, path)
+		} else {
+			others = append(others, path)
+		}
+	}
+	return append(sorted, others...)
+}
+
+// check_package parses and checks Jule source file or package directory.
+// Returns result of package and exit code.
+func check_package(path string) (*compiler.Result, int) {
+	info, err := os.Stat(path)
+	if err != nil {
+		println(err.Error())
+		return nil, exit_io
+	}
+	dir := path
+	if !info.IsDir() {
+		dir = filepath.Dir(path)
+	}
+	code := load_dir(dir)
+	if code != exit_success {
+		return nil, code
+	}
+	// Files are selected by target, not by host.
+	env, code := target_env()
+	if code != exit_success {
+		return nil, code
+	}
+	paths := []string{path}
+	if info.IsDir() {
+		paths, err = find_package_files(env, path)
+		if err != nil {
+			println(err.Error())
+			return nil, exit_io
+		}
+	}
+	// Package is checked from first file which is not excluded
+	// by build constraints, other files are checked by parser.
+	// Files which define entry point are tried first,
+	// because parser looks for entry point in checked file.
+	paths = sort_entry_first(env, paths)
+	var r *compiler.Result
+	for _, path := range paths {
+		opts := options(path)
+		opts.Mode = compiler.MODE_CHECK
+		r, code = compile(opts)
+		if code != exit_success {
+			return nil, code
+		}
+		if !r.Parser.Excluded {
+			break
+		}
+	}
+	return r, code
+}
+
+// check_path analyzes Jule source file or package directory.
+// Returns exit code.
+func check_path(path string) int {
+	r, code := check_package(path)
+	if code != exit_success {
+		return code
+	}
+	if print_logs(r.Diagnostics) {
+		return exit_diagnostics
+	}
+	return exit_success
+}
+
+// check analyzes all paths without code generation.
+// Returns exit code of last failed path if any path is failed.
+func check(cmd string) int {
+	paths := strings.Fields(parse_arguments(cmd))
+	if len(paths) == 0 {
+		println("error: missing check path")
+		return exit_usage
+	}
+	code := exit_success
+	for _, path := range paths {
+		c := check_path(path)
+		if c != exit_success {
+			code = c
+		}
+	}
+	return code
+}
+
+// lsp_serve serves language server over stdin and stdout.
+// Returns exit code.
+func lsp_serve(cmd string) int {
+	if cmd != "" {
+		println("This module can only be used as single!")
+		return exit_usage
+	}
+	set_path, errs := load_settings(jule.WORKING_PATH)
+	set()
+	if len(errs) > 0 {
+		for _, err := range errs {
+			println(set_path + ": " + err.Error())
+		}
+		return exit_usage
+	}
+	opts := options("")
+	_, err := compiler.NewSession(opts)
+	if err != nil {
+		println(err.Error())
+		return exit_usage
+	}
+	server := lsp.New(os.Stdin, os.Stdout)
+	server.NewSession = func() *parser.Session {
+		s, _ := compiler.NewSession(opts)
+		return s
+	}
+	err = server.Serve()
+	if err != nil {
+		println(err.Error())
+		return exit_io
+	}
+	return exit_success
+}
+
+// explain prints long-form explanation of error code.
+func explain(cmd string) int {
+	code := strings.TrimSpace(parse_arguments(cmd))
+	if code == "" || strings.Contains(code, " ") {
+		println("error: explain requires single error code")
+		return exit_usage
+	}
+	set_path, errs := load_settings(jule.WORKING_PATH)
+	set()
+	if len(errs) > 0 {
+		for _, err := range errs {
+			println(set_path + ": " + err.Error())
+		}
+		return exit_usage
+	}
+	key := jule.GetKeyByCode(code)
+	if key == "" {
+		println("error: undefined error code: " + code)
+		return exit_usage
+	}
+	fmt.Println(jule.GetCode(key) + ": " + jule.GetErrorPattern(key) + "\n")
+	explanation := jule.GetExplanation(key)
+	if explanation == "" {
+		fmt.Println("There is no extended explanation for this error.")
+	} else {
+		fmt.Println(explanation)
+	}
+	return exit_success
 }
 
 func open_url(url string) error {
@@ -128,15 +424,17 @@ func open_url(url string) error {
 	return cmd.Start()
 }
 
-func bug(cmd string) {
+func bug(cmd string) int {
 	if cmd != "" {
 		println("This module can only be used as single!")
-		return
+		return exit_usage
 	}
 	err := open_url("https://github.com/jule-lang/jule/issues/new?assignees=&labels=bug&template=bug-report.md&title=bug%3A+parser+generates+wrong+variable+declaration")
 	if err != nil {
 		fmt.Println(err.Error())
+		return exit_io
 	}
+	return exit_success
 }
 
 func list_horizontal_slice(s []string) string {
@@ -144,12 +442,48 @@ func list_horizontal_slice(s []string) string {
 	return lst[1 : len(lst)-1]
 }
 
-func tool(cmd string) {
+// deadcode reports unused use declarations and
+// private definitions of package.
+// Returns exit code.
+func deadcode(cmd string) int {
+	path := strings.TrimSpace(parse_arguments(cmd))
+	if path == "" {
+		path = "."
+	}
+	// Report all dead code even if disabled by settings.
+	werror = new(bool)
+	lint_args = append(lint_args,
+		lint_arg{julelint.UNUSED_USE, true},
+		lint_arg{julelint.UNUSED, true})
+	r, code := check_package(path)
+	if code != exit_success {
+		return code
+	}
+	var dead []julelog.CompilerLog
+	for _, l := range r.Diagnostics {
+		switch {
+		case l.Lint == julelint.UNUSED_USE, l.Lint == julelint.UNUSED:
+			dead = append(dead, l)
+		case l.Lint == "" && is_err(l):
+			dead = append(dead, l)
+		}
+	}
+	if print_logs(dead) {
+		return exit_diagnostics
+	}
+	return exit_success
+}
+
+func tool(cmd string) int {
 	if cmd == "" {
 		println(`tool commands:
  distos     Lists all supported operating systems
- distarch   Lists all supported architects`)
-		return
+ distarch   Lists all supported architects
+ deadcode   Reports unused definitions of package`)
+		return exit_success
+	}
+	if name, args, _ := strings.Cut(cmd, " "); name == "deadcode" {
+		return deadcode(args)
 	}
 	switch cmd {
 	case "distos":
@@ -160,38 +494,50 @@ func tool(cmd string) {
 		println(list_horizontal_slice(jule.DISTARCH))
 	default:
 		println("Undefined command: " + cmd)
+		return exit_usage
 	}
+	return exit_success
 }
 
-func process_command(namespace, cmd string) bool {
+// process_command processes command and returns exit code of command.
+// Reports false as ok if namespace is not command.
+func process_command(namespace, cmd string) (code int, ok bool) {
 	cmd = strings.TrimSpace(cmd)
 	switch namespace {
 	case CMD_HELP:
-		help(cmd)
+		code = help(cmd)
 	case CMD_VERSION:
-		version(cmd)
+		code = version(cmd)
 	case CMD_DOC:
-		doc(cmd)
+		code = doc(cmd)
 	case CMD_BUG:
-		bug(cmd)
+		code = bug(cmd)
 	case CMD_TOOL:
-		tool(cmd)
+		code = tool(cmd)
+	case CMD_RUN:
+		code = run()
+	case CMD_CHECK:
+		code = check(cmd)
+	case CMD_EXPLAIN:
+		code = explain(cmd)
+	case CMD_LSP:
+		code = lsp_serve(cmd)
 	default:
-		return false
+		return exit_success, false
 	}
-	return true
+	return code, true
 }
 
 func init() {
 	execp, err := os.Executable()
 	if err != nil {
 		println(err.Error())
-		os.Exit(0)
+		os.Exit(exit_io)
 	}
 	jule.WORKING_PATH, err = os.Getwd()
 	if err != nil {
 		println(err.Error())
-		os.Exit(0)
+		os.Exit(exit_io)
 	}
 	execp = filepath.Dir(execp)
 	jule.EXEC_PATH = execp
@@ -203,7 +549,7 @@ func init() {
 	// Not started with arguments.
 	// Here is "2" but "os.Args" always have one element for store working directory.
 	if len(os.Args) < 2 {
-		os.Exit(0)
+		os.Exit(exit_usage)
 	}
 	var sb strings.Builder
 	for _, arg := range os.Args[1:] {
@@ -215,8 +561,9 @@ func init() {
 	if i == -1 {
 		i = len(arg)
 	}
-	if process_command(arg[:i], arg[i:]) {
-		os.Exit(0)
+	code, ok := process_command(arg[:i], arg[i:])
+	if ok {
+		exit(code)
 	}
 }
 
@@ -238,140 +585,366 @@ func load_localization() {
 		println(err.Error())
 		return
 	}
+	// Explanations are optional for language packs.
+	path = filepath.Join(jule.LOCALIZATION_PATH, jule.EXPLANATIONS_DIR, lang+".json")
+	bytes, err = os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(bytes, &jule.EXPLANATIONS)
+	if err != nil {
+		println("Language's explanations couldn't loaded (uses default);")
+		println(err.Error())
+	}
+}
+
+// load_settings loads nearest settings file of directory
+// and sets not already setted settings.
+// Returns path of settings file and errors of settings.
+func load_settings(dir string) (string, []error) {
+	set_path := juleset.Find(dir)
+	if set_path == "" {
+		return "", nil
+	}
+	bytes, err := os.ReadFile(set_path)
+	if err != nil {
+		return set_path, []error{err}
+	}
+	s, errs := juleset.Load(bytes)
+	if s == nil {
+		return set_path, errs
+	}
+	if out_dir == "" && s.OutDir != "" {
+		// Relative output directories are relative to the settings file.
+		out_dir = s.OutDir
+		if !filepath.IsAbs(out_dir) {
+			out_dir = filepath.Join(filepath.Dir(set_path), out_dir)
+		}
+	}
+	if out_name == "" {
+		out_name = s.OutName
+	}
+	if language == "" {
+		language = s.Language
+	}
+	if cpp_compiler == "" {
+		cpp_compiler = s.Compiler
+	}
+	if cpp_compiler_path == "" {
+		cpp_compiler_path = s.CompilerPath
+	}
+	if cxx_flags == nil {
+		cxx_flags = s.CxxFlags
+	}
+	if ld_flags == nil {
+		ld_flags = s.LdFlags
+	}
+	if libs == nil {
+		libs = s.Libs
+	}
+	if optimization == "" {
+		optimization = s.Optimization
+	}
+	if debug_info == nil {
+		debug_info = s.Debug
+	}
+	if line_directives == nil {
+		line_directives = s.LineDirectives
+	}
+	if panic_trace == nil {
+		panic_trace = s.PanicTrace
+	}
+	if cpp_std == "" {
+		cpp_std = s.Std
+	}
+	if tags == nil {
+		tags = s.Tags
+	}
+	if sanitizers == nil {
+		sanitizers = s.Sanitize
+	}
+	if error_limit == nil {
+		error_limit = s.ErrorLimit
+	}
+	if werror == nil {
+		werror = s.Werror
+	}
+	lint_settings = s.Warnings
+	return set_path, errs
 }
 
-func check_mode() {
-	if mode != mode_transpile && mode != mode_compile {
-		println(jule.GetError("invalid_value_for_key", mode, "mode"))
-		os.Exit(0)
+func set() {
+	if out_dir == "" {
+		out_dir = default_out_dir
+	}
+	if mode == "" {
+		mode = compiler.MODE_COMPILE
+	}
+	if debug_info == nil {
+		debug_info = new(bool)
+		*debug_info = true
+	}
+	if line_directives == nil {
+		line_directives = new(bool)
+	}
+	if panic_trace == nil {
+		panic_trace = new(bool)
+	}
+	if error_limit == nil {
+		error_limit = new(int)
+		*error_limit = default_error_limit
 	}
+	if werror == nil {
+		werror = new(bool)
+	}
+	set_lints()
+	load_localization()
 }
 
-func check_compiler() {
-	if compiler != jule.COMPILER_GCC && compiler != jule.COMPILER_CLANG {
-		println(jule.GetError("invalid_value_for_key", compiler, "compiler"))
-		os.Exit(0)
+// set_lints sets lint configuration.
+func set_lints() {
+	lints = julelint.NewConfig()
+	lints.Werror = *werror
+	// All lints are set first, so specific lints can override.
+	if state, ok := lint_settings[julelint.ALL]; ok {
+		lints.Set(julelint.ALL, state)
+	}
+	for name, state := range lint_settings {
+		if name != julelint.ALL {
+			lints.Set(name, state)
+		}
+	}
+	for _, arg := range lint_args {
+		lints.Set(arg.name, arg.state)
+	}
+	for _, name := range lint_errors {
+		lints.SetError(name, true)
 	}
 }
 
-func set() {
-	if runtime.GOOS == "windows" {
-		compiler = compiler_gcc
-		compiler_path = compiler_path_gcc
-	} else {
-		compiler = compiler_clang
-		compiler_path = compiler_path_clang
+// options returns compilation options of path by settings.
+func options(path string) compiler.Options {
+	dir := out_dir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(jule.WORKING_PATH, dir)
+	}
+	return compiler.Options{
+		Path:           path,
+		Target:         target,
+		Mode:           mode,
+		Compiler:       cpp_compiler,
+		CompilerPath:   cpp_compiler_path,
+		Std:            cpp_std,
+		Optimization:   optimization,
+		Debug:          *debug_info,
+		CxxFlags:       cxx_flags,
+		LdFlags:        ld_flags,
+		Libs:           libs,
+		Sanitizers:     sanitizers,
+		LineDirectives: *line_directives,
+		PanicTrace:     *panic_trace,
+		Tags:           tags,
+		Library:        library,
+		Lints:          lints,
+		ErrorLimit:     *error_limit,
+		OutDir:         dir,
+		OutPath:        get_out_path(),
+		Stdout:         os.Stdout,
+		Stderr:         os.Stderr,
 	}
+}
 
-	load_localization()
-	check_mode()
-	check_compiler()
+// print_log prints log immediately if diagnostics format is text.
+// Otherwise log is collected for printing at exit.
+func print_log(l julelog.CompilerLog) {
+	if diagnostics != diagnostics_text {
+		logs = append(logs, l)
+		return
+	}
+	println(l.Render(color))
+}
+
+// is_err reports log is an error.
+func is_err(l julelog.CompilerLog) bool {
+	return l.Type == julelog.ERR || l.Type == julelog.FLAT_ERR
 }
 
 // print_logs prints logs and returns true
 // if logs has error, false if not.
-func print_logs(p *parser.Parser) bool {
+func print_logs(compiler_logs []julelog.CompilerLog) bool {
+	var warnings []julelog.CompilerLog
+	var errors []julelog.CompilerLog
+	for _, l := range compiler_logs {
+		if is_err(l) {
+			errors = append(errors, l)
+		} else {
+			warnings = append(warnings, l)
+		}
+	}
+	has_errors := len(errors) > 0
+	if diagnostics != diagnostics_text {
+		logs = append(logs, warnings...)
+		logs = append(logs, errors...)
+		return has_errors
+	}
 	var str strings.Builder
-	for _, l := range p.Warnings {
-		str.WriteString(l.String())
+	for _, l := range warnings {
+		str.WriteString(l.Render(color))
 		str.WriteByte('\n')
 	}
-	for _, l := range p.Errors {
-		str.WriteString(l.String())
+	for _, l := range errors {
+		str.WriteString(l.Render(color))
 		str.WriteByte('\n')
 	}
 	print(str.String())
-	return len(p.Errors) > 0
+	return has_errors
 }
 
-func append_standard(code *string) {
-	y, m, d := time.Now().Date()
-	h, min, _ := time.Now().Clock()
-	timeStr := fmt.Sprintf("%d/%d/%d %d.%d (DD/MM/YYYY) (HH.MM)",
-		d, m, y, h, min)
-	var sb strings.Builder
-	sb.WriteString("// Auto generated by JuleC.\n")
-	sb.WriteString("// JuleC version: ")
-	sb.WriteString(jule.VERSION)
-	sb.WriteByte('\n')
-	sb.WriteString("// Date: ")
-	sb.WriteString(timeStr)
-	sb.WriteString("\n\n#include \"")
-	sb.WriteString(juleapi.JULEC_HEADER)
-	sb.WriteString("\"\n\n")
-	sb.WriteString(*code)
-	*code = sb.String()
-}
-
-func write_output(path, content string) {
+// flush_logs prints collected logs in diagnostics format.
+func flush_logs() {
+	var out string
+	var err error
+	switch diagnostics {
+	case diagnostics_json:
+		out, err = julelog.Json(logs)
+	case diagnostics_sarif:
+		out, err = julelog.Sarif(logs, "julec", jule.VERSION, jule.WORKING_PATH)
+	default:
+		return
+	}
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	fmt.Println(out)
+}
+
+// is_terminal reports file is a terminal device.
+func is_terminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// exit prints collected logs and exits with code.
+func exit(code int) {
+	flush_logs()
+	os.Exit(code)
+}
+
+func write_output(path, content string) error {
 	dir := filepath.Dir(path)
 	err := os.MkdirAll(dir, 0o777)
 	if err != nil {
-		println(err.Error())
-		os.Exit(0)
+		return err
 	}
 	f, err := os.Create(path)
 	if err != nil {
-		println(err.Error())
-		os.Exit(0)
+		return err
 	}
 	_, err = f.WriteString(content)
 	if err != nil {
-		println(err.Error())
-		os.Exit(0)
+		_ = f.Close()
+		return err
 	}
+	return f.Close()
 }
 
-func compile(path string, main, nolocal, justDefs bool) *parser.Parser {
+// load_dir loads settings of directory.
+// Returns non-success exit code if settings are not valid.
+func load_dir(dir string) int {
+	set_path, errs := load_settings(dir)
 	set()
-	p := parser.New(nil)
-	// Check standard library.
-	inf, err := os.Stat(jule.STDLIB_PATH)
-	if err != nil || !inf.IsDir() {
-		p.PushErr("stdlib_not_exist")
-		return p
-	}
-	f, err := juleio.Jopen(path)
+	if len(errs) > 0 {
+		for _, err := range errs {
+			println(set_path + ": " + err.Error())
+		}
+		return exit_usage
+	}
+	return exit_success
+}
+
+// load loads settings of path and returns compilation options of path.
+// Returns non-success exit code if settings are not valid.
+func load(path string) (compiler.Options, int) {
+	code := load_dir(filepath.Dir(path))
+	if code != exit_success {
+		return compiler.Options{}, code
+	}
+	return options(path), exit_success
+}
+
+// target_env returns environment of compilation target.
+// Returns non-success exit code if target is not valid.
+func target_env() (*jule.Env, int) {
+	s, err := compiler.NewSession(options(""))
 	if err != nil {
 		println(err.Error())
-		return nil
-	}
-	if !juleio.IsPassFileAnnotation(path) {
-		p.PushErr("file_not_useable")
-		return p
-	}
-	p.File = f
-	p.NoLocalPkg = nolocal
-	p.SetupPackage()
-	p.Parsef(main, justDefs)
-	return p
-}
-
-func generate_compile_command(source_path string) (c, cmd string) {
-	var cpp strings.Builder
-	cpp.WriteString("-g -O0 ")
-	cpp.WriteString(source_path)
-	return compiler_path, cpp.String()
-}
-
-func do_spell(cpp string) {
-	path := filepath.Join(jule.WORKING_PATH, out_dir)
-	path = filepath.Join(path, out_name)
-	write_output(path, cpp)
-	switch mode {
-	case mode_compile:
-		c, cmd := generate_compile_command(path)
-		println(c + " " + cmd)
-		entries := strings.SplitN(cmd, " ", -1)
-		command := exec.Command(c, entries...)
-		err := command.Start()
-		if err != nil {
-			println(err.Error())
-		}
-		err = command.Wait()
-		if err != nil {
-			println(err.Error())
-		}
+		return nil, exit_usage
+	}
+	return s.Env, exit_success
+}
+
+// compile compiles Jule source code by options.
+// Returns non-success exit code if compilation could not start or failed.
+// Diagnostics of result must be checked even if exit code is success.
+func compile(opts compiler.Options) (*compiler.Result, int) {
+	r, err := compiler.Compile(context.Background(), opts)
+	var opt_err *compiler.OptionError
+	var backend_err *compiler.BackendError
+	switch {
+	case err == nil, errors.Is(err, compiler.ErrDiagnostics):
+		return r, exit_success
+	case errors.Is(err, compiler.ErrStdlib):
+		print_log(julelog.CompilerLog{
+			Type:    julelog.FLAT_ERR,
+			Message: jule.GetError("stdlib_not_exist"),
+			Key:     "stdlib_not_exist",
+		})
+		return nil, exit_io
+	case errors.As(err, &opt_err):
+		println(err.Error())
+		return nil, exit_usage
+	case errors.As(err, &backend_err):
+		println(err.Error())
+		return nil, exit_backend
+	default:
+		println(err.Error())
+		return nil, exit_io
+	}
+}
+
+// build compiles Jule program of path and prints diagnostics.
+// Returns exit code.
+func build(path string) int {
+	opts, code := load(path)
+	if code != exit_success {
+		return code
+	}
+	opts.Diagnostics = func(compiler_logs []julelog.CompilerLog) { print_logs(compiler_logs) }
+	r, code := compile(opts)
+	if code != exit_success {
+		return code
+	}
+	if r.HasErrors() {
+		return exit_diagnostics
+	}
+	return exit_success
+}
+
+// get_out_path returns output path of binary.
+//
+// Special case is;
+//
+//	get_out_path() -> returns default name of binary in working
+//	                  directory if output is not specified.
+func get_out_path() string {
+	switch {
+	case out_path != "":
+		return out_path
+	case out_name != "":
+		return filepath.Join(out_dir, out_name)
+	default:
+		return compiler.BinaryName(target)
 	}
 }
 
@@ -386,30 +959,31 @@ func get_arg(i *int, runes []rune) (arg string, content string) {
 		*i++
 		if *i >= len(runes) {
 			println("error: undefined syntax: " + string(runes[j:]))
-			os.Exit(0)
+			os.Exit(exit_usage)
 		}
 		r = runes[*i]
 		if r == '-' {
 			*i++
 			if *i >= len(runes) {
 				println("error: undefined syntax: " + string(runes[j:]))
-				os.Exit(0)
+				os.Exit(exit_usage)
 			}
 			r = runes[*i]
 		}
 		if !lex.IsIdentifierRune(string(r)) {
 			println("error: undefined syntax: " + string(runes[j:]))
-			os.Exit(0)
+			os.Exit(exit_usage)
 		}
 		*i++
 		for ; *i < len(runes); *i++ {
 			r = runes[*i]
-			if lex.IsSpace(byte(r)) {
+			// Value can be separated by equal sign: --arg=value
+			if lex.IsSpace(byte(r)) || r == '=' {
 				break
 			} else if !lex.IsLetter(r) && !lex.IsDecimal(byte(r)) &&
 				r != '_' && r != '-' {
 				println("error: undefined syntax: " + string(runes[j:]))
-				os.Exit(0)
+				os.Exit(exit_usage)
 			}
 		}
 		arg = string(runes[j:*i])
@@ -421,6 +995,9 @@ func get_arg(i *int, runes []rune) (arg string, content string) {
 }
 
 func get_arg_value(i *int, runes []rune) string {
+	if *i < len(runes) && runes[*i] == '=' {
+		*i++
+	}
 	first := -1
 	for ; *i < len(runes); *i++ {
 		r := runes[*i]
@@ -440,23 +1017,49 @@ func get_arg_value(i *int, runes []rune) string {
 	return string(runes[first:])
 }
 
+// get_required_arg_value returns value of argument.
+// Exits if argument has not value.
+func get_required_arg_value(i *int, runes []rune, arg string) string {
+	value := get_arg_value(i, runes)
+	if value == "" {
+		println("error: missing argument value: " + arg)
+		os.Exit(exit_usage)
+	}
+	return value
+}
+
 func parse_compiler_arg(i *int, runes []rune) {
 	value := get_arg_value(i, runes)
 	if value == "" {
 		println("error: missing argument value: -c --compiler")
-		os.Exit(0)
+		os.Exit(exit_usage)
 	}
 	switch value {
-	case compiler_clang:
-		compiler = value
-		compiler_path = compiler_path_clang
-	case compiler_gcc:
-		compiler = value
-		compiler_path = compiler_path_gcc
+	case jule.COMPILER_CLANG:
+		cpp_compiler = value
+		cpp_compiler_path = compiler_path_clang
+	case jule.COMPILER_GCC:
+		cpp_compiler = value
+		cpp_compiler_path = compiler_path_gcc
 	default:
 		println("error: invalid argument value: " + value)
-		os.Exit(0)
+		os.Exit(exit_usage)
+	}
+}
+
+// parse_lint_arg parses lint argument: -W<name> or -Wno-<name>
+func parse_lint_arg(arg string) {
+	name := arg[2:]
+	state := true
+	if strings.HasPrefix(name, "no-") {
+		name = name[3:]
+		state = false
 	}
+	if name != julelint.ALL && !julelint.IsLint(name) {
+		println("error: unknown lint: " + name)
+		os.Exit(exit_usage)
+	}
+	lint_args = append(lint_args, lint_arg{name, state})
 }
 
 func parse_arguments(cmd string) string {
@@ -469,35 +1072,150 @@ func parse_arguments(cmd string) string {
 		switch arg {
 		case "":
 		case "-t", "--transpile":
-			mode = mode_transpile
+			mode = compiler.MODE_TRANSPILE
 		case "-c", "--compile":
-			mode = mode_compile
+			mode = compiler.MODE_COMPILE
 		case "--compiler":
 			parse_compiler_arg(&i, runes)
+		case "-o":
+			out_path = get_required_arg_value(&i, runes, arg)
+		case "-O0", "-O1", "-O2", "-O3", "-Os":
+			optimization = arg[2:]
+		case "-g", "--debug":
+			debug_info = new(bool)
+			*debug_info = true
+		case "--no-debug":
+			debug_info = new(bool)
+		case "--line-directives":
+			line_directives = new(bool)
+			*line_directives = true
+		case "--panic-trace":
+			panic_trace = new(bool)
+			*panic_trace = true
+		case "--sanitize":
+			sanitizers = append(sanitizers, strings.Split(get_required_arg_value(&i, runes, arg), ",")...)
+		case "--library":
+			library = true
+		case "--tags":
+			tags = append(tags, strings.Split(get_required_arg_value(&i, runes, arg), ",")...)
+		case "--diagnostics":
+			diagnostics = get_required_arg_value(&i, runes, arg)
+			switch diagnostics {
+			case diagnostics_text, diagnostics_json, diagnostics_sarif:
+			default:
+				println("error: invalid argument value: " + diagnostics)
+				os.Exit(exit_usage)
+			}
+		case "--language":
+			language = get_required_arg_value(&i, runes, arg)
+		case "--error-limit":
+			value := get_required_arg_value(&i, runes, arg)
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				println("error: invalid argument value: " + value)
+				os.Exit(exit_usage)
+			}
+			error_limit = &n
+		case "-Werror":
+			// Lint can be specified by equal sign: -Werror=name
+			if i < len(runes) && runes[i] == '=' {
+				name := get_required_arg_value(&i, runes, arg)
+				if name != julelint.ALL && !julelint.IsLint(name) {
+					println("error: unknown lint: " + name)
+					os.Exit(exit_usage)
+				}
+				lint_errors = append(lint_errors, name)
+				break
+			}
+			werror = new(bool)
+			*werror = true
+		case "-Wno-error":
+			werror = new(bool)
+		case "--target":
+			target = get_required_arg_value(&i, runes, arg)
+		case "--std":
+			cpp_std = get_required_arg_value(&i, runes, arg)
+		case "--cxxflags":
+			cxx_flags = append(cxx_flags, get_required_arg_value(&i, runes, arg))
+		case "--ldflags":
+			ld_flags = append(ld_flags, get_required_arg_value(&i, runes, arg))
+		case "-l", "--lib":
+			libs = append(libs, get_required_arg_value(&i, runes, arg))
 		default:
+			if strings.HasPrefix(arg, "-W") {
+				parse_lint_arg(arg)
+				break
+			}
 			println("error: undefined argument: " + arg)
-			os.Exit(0)
+			os.Exit(exit_usage)
 		}
 	}
 	cmd = strings.TrimSpace(cmd)
 	return cmd
 }
 
+// run compiles program into temporary directory and executes it.
+// Arguments after the "--" argument are passed to the program.
+// Returns exit code of the program if program is executed.
+func run() int {
+	args := os.Args[2:]
+	var program_args []string
+	for i, arg := range args {
+		if arg == "--" {
+			program_args = args[i+1:]
+			args = args[:i]
+			break
+		}
+	}
+	path := parse_arguments(strings.Join(args, " "))
+	if path == "" {
+		println("error: missing compile path")
+		return exit_usage
+	}
+	dir, err := os.MkdirTemp("", "julec-run-")
+	if err != nil {
+		println(err.Error())
+		return exit_io
+	}
+	code := run_in(dir, path, program_args)
+	_ = os.RemoveAll(dir)
+	return code
+}
+
+func run_in(dir, path string, args []string) int {
+	mode = compiler.MODE_COMPILE
+	out_dir = dir
+	name := filepath.Base(path)
+	name = name[:len(name)-len(filepath.Ext(name))]
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	out_path = filepath.Join(dir, name)
+	code := build(path)
+	if code != exit_success {
+		return code
+	}
+	command := exec.Command(out_path, args...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	err := command.Run()
+	if err != nil {
+		if exit, ok := err.(*exec.ExitError); ok {
+			return exit.ExitCode()
+		}
+		println(err.Error())
+		return exit_backend
+	}
+	return exit_success
+}
+
 func main() {
 	cmd := os.Args[0]
 	cmd = parse_arguments(cmd)
 	if cmd == "" {
 		println("error: missing compile path")
-		return
-	}
-	t := compile(cmd, true, false, false)
-	if t == nil {
-		return
-	}
-	if print_logs(t) {
-		return
+		exit(exit_usage)
 	}
-	cpp := t.Cpp()
-	append_standard(&cpp)
-	do_spell(cpp)
+	exit(build(cmd))
 }
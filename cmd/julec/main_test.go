@@ -0,0 +1,307 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/julelang/jule/compiler"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/juleapi"
+)
+
+// reset_settings resets settings of command-line and settings file
+// before and after test, settings are global.
+func reset_settings(t *testing.T) {
+	stdlib_path := jule.STDLIB_PATH
+	header_path := juleapi.JULEC_HEADER
+	reset := func() {
+		out_dir, out_name, out_path = "", "", ""
+		language, mode = "", ""
+		cpp_compiler, cpp_compiler_path = "", ""
+		cxx_flags, ld_flags, libs = nil, nil, nil
+		optimization, cpp_std = "", ""
+		debug_info, line_directives, panic_trace = nil, nil, nil
+		tags, sanitizers = nil, nil
+		library = false
+		target = ""
+		diagnostics = diagnostics_text
+		error_limit, werror = nil, nil
+		lint_settings, lint_args, lint_errors = nil, nil, nil
+		lints = nil
+		logs = nil
+		jule.STDLIB_PATH = stdlib_path
+		juleapi.JULEC_HEADER = header_path
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+// setup_check resets settings and sets options of compiler for tests.
+func setup_check(t *testing.T) {
+	t.Helper()
+	reset_settings(t)
+	std, err := filepath.Abs(filepath.Join("..", "..", jule.STDLIB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jule.STDLIB_PATH = std
+	header, err := filepath.Abs(filepath.Join("..", "..", "api", "julec.hpp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	juleapi.JULEC_HEADER = header
+	cpp_compiler = jule.COMPILER_GCC
+	cpp_compiler_path = compiler_path_gcc
+}
+
+// write_package writes files to temporary package directory.
+// Returns path of directory.
+func write_package(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const helper_file = "fn helper(): int { ret 1 }\n"
+const main_file = "fn main() {\n\t_ = helper()\n}\n"
+
+func TestCheckPackage(t *testing.T) {
+	setup_check(t)
+	tests := []struct {
+		name   string
+		files  map[string]string
+		target string
+		want   []string // Keys of diagnostics.
+	}{
+		{
+			name:  "entry point in first file",
+			files: map[string]string{"main.jule": main_file, "util.jule": helper_file},
+		},
+		{
+			name:  "entry point in last file",
+			files: map[string]string{"a.jule": helper_file, "main.jule": main_file},
+		},
+		{
+			name:  "no entry point",
+			files: map[string]string{"a.jule": helper_file, "b.jule": "fn f() {}\n"},
+			want:  []string{"no_entry_point"},
+		},
+		{
+			name:   "files of target",
+			files:  map[string]string{"main_windows.jule": main_file, "util_windows.jule": helper_file},
+			target: "windows/amd64",
+		},
+		{
+			name:   "files of other target",
+			files:  map[string]string{"main_windows.jule": main_file, "util.jule": helper_file},
+			target: "linux/amd64",
+			want:   []string{"no_entry_point"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target = test.target
+			defer func() { target = "" }()
+			r, code := check_package(write_package(t, test.files))
+			if code != exit_success {
+				t.Fatalf("got exit code %d, want %d", code, exit_success)
+			}
+			var keys []string
+			for _, d := range r.Diagnostics {
+				keys = append(keys, d.Key)
+			}
+			if !reflect.DeepEqual(keys, test.want) {
+				t.Errorf("got diagnostics %v, want %v", keys, test.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath(compiler_path_gcc); err != nil {
+		t.Skip(compiler_path_gcc + " is not found")
+	}
+	// Exit code of program reports arguments are forwarded or not.
+	const args_file = `use std::os::{ARGS, exit}
+
+fn main() {
+	if ARGS.len == 3 && ARGS[1] == "x" && ARGS[2] == "-o" {
+		exit(7)
+	}
+	exit(5)
+}
+`
+	tests := []struct {
+		name string
+		main string   // Source code of main.jule, path is missing if empty.
+		args []string // Arguments of program.
+		want int
+	}{
+		{name: "arguments", main: args_file, args: []string{"x", "-o"}, want: 7},
+		{name: "no arguments", main: args_file, want: 5},
+		{name: "diagnostics", main: "fn main() { x }\n", want: exit_diagnostics},
+		{name: "missing path", want: exit_usage},
+	}
+	args := os.Args
+	defer func() { os.Args = args }()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setup_check(t)
+			os.Args = []string{"julec", CMD_RUN}
+			if test.main != "" {
+				dir := write_package(t, map[string]string{"main.jule": test.main})
+				os.Args = append(os.Args, filepath.Join(dir, "main.jule"))
+			}
+			os.Args = append(os.Args, "--")
+			os.Args = append(os.Args, test.args...)
+			if code := run(); code != test.want {
+				t.Errorf("got exit code %d, want %d", code, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildExitCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string // Files of package, main.jule is built.
+		setup func(dir string)  // Sets settings of command-line, can be nil.
+		want  int
+	}{
+		{
+			name:  "success",
+			files: map[string]string{"main.jule": "fn main() {}\n"},
+		},
+		{
+			name:  "diagnostics",
+			files: map[string]string{"main.jule": "fn main() { x }\n"},
+			want:  exit_diagnostics,
+		},
+		{
+			name: "settings",
+			files: map[string]string{
+				"main.jule":        "fn main() {}\n",
+				jule.SETTINGS_FILE: `{"optimization": "4"}`,
+			},
+			want: exit_usage,
+		},
+		{
+			name:  "target",
+			files: map[string]string{"main.jule": "fn main() {}\n"},
+			setup: func(string) { target = "plan9/amd64" },
+			want:  exit_usage,
+		},
+		{
+			name:  "sanitizers",
+			files: map[string]string{"main.jule": "fn main() {}\n"},
+			setup: func(string) { sanitizers = []string{"address", "thread"} },
+			want:  exit_usage,
+		},
+		{
+			name:  "missing file",
+			files: map[string]string{"util.jule": "fn main() {}\n"},
+			want:  exit_io,
+		},
+		{
+			name:  "missing stdlib",
+			files: map[string]string{"main.jule": "fn main() {}\n"},
+			setup: func(dir string) { jule.STDLIB_PATH = filepath.Join(dir, "std") },
+			want:  exit_io,
+		},
+		{
+			name:  "backend",
+			files: map[string]string{"main.jule": "fn main() {}\n"},
+			setup: func(dir string) {
+				mode = compiler.MODE_COMPILE
+				cpp_compiler_path = filepath.Join(dir, "c++")
+			},
+			want: exit_backend,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setup_check(t)
+			dir := write_package(t, test.files)
+			out_dir = filepath.Join(dir, "dist")
+			mode = compiler.MODE_TRANSPILE
+			if test.setup != nil {
+				test.setup(dir)
+			}
+			code := build(filepath.Join(dir, "main.jule"))
+			if code != test.want {
+				t.Errorf("got exit code %d, want %d", code, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     string // Arguments of command-line.
+		settings string // Content of settings file, not written if empty.
+		want     []string
+	}{
+		{
+			name: "defaults",
+			want: []string{"-g", "-O0", "-std=c++17", "-o", "a.out", "ir.cpp"},
+		},
+		{
+			name: "arguments",
+			args: "-o bin/x -O2 --no-debug --std c++20 --cxxflags -DX --ldflags -static -l m",
+			want: []string{"-O2", "-std=c++20", "-DX", "-o", "bin/x", "ir.cpp", "-static", "-lm"},
+		},
+		{
+			name: "settings",
+			settings: `{"out_name": "app", "optimization": "3", "debug": false, "std": "gnu++17",
+				"cxx_flags": ["-DX"], "ld_flags": ["-static"], "libs": ["m"]}`,
+			want: []string{
+				"-O3", "-std=gnu++17", "-DX", "-o", filepath.Join("dist", "app"),
+				"ir.cpp", "-static", "-lm",
+			},
+		},
+		{
+			name:     "arguments override settings",
+			args:     "-O1 -g -l z",
+			settings: `{"optimization": "3", "debug": false, "libs": ["m"]}`,
+			want:     []string{"-g", "-O1", "-std=c++17", "-o", "a.out", "ir.cpp", "-lz"},
+		},
+		{
+			name:     "sanitizers",
+			args:     "-O2 --no-debug --sanitize thread",
+			settings: `{"sanitize": ["undefined"]}`,
+			want: []string{
+				"-g", "-O1", "-fsanitize=thread", "-fno-omit-frame-pointer",
+				"-std=c++17", "-o", "a.out", "ir.cpp",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setup_check(t)
+			files := map[string]string{"main.jule": "fn main() {}\n"}
+			if test.settings != "" {
+				files[jule.SETTINGS_FILE] = test.settings
+			}
+			dir := write_package(t, files)
+			path := parse_arguments(test.args + " " + filepath.Join(dir, "main.jule"))
+			opts, code := load(path)
+			if code != exit_success {
+				t.Fatalf("got exit code %d, want %d", code, exit_success)
+			}
+			_, args := compiler.CompileCommand("ir.cpp", opts)
+			if !reflect.DeepEqual(args, test.want) {
+				t.Errorf("got arguments %q, want %q", args, test.want)
+			}
+		})
+	}
+}
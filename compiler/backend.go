@@ -0,0 +1,162 @@
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/julelang/jule/pkg/jule"
+)
+
+// Optimization level of sanitized builds.
+// Sanitizers are too slow without optimization and
+// reports are less accurate with higher levels.
+const sanitizer_optimization = "1"
+
+// append_standard returns cpp code with standard header.
+func append_standard(code string, opts *Options) string {
+	y, m, d := time.Now().Date()
+	h, min, _ := time.Now().Clock()
+	timeStr := fmt.Sprintf("%d/%d/%d %d.%d (DD/MM/YYYY) (HH.MM)",
+		d, m, y, h, min)
+	var sb strings.Builder
+	sb.WriteString("// Auto generated by JuleC.\n")
+	sb.WriteString("// JuleC version: ")
+	sb.WriteString(jule.VERSION)
+	sb.WriteByte('\n')
+	sb.WriteString("// Date: ")
+	sb.WriteString(timeStr)
+	sb.WriteString("\n\n")
+	if opts.PanicTrace {
+		sb.WriteString("#define __JULEC_PANIC_TRACE\n\n")
+	}
+	sb.WriteString("#include \"")
+	sb.WriteString(opts.HeaderPath)
+	sb.WriteString("\"\n\n")
+	sb.WriteString(code)
+	return sb.String()
+}
+
+func write_output(path, content string) error {
+	dir := filepath.Dir(path)
+	err := os.MkdirAll(dir, 0o777)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(content)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// target_triple returns target triple of target platform for clang.
+func target_triple(os, arch string) string {
+	var triple string
+	switch arch {
+	case jule.ARCH_AMD64:
+		triple = "x86_64"
+	case jule.ARCH_I386:
+		triple = "i686"
+	case jule.ARCH_ARM64:
+		triple = "aarch64"
+	case jule.ARCH_ARM:
+		triple = "armv7"
+	}
+	switch os {
+	case jule.OS_LINUX:
+		if arch == jule.ARCH_ARM {
+			return triple + "-linux-gnueabihf"
+		}
+		return triple + "-linux-gnu"
+	case jule.OS_WINDOWS:
+		return triple + "-w64-windows-gnu"
+	case jule.OS_DARWIN:
+		if arch == jule.ARCH_ARM64 {
+			triple = "arm64"
+		}
+		return triple + "-apple-darwin"
+	}
+	return triple
+}
+
+// sanitizer_flags returns compiler flags of sanitizers.
+// Flags are same for GCC and Clang.
+//
+// Special case is;
+//
+//	sanitizer_flags(sanitizers) -> returns nil if sanitizers are not specified.
+func sanitizer_flags(sanitizers []string) []string {
+	if len(sanitizers) == 0 {
+		return nil
+	}
+	return []string{
+		"-fsanitize=" + strings.Join(sanitizers, ","),
+		// Keep frame pointers for stack traces of reports.
+		"-fno-omit-frame-pointer",
+	}
+}
+
+// CompileCommand returns command of C++ compiler
+// to compile C++ unit of source path by options.
+func CompileCommand(source_path string, opts Options) (c string, args []string) {
+	opts.set_defaults()
+	sanitize := sanitizer_flags(opts.Sanitizers)
+	if opts.Debug || sanitize != nil {
+		args = append(args, "-g")
+	}
+	if sanitize != nil {
+		args = append(args, "-O"+sanitizer_optimization)
+		args = append(args, sanitize...)
+	} else {
+		args = append(args, "-O"+opts.Optimization)
+	}
+	args = append(args, "-std="+opts.Std)
+	if opts.Target != "" && opts.Compiler == jule.COMPILER_CLANG {
+		os, arch, err := parse_target(opts.Target)
+		if err == nil {
+			args = append(args, "--target="+target_triple(os, arch))
+		}
+	}
+	args = append(args, opts.CxxFlags...)
+	if opts.OutPath != "" {
+		args = append(args, "-o", opts.OutPath)
+	}
+	args = append(args, source_path)
+	args = append(args, opts.LdFlags...)
+	for _, lib := range opts.Libs {
+		args = append(args, "-l"+lib)
+	}
+	return opts.CompilerPath, args
+}
+
+// build compiles C++ unit of source path with C++ compiler.
+func build(ctx context.Context, source_path string, opts *Options) error {
+	if opts.OutPath != "" {
+		err := os.MkdirAll(filepath.Dir(opts.OutPath), 0o777)
+		if err != nil {
+			return err
+		}
+	}
+	c, args := CompileCommand(source_path, *opts)
+	if opts.Stderr != nil {
+		fmt.Fprintln(opts.Stderr, c+" "+strings.Join(args, " "))
+	}
+	command := exec.CommandContext(ctx, c, args...)
+	command.Stdout = opts.Stdout
+	command.Stderr = opts.Stderr
+	err := command.Run()
+	if err != nil {
+		return &BackendError{err}
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+// Package compiler compiles Jule source code from Go code.
+//
+// Compile parses entry file of options with its package and used
+// packages, generates C++ code and compiles it with the C++ compiler.
+// Compilations have not shared state, so concurrent compilations are
+// safe, even for targets of different bit size.
+package compiler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/parser"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/juleapi"
+	"github.com/julelang/jule/pkg/juleio"
+	"github.com/julelang/jule/pkg/julelint"
+	"github.com/julelang/jule/pkg/julelog"
+)
+
+// Modes of compilation.
+const MODE_CHECK = "check"         // Parse and check, code is not generated.
+const MODE_TRANSPILE = "transpile" // Generate C++ code.
+const MODE_COMPILE = "compile"     // Generate C++ code and compile it.
+
+// Sanitizers of C++ compiler.
+const SANITIZER_ADDRESS = "address"
+const SANITIZER_UNDEFINED = "undefined"
+const SANITIZER_THREAD = "thread"
+
+// CPP_OUT_NAME is file name of generated C++ unit.
+const CPP_OUT_NAME = "ir.cpp"
+
+const default_optimization = "0"
+const default_cpp_std = "c++17"
+
+// ErrDiagnostics is returned if source code has errors.
+// Errors are reported as diagnostics of result.
+var ErrDiagnostics = errors.New("source code has errors")
+
+// ErrStdlib is returned if standard library directory is not exist.
+var ErrStdlib = errors.New("standard library directory not found")
+
+// OptionError is the error of invalid options.
+type OptionError struct {
+	Message string
+}
+
+func (e *OptionError) Error() string { return e.Message }
+
+// BackendError is the error of C++ compiler.
+type BackendError struct {
+	Err error
+}
+
+func (e *BackendError) Error() string { return e.Err.Error() }
+func (e *BackendError) Unwrap() error { return e.Err }
+
+// Options are options of compilation.
+// Zero values are defaults.
+type Options struct {
+	// Path of entry Jule source file.
+	Path string
+	// File system of sources.
+	// Defaults to file system of operating system.
+	FS juleio.FS
+	// Target platform in "os/arch" format.
+	// Defaults to host platform.
+	Target string
+	// Path of standard library directory.
+	// Defaults to jule.STDLIB_PATH.
+	StdlibPath string
+	// Path of "julec.hpp" header of API.
+	// Defaults to juleapi.JULEC_HEADER.
+	HeaderPath string
+
+	// Mode of compilation.
+	// Defaults to MODE_COMPILE.
+	Mode string
+	// Parse only definitions of entry file.
+	// Files of local package are not parsed.
+	// It is useful for documentation, mode should be MODE_CHECK.
+	DefsOnly bool
+	// Entry file is a library package, entry point is not required.
+	// Entry point is checked in all modes if false, so mode
+	// MODE_CHECK reports same errors with builds.
+	Library bool
+	// C++ compiler, jule.COMPILER_GCC or jule.COMPILER_CLANG.
+	// Defaults to GCC for Windows, Clang for others.
+	Compiler string
+	// Path of C++ compiler.
+	// Defaults to default path of compiler.
+	CompilerPath string
+	// C++ standard.
+	// Defaults to C++17.
+	Std string
+	// Optimization level: "0", "1", "2", "3" or "s".
+	// Defaults to "0".
+	Optimization   string
+	Debug          bool
+	CxxFlags       []string
+	LdFlags        []string
+	Libs           []string
+	Sanitizers     []string
+	LineDirectives bool
+	PanicTrace     bool
+	// Build tags.
+	Tags []string
+	// Lint configuration.
+	// Defaults to default configuration.
+	Lints *julelint.Config
+	// Maximum count of reported errors, zero for no limit.
+	ErrorLimit int
+
+	// Directory of generated C++ units.
+	// Units are not written if empty and mode is transpile.
+	// Temporary directory is used if empty and mode is compile.
+	OutDir string
+	// Path of built binary.
+	// Binary is built into OutDir if empty, with default name of
+	// C++ compilers for the target. If OutDir is also empty, binary is
+	// built into a temporary directory which is not removed.
+	OutPath string
+	// Command of C++ compiler and outputs of C++ compiler
+	// are written to Stdout and Stderr. Discarded if nil.
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Diagnostics is called with diagnostics of source code after
+	// checking, before code generation. Diagnostics are reported
+	// to result even if nil.
+	Diagnostics func([]julelog.CompilerLog)
+}
+
+// Unit is a generated C++ unit.
+type Unit struct {
+	Name string
+	// Path of written unit.
+	// Empty if unit is not written.
+	Path string
+	Code string
+}
+
+// Result is the result of compilation.
+type Result struct {
+	// Parser of entry file.
+	// Nil if parsing is not started.
+	Parser *parser.Parser
+	// Generated C++ units.
+	Units []Unit
+	// Warnings and errors in report order.
+	// Warnings of error lints are errors.
+	Diagnostics []julelog.CompilerLog
+	// Path of built binary.
+	// Empty if binary is not built.
+	Binary string
+}
+
+// HasErrors reports result has errors.
+func (r *Result) HasErrors() bool {
+	for _, l := range r.Diagnostics {
+		if is_err(l) {
+			return true
+		}
+	}
+	return false
+}
+
+func is_err(l julelog.CompilerLog) bool {
+	return l.Type == julelog.ERR || l.Type == julelog.FLAT_ERR
+}
+
+// report reports logs of parser to result and callback of options.
+// Warnings are reported first, errors follows them.
+// Errors are limited by error limit of session.
+func (r *Result) report(s *parser.Session, p *parser.Parser, opts *Options) {
+	var errors []julelog.CompilerLog
+	for _, w := range p.Warnings {
+		if opts.Lints.IsError(w.Lint) {
+			w.Type = julelog.ERR
+			errors = append(errors, w)
+		} else {
+			r.Diagnostics = append(r.Diagnostics, w)
+		}
+	}
+	errors = append(errors, p.Errors...)
+	r.Diagnostics = append(r.Diagnostics, s.LimitErrors(errors)...)
+	if opts.Diagnostics != nil {
+		opts.Diagnostics(r.Diagnostics)
+	}
+}
+
+// set_defaults sets defaults of not specified options.
+func (opts *Options) set_defaults() {
+	if opts.FS == nil {
+		opts.FS = juleio.OS
+	}
+	if opts.StdlibPath == "" {
+		opts.StdlibPath = jule.STDLIB_PATH
+	}
+	if opts.HeaderPath == "" {
+		opts.HeaderPath = juleapi.JULEC_HEADER
+	}
+	if opts.Mode == "" {
+		opts.Mode = MODE_COMPILE
+	}
+	if opts.Compiler == "" {
+		if runtime.GOOS == "windows" {
+			opts.Compiler = jule.COMPILER_GCC
+		} else {
+			opts.Compiler = jule.COMPILER_CLANG
+		}
+	}
+	if opts.CompilerPath == "" {
+		switch opts.Compiler {
+		case jule.COMPILER_GCC:
+			opts.CompilerPath = "g++"
+		case jule.COMPILER_CLANG:
+			opts.CompilerPath = "clang++"
+		}
+	}
+	if opts.Std == "" {
+		opts.Std = default_cpp_std
+	}
+	if opts.Optimization == "" {
+		opts.Optimization = default_optimization
+	}
+	if opts.Lints == nil {
+		opts.Lints = julelint.NewConfig()
+	}
+}
+
+// check reports error if options are not valid.
+func (opts *Options) check() error {
+	switch opts.Mode {
+	case MODE_CHECK, MODE_TRANSPILE, MODE_COMPILE:
+	default:
+		return &OptionError{jule.GetError("invalid_value_for_key", opts.Mode, "mode")}
+	}
+	switch opts.Compiler {
+	case jule.COMPILER_GCC, jule.COMPILER_CLANG:
+	default:
+		return &OptionError{jule.GetError("invalid_value_for_key", opts.Compiler, "compiler")}
+	}
+	return check_sanitizers(opts.Sanitizers)
+}
+
+// check_sanitizers reports error if sanitizers are not known or
+// can not be used together.
+func check_sanitizers(sanitizers []string) error {
+	address, thread := false, false
+	for _, s := range sanitizers {
+		switch s {
+		case SANITIZER_ADDRESS:
+			address = true
+		case SANITIZER_THREAD:
+			thread = true
+		case SANITIZER_UNDEFINED:
+		default:
+			return &OptionError{jule.GetError("unknown_sanitizer", s)}
+		}
+	}
+	// ThreadSanitizer has own shadow memory, so it is
+	// not works with AddressSanitizer.
+	if address && thread {
+		return &OptionError{jule.GetError("incompatible_sanitizers",
+			SANITIZER_ADDRESS, SANITIZER_THREAD)}
+	}
+	return nil
+}
+
+// parse_target returns operating system and architecture
+// of "os/arch" formatted target.
+func parse_target(target string) (os, arch string, err error) {
+	i := strings.IndexByte(target, '/')
+	if i == -1 {
+		return "", "", &OptionError{"invalid target: " + target}
+	}
+	os, arch = target[:i], target[i+1:]
+	if !jule.IsSupportedOS(os) {
+		return "", "", &OptionError{"unsupported operating system: " + os}
+	}
+	if !jule.IsSupportedArch(arch) {
+		return "", "", &OptionError{"unsupported architecture: " + arch}
+	}
+	return os, arch, nil
+}
+
+// BinaryName returns default name of built binary for target,
+// same as default output of C++ compilers.
+func BinaryName(target string) string {
+	goos := runtime.GOOS
+	if target != "" {
+		os, _, err := parse_target(target)
+		if err == nil {
+			goos = os
+		}
+	}
+	if goos == "windows" {
+		return "a.exe"
+	}
+	return "a.out"
+}
+
+// NewSession returns new compilation session by options.
+// Sessions are not share any state, so they can compile concurrently.
+// Returns error if target is not valid, other options are not checked.
+func NewSession(opts Options) (*parser.Session, error) {
+	opts.set_defaults()
+	env := jule.NewEnv()
+	env.StdlibPath = opts.StdlibPath
+	env.Tags = opts.Tags
+	if opts.Target != "" {
+		var err error
+		env.TargetOS, env.TargetArch, err = parse_target(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+	}
+	s := parser.NewSession(env)
+	s.FS = opts.FS
+	s.Lints = opts.Lints
+	s.ErrorLimit = opts.ErrorLimit
+	s.Writer.LineDirectives = opts.LineDirectives
+	s.Writer.PanicTrace = opts.PanicTrace
+	return s, nil
+}
+
+// parse parses entry file of options.
+// Returns parser even if source code has errors.
+func parse(s *parser.Session, opts *Options) (*parser.Parser, error) {
+	// Check standard library.
+	inf, err := s.FS.Stat(s.Env.StdlibPath)
+	if err != nil || !inf.IsDir() {
+		return nil, ErrStdlib
+	}
+	p := parser.New(s, nil)
+	f, err := juleio.Jopen(s.FS, opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !juleio.IsPassFileAnnotation(s.Env, opts.Path) {
+		p.PushErr("file_not_useable")
+		return p, nil
+	}
+	p.File = f
+	p.NoLocalPkg = opts.DefsOnly
+	p.SetupPackage()
+	p.Parsef(!opts.Library, opts.DefsOnly)
+	if p.Excluded {
+		p.PushErr("file_not_useable")
+	}
+	return p, nil
+}
+
+// Compile compiles Jule source code by options.
+// Returns result even if source code has errors, with ErrDiagnostics.
+// Context cancels compilation between stages and C++ compiler,
+// parsing is not cancelled.
+func Compile(ctx context.Context, opts Options) (*Result, error) {
+	opts.set_defaults()
+	err := opts.check()
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewSession(opts)
+	if err != nil {
+		return nil, err
+	}
+	p, err := parse(s, &opts)
+	r := &Result{Parser: p}
+	if p != nil {
+		r.report(s, p, &opts)
+	}
+	switch {
+	case err != nil:
+		return r, err
+	case r.HasErrors():
+		return r, ErrDiagnostics
+	case opts.Mode == MODE_CHECK:
+		return r, nil
+	}
+	err = ctx.Err()
+	if err != nil {
+		return r, err
+	}
+	unit := Unit{Name: CPP_OUT_NAME}
+	dir := opts.OutDir
+	if dir == "" && opts.Mode == MODE_COMPILE {
+		dir, err = os.MkdirTemp("", "julec-")
+		if err != nil {
+			return r, err
+		}
+		defer func() {
+			if r.Binary != "" && filepath.Dir(r.Binary) == dir {
+				// Built binary is kept, just unit is removed.
+				_ = os.Remove(filepath.Join(dir, unit.Name))
+			} else {
+				_ = os.RemoveAll(dir)
+			}
+		}()
+	}
+	if dir != "" {
+		unit.Path = filepath.Join(dir, unit.Name)
+	}
+	if opts.Mode == MODE_COMPILE && opts.OutPath == "" {
+		opts.OutPath = filepath.Join(dir, BinaryName(opts.Target))
+	}
+	// Lines of generated code are mapped to unit,
+	// or name of unit if it is not written.
+	path := unit.Path
+	if path == "" {
+		path = unit.Name
+	}
+	unit.Code = models.ResolveLineResets(append_standard(p.Cpp(), &opts), path)
+	if unit.Path != "" {
+		err = write_output(unit.Path, unit.Code)
+		if err != nil {
+			return r, err
+		}
+	}
+	if opts.Mode == MODE_TRANSPILE {
+		r.Units = []Unit{unit}
+		return r, nil
+	}
+	err = build(ctx, unit.Path, &opts)
+	if opts.OutDir == "" {
+		// Unit of temporary directory is removed.
+		unit.Path = ""
+	}
+	r.Units = []Unit{unit}
+	if err != nil {
+		return r, err
+	}
+	r.Binary = opts.OutPath
+	return r, nil
+}
@@ -0,0 +1,1121 @@
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/julelang/jule/pkg/juleio"
+)
+
+// Paths of in-memory sources, source paths are absolute.
+var check_std = filepath.FromSlash("/jule/std")
+var check_main = filepath.FromSlash("/jule/src/main.jule")
+
+// check_fs returns in-memory file system with empty standard library
+// and file of main.jule.
+func check_fs(main string) juleio.FS {
+	return juleio.MapFS{
+		filepath.Join(check_std, "dummy", "dummy.jule"): nil,
+		check_main: []byte(main),
+	}
+}
+
+func TestCompileCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		library bool
+		// Keys of wanted errors in report order.
+		errs []string
+	}{
+		{
+			name: "valid",
+			code: "fn main() {\n\tlet x = 10\n\t_ = x\n}\n",
+		},
+		{
+			name: "no entry point",
+			code: "fn add(a: int, b: int): int { ret a + b }\n",
+			errs: []string{"no_entry_point"},
+		},
+		{
+			name:    "library",
+			code:    "fn add(a: int, b: int): int { ret a + b }\n",
+			library: true,
+		},
+		{
+			name: "undefined name",
+			code: "fn main() {\n\t_ = y\n}\n",
+			errs: []string{"id_not_exist"},
+		},
+		{
+			name: "mismatched types",
+			code: "fn main() {\n\tlet x: int = \"a\"\n\t_ = x\n}\n",
+			errs: []string{"incompatible_types"},
+		},
+		{
+			name: "duplicate use",
+			code: "use std::dummy\nuse std::dummy\n\nfn main() {}\n",
+			errs: []string{"already_uses"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := Compile(context.Background(), Options{
+				Path:       check_main,
+				StdlibPath: check_std,
+				FS:         check_fs(test.code),
+				Mode:       MODE_CHECK,
+				Compiler:   "gcc",
+				Library:    test.library,
+			})
+			if len(test.errs) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v: %v", err, r.Diagnostics)
+			} else if len(test.errs) > 0 && err != ErrDiagnostics {
+				t.Fatalf("got error %v, want %v", err, ErrDiagnostics)
+			}
+			if len(r.Units) > 0 {
+				t.Errorf("check mode generated %d units", len(r.Units))
+			}
+			var keys []string
+			for _, l := range r.Diagnostics {
+				if is_err(l) {
+					keys = append(keys, l.Key)
+				}
+			}
+			if len(keys) != len(test.errs) {
+				t.Fatalf("got errors %v, want %v", keys, test.errs)
+			}
+			for i, key := range keys {
+				if key != test.errs[i] {
+					t.Errorf("error %d is %q, want %q", i, key, test.errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCompileCheckStdlib(t *testing.T) {
+	_, err := Compile(context.Background(), Options{
+		Path:       check_main,
+		StdlibPath: filepath.FromSlash("/jule/no_std"),
+		FS:         check_fs("fn main() {}\n"),
+		Mode:       MODE_CHECK,
+		Compiler:   "gcc",
+	})
+	if err != ErrStdlib {
+		t.Errorf("got error %v, want %v", err, ErrStdlib)
+	}
+}
+
+func TestCompileCheckTags(t *testing.T) {
+	const code = "//jule:build foo && !bar\n\nfn main() {}\n"
+	tests := []struct {
+		tags     []string
+		excluded bool
+	}{
+		{nil, true},
+		{[]string{"foo"}, false},
+		{[]string{"bar", "foo"}, true},
+		{[]string{"baz"}, true},
+	}
+	for _, test := range tests {
+		r, _ := Compile(context.Background(), Options{
+			Path:       check_main,
+			StdlibPath: check_std,
+			FS:         check_fs(code),
+			Mode:       MODE_CHECK,
+			Compiler:   "gcc",
+			Tags:       test.tags,
+		})
+		if r.Parser.Excluded != test.excluded {
+			t.Errorf("tags %v: excluded is %v, want %v", test.tags, r.Parser.Excluded, test.excluded)
+		}
+	}
+}
+
+func TestCompileCheckLints(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		// Keys of wanted diagnostics in report order.
+		keys []string
+	}{
+		{
+			name: "same operands",
+			code: "fn main() {\n\tlet x = 10\n\toutln(x == x)\n}\n",
+			keys: []string{"comparison_always"},
+		},
+		{
+			name: "bool literals",
+			code: "fn main() {\n\toutln(true == true)\n}\n",
+			keys: []string{"comparison_always"},
+		},
+		{
+			name: "str literals",
+			code: "fn main() {\n\toutln(\"a\" != \"b\")\n}\n",
+			keys: []string{"comparison_always"},
+		},
+		{
+			name: "numeric literals",
+			code: "fn main() {\n\toutln(1 < 2)\n}\n",
+		},
+		{
+			name: "bool constant",
+			code: "const DEBUG = false\n\nfn main() {\n\toutln(DEBUG == false)\n}\n",
+		},
+		{
+			name: "unknown lint of statement",
+			code: "fn main() {\n\t//jule:allow(shadowing)\n\toutln(1)\n}\n",
+			keys: []string{"unknown_lint"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, _ := Compile(context.Background(), Options{
+				Path:       check_main,
+				StdlibPath: check_std,
+				FS:         check_fs(test.code),
+				Mode:       MODE_CHECK,
+				Compiler:   "gcc",
+			})
+			var keys []string
+			for _, l := range r.Diagnostics {
+				keys = append(keys, l.Key)
+			}
+			if len(keys) != len(test.keys) {
+				t.Fatalf("got diagnostics %v, want %v", keys, test.keys)
+			}
+			for i, key := range keys {
+				if key != test.keys[i] {
+					t.Errorf("diagnostic %d is %q, want %q", i, key, test.keys[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCompileCheckUnusedPosition(t *testing.T) {
+	tests := []struct {
+		code   string
+		column int
+	}{
+		{"fn helper() {}\n", 4},
+		{"unsafe fn helper() {}\n", 11},
+		{"struct Point {}\n", 8},
+		{"trait Shape {}\n", 7},
+		{"enum Color {\n\tRed,\n}\n", 6},
+		{"type Id: int\n", 6},
+		{"let x = 10\n", 5},
+	}
+	for _, test := range tests {
+		code := test.code + "\nfn main() {}\n"
+		r, _ := Compile(context.Background(), Options{
+			Path:       check_main,
+			StdlibPath: check_std,
+			FS:         check_fs(code),
+			Mode:       MODE_CHECK,
+			Compiler:   "gcc",
+		})
+		if len(r.Diagnostics) != 1 || r.Diagnostics[0].Key != "unused_define" {
+			t.Errorf("%q: got diagnostics %v, want unused_define", test.code, r.Diagnostics)
+			continue
+		}
+		l := r.Diagnostics[0]
+		if l.Row != 1 || l.Column != test.column {
+			t.Errorf("%q: got position %d:%d, want 1:%d", test.code, l.Row, l.Column, test.column)
+		}
+	}
+}
+
+func TestCompileCheckSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		// Wanted diagnostics as "row:column key" in report order.
+		diags []string
+	}{
+		{
+			name:  "unclosed parentheses",
+			code:  "fn main() {\n\tlet x = (1 +\n}\n",
+			diags: []string{"3:1 expected_parentheses_close", "2:13 wait_close_parentheses"},
+		},
+		{
+			name:  "unclosed bracket of nested block",
+			code:  "fn main() {\n\tif true {\n\t\tlet x = [1, 2\n\t}\n\t_ = z\n}\n",
+			diags: []string{"4:5 expected_bracket_close", "6:1 expected_bracket_close", "3:17 wait_close_bracket", "5:9 id_not_exist"},
+		},
+		{
+			name:  "unclosed string",
+			code:  "fn main() {\n\tlet s = \"abc\n\t_ = z\n}\n",
+			diags: []string{"2:17 missing_string_end", "3:9 id_not_exist"},
+		},
+		{
+			name:  "unclosed parameters of entry point",
+			code:  "fn main( {\n}\n",
+			diags: []string{"1:8 wait_close_parentheses"},
+		},
+		{
+			name:  "return of unclosed expression",
+			code:  "fn f(): int {\n\tret (1 +\n}\n\nfn main() {\n\t_ = f()\n}\n",
+			diags: []string{"3:1 expected_parentheses_close", "2:9 wait_close_parentheses"},
+		},
+		{
+			name:  "missing argument of method",
+			code:  "fn main() {\n\tlet s = \"\"\n\toutln(s.find(, \"\"))\n}\n",
+			diags: []string{"3:18 invalid_syntax"},
+		},
+		{
+			name:  "missing argument of make",
+			code:  "fn main() {\n\tlet s = make([]int, , 1)\n\t_ = s\n}\n",
+			diags: []string{"2:25 invalid_syntax"},
+		},
+		{
+			name:  "missing operand in slice",
+			code:  "fn main() {\n\tlet s = [1, (2 + )]\n\t_ = s\n}\n",
+			diags: []string{"2:20 missing_expr"},
+		},
+		{
+			name:  "undefined operand of built-in",
+			code:  "fn main() {\n\toutln(x + len)\n}\n",
+			diags: []string{"2:11 id_not_exist"},
+		},
+		{
+			name:  "invalid variadic argument",
+			code:  "fn f(a: ...i32) {}\n\nfn main() {\n\tf([1 2]...)\n}\n",
+			diags: []string{"4:8 invalid_syntax"},
+		},
+		{
+			name:  "variadic parameter without type",
+			code:  "fn f(..., s: str) {}\n\nfn main() {\n\tf(\"\")\n}\n",
+			diags: []string{"1:6 missing_type"},
+		},
+		{
+			name:  "operator in multiple declaration",
+			code:  "fn main() {\n\tlet (a, =) = 1, 2\n}\n",
+			diags: []string{"2:13 invalid_syntax"},
+		},
+		{
+			name: "assignment to undefined fields",
+			code: "struct S {\n\tx: int\n}\n\nfn main() {\n\tlet mut s = S{}\n" +
+				"\ts.a = 1\n\ts.b++\n\ts.c, s.x = 1, 2\n\ts.x = y\n}\n",
+			diags: []string{"7:7 obj_have_not_id", "8:7 obj_have_not_id", "9:7 obj_have_not_id", "10:11 id_not_exist"},
+		},
+		{
+			name:  "missing argument of out",
+			code:  "fn main() {\n\toutln()\n}\n",
+			diags: []string{"2:10 missing_expr_for"},
+		},
+		{
+			name:  "item of enum with errors",
+			code:  "enum E { A = B }\n\nfn main() {\n\toutln(E.A)\n}\n",
+			diags: []string{"1:14 id_not_exist"},
+		},
+		{
+			name:  "invalid expressions of casts",
+			code:  "fn main() {\n\t_ = (f32)(, 5000)\n\t_ = ([]byte)()\n}\n",
+			diags: []string{"2:15 invalid_syntax", "3:9 missing_expr"},
+		},
+		{
+			name:  "unclosed condition of else-if",
+			code:  "fn main() {\n\tif true {\n\t} else if (\n}\n",
+			diags: []string{"4:1 expected_parentheses_close", "3:15 wait_close_parentheses"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, _ := Compile(context.Background(), Options{
+				Path:       check_main,
+				StdlibPath: check_std,
+				FS:         check_fs(test.code),
+				Mode:       MODE_CHECK,
+				Compiler:   "gcc",
+			})
+			diags := []string{}
+			for _, l := range r.Diagnostics {
+				diags = append(diags, fmt.Sprintf("%d:%d %s", l.Row, l.Column, l.Key))
+			}
+			if !reflect.DeepEqual(diags, test.diags) {
+				t.Errorf("got diagnostics %q, want %q", diags, test.diags)
+			}
+		})
+	}
+}
+
+func TestCompileCheckErrorLimit(t *testing.T) {
+	const code = "fn main() {\n\t_ = a\n\t_ = b\n\t_ = c\n}\n"
+	tests := []struct {
+		limit int
+		// Keys of wanted errors in report order.
+		errs []string
+	}{
+		{0, []string{"id_not_exist", "id_not_exist", "id_not_exist"}},
+		{2, []string{"id_not_exist", "id_not_exist", "error_limit_exceeded"}},
+		{3, []string{"id_not_exist", "id_not_exist", "id_not_exist"}},
+	}
+	for _, test := range tests {
+		r, _ := Compile(context.Background(), Options{
+			Path:       check_main,
+			StdlibPath: check_std,
+			FS:         check_fs(code),
+			Mode:       MODE_CHECK,
+			Compiler:   "gcc",
+			ErrorLimit: test.limit,
+		})
+		var keys []string
+		for _, l := range r.Diagnostics {
+			keys = append(keys, l.Key)
+		}
+		if !reflect.DeepEqual(keys, test.errs) {
+			t.Errorf("limit %d: got errors %v, want %v", test.limit, keys, test.errs)
+		}
+	}
+}
+
+func TestCompileCheckMatches(t *testing.T) {
+	const enum = "enum Color {\n\tRed,\n\tGreen,\n\tBlue,\n}\n\n"
+	tests := []struct {
+		name string
+		code string
+		// Wanted diagnostics as "row:column key" in report order,
+		// with arguments and positions of labels.
+		diags []string
+	}{
+		{
+			name:  "duplicate case",
+			code:  "fn main() {\n\tlet x = 2\n\tmatch x {\n\tcase 1:\n\tcase 2:\n\tcase 1:\n\t}\n}\n",
+			diags: []string{"6:10 duplicate_case [4:10]"},
+		},
+		{
+			name:  "duplicate expression of case",
+			code:  "fn main() {\n\tlet x = \"a\"\n\tmatch x {\n\tcase \"a\", \"b\", \"a\":\n\t}\n}\n",
+			diags: []string{"4:20 duplicate_case [4:10]"},
+		},
+		{
+			name:  "distinct cases",
+			code:  "fn main() {\n\tlet x = 2\n\tmatch x {\n\tcase 1:\n\tcase 2:\n\t}\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "non-exhaustive enum",
+			code:  enum + "fn main() {\n\tlet c = Color.Red\n\tmatch c {\n\tcase Color.Green:\n\t}\n}\n",
+			diags: []string{"9:5 non_exhaustive_match \"Red, Blue\""},
+		},
+		{
+			name:  "exhaustive enum",
+			code:  enum + "fn main() {\n\tlet c = Color.Red\n\tmatch c {\n\tcase Color.Red, Color.Green:\n\tcase Color.Blue:\n\t}\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "enum with default",
+			code:  enum + "fn main() {\n\tlet c = Color.Red\n\tmatch c {\n\tcase Color.Red:\n\tdefault:\n\t}\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "non-exhaustive bool",
+			code:  "fn main() {\n\tlet b = true\n\tmatch b {\n\tcase true:\n\t}\n}\n",
+			diags: []string{"3:5 non_exhaustive_match \"false\""},
+		},
+		{
+			name:  "non-constant case",
+			code:  "fn main() {\n\tlet b = true\n\tlet c = false\n\tmatch b {\n\tcase c:\n\t}\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "case after default",
+			code:  "fn main() {\n\tlet x = 2\n\tmatch x {\n\tcase 1:\n\tdefault:\n\tcase 2:\n\t}\n}\n",
+			diags: []string{"6:5 case_after_default"},
+		},
+		{
+			name:  "condition after default",
+			code:  "fn main() {\n\tlet x = 2\n\tmatch {\n\tdefault:\n\tcase x > 1:\n\t}\n}\n",
+			diags: []string{"5:5 case_after_default"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, _ := Compile(context.Background(), Options{
+				Path:       check_main,
+				StdlibPath: check_std,
+				FS:         check_fs(test.code),
+				Mode:       MODE_CHECK,
+				Compiler:   "gcc",
+			})
+			diags := []string{}
+			for _, l := range r.Diagnostics {
+				diag := fmt.Sprintf("%d:%d %s", l.Row, l.Column, l.Key)
+				for _, arg := range l.Args {
+					diag += fmt.Sprintf(" %q", arg)
+				}
+				for _, label := range l.Labels {
+					diag += fmt.Sprintf(" [%d:%d]", label.Row, label.Column)
+				}
+				diags = append(diags, diag)
+			}
+			if !reflect.DeepEqual(diags, test.diags) {
+				t.Errorf("got diagnostics %q, want %q", diags, test.diags)
+			}
+		})
+	}
+}
+
+func TestCompileCheckFlow(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		// Wanted diagnostics as "row:column key" in report order,
+		// with positions of labels.
+		diags []string
+	}{
+		{
+			name:  "dereference of nil",
+			code:  "fn main() {\n\tlet mut p: *int = nil\n\tunsafe { outln(*p) }\n}\n",
+			diags: []string{"3:21 nil_dereference [2:23]"},
+		},
+		{
+			name:  "call of nil",
+			code:  "fn main() {\n\tlet f: fn() = nil\n\tf()\n}\n",
+			diags: []string{"3:5 nil_call [2:19]"},
+		},
+		{
+			name:  "global before init",
+			code:  "let mut f: fn(): int = nil\nlet x = f()\n\nfn init() {\n\tf = fn(): int { ret 1 }\n}\n\nfn main() {\n\toutln(x)\n}\n",
+			diags: []string{"2:9 nil_before_init [1:24]"},
+		},
+		{
+			name:  "nil in all branches",
+			code:  "fn main() {\n\tlet b = true\n\tlet mut p: *int = nil\n\tif b {\n\t\tp = nil\n\t} else {\n\t\toutln(b)\n\t}\n\tunsafe { outln(*p) }\n}\n",
+			diags: []string{"9:21 nil_dereference [3:23]"},
+		},
+		{
+			name:  "assigned in a branch",
+			code:  "fn main() {\n\tlet b = true\n\tlet mut x = 1\n\tlet mut p: *int = nil\n\tif b {\n\t\tp = &x\n\t}\n\tunsafe { outln(*p) }\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "checked nil",
+			code:  "fn f(p: *int) {\n\tif p == nil {\n\t\tunsafe { outln(*p) }\n\t}\n}\n\nfn main() {\n\tf(nil)\n}\n",
+			diags: []string{"3:25 nil_dereference [2:10]"},
+		},
+		{
+			name:  "return of nil branch",
+			code:  "fn f(p: *int) {\n\tif p == nil {\n\t\tret\n\t}\n\tunsafe { outln(*p) }\n}\n\nfn main() {\n\tf(nil)\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "not assigned in iteration",
+			code:  "fn main() {\n\tlet mut p: *int = nil\n\tfor _, x in [1, 2] {\n\t\toutln(x)\n\t}\n\tunsafe { outln(*p) }\n}\n",
+			diags: []string{"6:21 nil_dereference [2:23]"},
+		},
+		{
+			name:  "assigned in iteration",
+			code:  "fn main() {\n\tlet mut x = 1\n\tlet mut p: *int = nil\n\tfor _, i in [1, 2] {\n\t\tif i > 1 {\n\t\t\tunsafe { outln(*p) }\n\t\t}\n\t\tp = &x\n\t}\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "label of goto",
+			code:  "fn main() {\n\tlet mut x = 1\n\tlet mut p: *int = nil\nagain:\n\tunsafe { outln(*p) }\n\tp = &x\n\tgoto again\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "variable without initializer",
+			code:  "fn main() {\n\tlet mut x: int\n\tx = 1\n\toutln(x)\n}\n",
+			diags: []string{"2:13 variable_not_initialized"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, _ := Compile(context.Background(), Options{
+				Path:       check_main,
+				StdlibPath: check_std,
+				FS:         check_fs(test.code),
+				Mode:       MODE_CHECK,
+				Compiler:   "gcc",
+			})
+			diags := []string{}
+			for _, l := range r.Diagnostics {
+				diag := fmt.Sprintf("%d:%d %s", l.Row, l.Column, l.Key)
+				for _, label := range l.Labels {
+					diag += fmt.Sprintf(" [%d:%d]", label.Row, label.Column)
+				}
+				diags = append(diags, diag)
+			}
+			if !reflect.DeepEqual(diags, test.diags) {
+				t.Errorf("got diagnostics %q, want %q", diags, test.diags)
+			}
+		})
+	}
+}
+
+// race_fs returns in-memory file system of check_fs
+// with std::sync package for race analysis.
+func race_fs(main string) juleio.FS {
+	fs := check_fs(main).(juleio.MapFS)
+	fs[filepath.Join(check_std, "sync", "waitgroup.jule")] = []byte(
+		"pub struct WaitGroup {}\n\nimpl WaitGroup {\n\tpub fn done(mut self) {}\n\tpub fn wait(mut self) {}\n}\n")
+	fs[filepath.Join(check_std, "sync", "atomic", "atomic.jule")] = []byte(
+		"pub fn add_int(mut addr: *int, delta: int): int { ret 0 }\n")
+	return fs
+}
+
+func TestCompileCheckRaces(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		// Wanted diagnostics as "row:column key" in report order.
+		diags []string
+	}{
+		{
+			name:  "captured local",
+			code:  "fn f(n: int) {}\n\nfn main() {\n\tlet n = 1\n\tco f(n + n)\n}\n",
+			diags: []string{"5:10 co_captured_local"},
+		},
+		{
+			name:  "captured parameter",
+			code:  "fn f(n: int) {\n\tco f(n)\n}\n\nfn main() {\n\tf(1)\n}\n",
+			diags: []string{"2:10 co_captured_local"},
+		},
+		{
+			name:  "captured local of closure",
+			code:  "fn main() {\n\tlet n = 1\n\tco fn() {\n\t\tlet m = n\n\t\toutln(m)\n\t}()\n}\n",
+			diags: []string{"4:17 co_captured_local"},
+		},
+		{
+			name:  "captured local with wait",
+			code:  "use std::sync::{WaitGroup}\n\nfn f(n: int) {}\n\nfn main() {\n\tlet n = 1\n\tlet mut wg = WaitGroup{}\n\tco f(n)\n\twg.wait()\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "global and literal",
+			code:  "let g = 1\n\nfn f(n: int) {}\n\nfn main() {\n\tco f(g)\n\tco f(10)\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "reference of local",
+			code:  "fn f(mut p: *int) {}\n\nfn main() {\n\tlet mut n = 1\n\tco f(&n)\n}\n",
+			diags: []string{"5:10 co_ref_local"},
+		},
+		{
+			name:  "written global",
+			code:  "let mut total = 0\n\nfn work() {\n\ttotal++\n}\n\nfn main() {\n\tco work()\n\ttotal = 1\n}\n",
+			diags: []string{"9:5 data_race"},
+		},
+		{
+			name:  "written global with wait",
+			code:  "use std::sync::{WaitGroup}\n\nlet mut total = 0\nlet mut wg = WaitGroup{}\n\nfn work() {\n\ttotal++\n}\n\nfn main() {\n\tco work()\n\twg.wait()\n\ttotal = 1\n}\n",
+			diags: []string{},
+		},
+		{
+			name:  "atomic write of global",
+			code:  "use std::sync::atomic::{add_int}\n\nlet mut total = 0\n\nfn main() {\n\tco add_int(&total, 1)\n\ttotal = 1\n}\n",
+			diags: []string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, _ := Compile(context.Background(), Options{
+				Path:       check_main,
+				StdlibPath: check_std,
+				FS:         race_fs(test.code),
+				Mode:       MODE_CHECK,
+				Compiler:   "gcc",
+			})
+			diags := []string{}
+			for _, l := range r.Diagnostics {
+				diags = append(diags, fmt.Sprintf("%d:%d %s", l.Row, l.Column, l.Key))
+			}
+			if !reflect.DeepEqual(diags, test.diags) {
+				t.Errorf("got diagnostics %q, want %q", diags, test.diags)
+			}
+		})
+	}
+}
+
+func TestCompileCheckTargetsParallel(t *testing.T) {
+	// Literal overflows just int of 32-bit architectures.
+	const code = "fn main() {\n\tlet x: int = 3000000000\n\t_ = x\n\t_ = int.max\n}\n"
+	tests := []struct {
+		target string
+		// Keys of wanted errors in report order.
+		errs []string
+	}{
+		{"linux/amd64", nil},
+		{"linux/arm", []string{"overflow_limits", "incompatible_types"}},
+	}
+	for i := 0; i < 4; i++ {
+		for _, test := range tests {
+			test := test
+			t.Run(test.target, func(t *testing.T) {
+				t.Parallel()
+				r, _ := Compile(context.Background(), Options{
+					Path:       check_main,
+					StdlibPath: check_std,
+					FS:         check_fs(code),
+					Mode:       MODE_CHECK,
+					Compiler:   "gcc",
+					Target:     test.target,
+				})
+				var keys []string
+				for _, l := range r.Diagnostics {
+					if is_err(l) {
+						keys = append(keys, l.Key)
+					}
+				}
+				if !reflect.DeepEqual(keys, test.errs) {
+					t.Errorf("got errors %v, want %v", keys, test.errs)
+				}
+			})
+		}
+	}
+}
+
+func TestCompileTranspileParallel(t *testing.T) {
+	const code = "fn main() {\n\tif true {\n\t\toutln(1)\n\t}\n}\n"
+	line := "\n\t\t#line 3 " + strconv.Quote(check_main)
+	tests := []struct {
+		name            string
+		line_directives bool
+		panic_trace     bool
+		// Generated statement of nested block.
+		want string
+	}{
+		{"default", false, false, "\n\t\t_outln(1LL);"},
+		{"line directives", true, false, line + "\n\t\t_outln(1LL);"},
+		{"panic trace", false, true, "\n\t\t__JULEC_TRACE_AT(3, 9);_outln(1LL);"},
+		{"all", true, true, line + "\n\t\t__JULEC_TRACE_AT(3, 9);_outln(1LL);"},
+	}
+	for i := 0; i < 4; i++ {
+		for _, test := range tests {
+			test := test
+			t.Run(test.name, func(t *testing.T) {
+				t.Parallel()
+				r, err := Compile(context.Background(), Options{
+					Path:           check_main,
+					StdlibPath:     check_std,
+					FS:             check_fs(code),
+					Mode:           MODE_TRANSPILE,
+					Compiler:       "gcc",
+					LineDirectives: test.line_directives,
+					PanicTrace:     test.panic_trace,
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v: %v", err, r.Diagnostics)
+				}
+				code := r.Units[0].Code
+				if !strings.Contains(code, test.want) {
+					t.Errorf("generated code has not %q:\n%s", test.want, code)
+				}
+				if !test.line_directives && strings.Contains(code, "#line") {
+					t.Error("generated code has line directives")
+				}
+				if !test.panic_trace && strings.Contains(code, "__JULEC_TRACE_AT(") {
+					t.Error("generated code has panic traces")
+				}
+			})
+		}
+	}
+}
+
+func TestCompileTranspileLineResets(t *testing.T) {
+	const code = "struct Point {\n\tx: int\n}\n\nfn main() {\n\toutln(Point{1})\n}\n"
+	r, err := Compile(context.Background(), Options{
+		Path:           check_main,
+		StdlibPath:     check_std,
+		FS:             check_fs(code),
+		Mode:           MODE_TRANSPILE,
+		Compiler:       "gcc",
+		LineDirectives: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v: %v", err, r.Diagnostics)
+	}
+	// Beginnings of generated code after resets in generation order.
+	wants := []string{"_Point(int_jt", "std::ostream &operator<<", "void __julec_call_package_initializers"}
+	lines := strings.Split(r.Units[0].Code, "\n")
+	var resets []string
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "__JULEC_GENERATED_LINE__") {
+			t.Fatalf("line %d has placeholder of reset", i+1)
+		}
+		suffix := " " + strconv.Quote(CPP_OUT_NAME)
+		if !strings.HasPrefix(line, "#line ") || !strings.HasSuffix(line, suffix) {
+			continue
+		}
+		row := strings.TrimSuffix(strings.TrimPrefix(line, "#line "), suffix)
+		if row != strconv.Itoa(i+2) {
+			t.Errorf("line %d resets to line %s, want %d", i+1, row, i+2)
+		}
+		resets = append(resets, strings.TrimSpace(lines[i+1]))
+	}
+	if len(resets) != len(wants) {
+		t.Fatalf("got resets before %q, want %q", resets, wants)
+	}
+	for i, want := range wants {
+		if !strings.Contains(resets[i], want) {
+			t.Errorf("reset %d is before %q, want %q", i, resets[i], want)
+		}
+	}
+}
+
+// std_code uses generic built-ins, built-in defines of types
+// and packages of standard library.
+const std_code = `use std::conv::{itoa}
+use std::mem::{size_of}
+
+type[T]
+fn max(a: T, b: T): T {
+	if a > b {
+		ret a
+	}
+	ret b
+}
+
+fn main() {
+	let mut s: []int = nil
+	s = append[int](s, 1, 2, 3)
+	let mut d = make([]int, 3)
+	_ = copy[int](d, s)
+	let names: []str = append[str](nil, "a")
+	let m: [str:int] = {"a": 1}
+	outln(m.has("a"))
+	outln(m.keys())
+	let text = "hello"
+	outln(text.find("l") + names.len)
+	outln(itoa(max[int](1, 2)))
+	outln(size_of(int))
+	let b: byte = 1
+	let r: rune = 'a'
+	outln(b)
+	outln(r)
+	outln(int.max)
+}
+`
+
+var file_id = regexp.MustCompile(`\bf[0-9a-f]+_`)
+var date_line = regexp.MustCompile(`(?m)^// Date: .*$`)
+
+// Shared state of concurrent sessions is reported when run with -race.
+func TestCompileStdParallel(t *testing.T) {
+	std, err := filepath.Abs(filepath.Join("..", "std"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compile := func(target string) (string, error) {
+		r, err := Compile(context.Background(), Options{
+			Path:       check_main,
+			StdlibPath: std,
+			FS: juleio.Overlay{
+				Upper: juleio.MapFS{check_main: []byte(std_code)},
+				Lower: juleio.OS,
+			},
+			Mode:     MODE_TRANSPILE,
+			Compiler: "gcc",
+			Target:   target,
+		})
+		if err != nil {
+			return "", fmt.Errorf("%v: %v", err, r.Diagnostics)
+		}
+		// Identifiers of files have address of file and code has date of
+		// compilation, remove them to compare codes of different compilations.
+		code := file_id.ReplaceAllString(r.Units[0].Code, "f_")
+		return date_line.ReplaceAllString(code, ""), nil
+	}
+
+	// Generated codes of sequential compilations.
+	targets := []string{"linux/amd64", "linux/arm"}
+	codes := map[string]string{}
+	for _, target := range targets {
+		code, err := compile(target)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", target, err)
+		}
+		codes[target] = code
+	}
+
+	// Compilations are started by goroutines instead of parallel tests,
+	// parallel tests are not run concurrently if GOMAXPROCS is 1.
+	var wg sync.WaitGroup
+	errs := make([]error, 4*len(targets))
+	for i := range errs {
+		target := targets[i%len(targets)]
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			code, err := compile(target)
+			switch {
+			case err != nil:
+				errs[i] = fmt.Errorf("%s: unexpected error: %v", target, err)
+			case code != codes[target]:
+				errs[i] = fmt.Errorf("%s: generated code is not same with sequential compilation", target)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCompileBinaryPath(t *testing.T) {
+	if _, err := exec.LookPath("g++"); err != nil {
+		t.Skip("g++ is not found")
+	}
+	std, err := filepath.Abs(filepath.Join("..", "std"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := filepath.Abs(filepath.Join("..", "api", "julec.hpp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compile := func(out_dir string) *Result {
+		r, err := Compile(context.Background(), Options{
+			Path:       check_main,
+			StdlibPath: std,
+			HeaderPath: header,
+			FS: juleio.Overlay{
+				Upper: juleio.MapFS{check_main: []byte("fn main() {}")},
+				Lower: juleio.OS,
+			},
+			OutDir:   out_dir,
+			Mode:     MODE_COMPILE,
+			Compiler: "gcc",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v: %v", err, r.Diagnostics)
+		}
+		if _, err := os.Stat(r.Binary); err != nil {
+			t.Fatalf("binary is not built: %v", err)
+		}
+		return r
+	}
+
+	// Binary is built into output directory.
+	dir := t.TempDir()
+	r := compile(dir)
+	if want := filepath.Join(dir, BinaryName("")); r.Binary != want {
+		t.Errorf("binary is %q, want %q", r.Binary, want)
+	}
+
+	// Binary is built into temporary directory which is not removed,
+	// but unit is removed.
+	r = compile("")
+	defer os.RemoveAll(filepath.Dir(r.Binary))
+	if filepath.Base(r.Binary) != BinaryName("") {
+		t.Errorf("binary is %q, want name %q", r.Binary, BinaryName(""))
+	}
+	entries, err := os.ReadDir(filepath.Dir(r.Binary))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temporary directory has %d entries, want only binary", len(entries))
+	}
+}
+
+func TestCompileCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		c    string
+		args []string
+	}{
+		{
+			name: "defaults",
+			opts: Options{Compiler: "gcc"},
+			c:    "g++",
+			args: []string{"-O0", "-std=c++17", "ir.cpp"},
+		},
+		{
+			name: "debug, optimization and standard",
+			opts: Options{Compiler: "gcc", Debug: true, Optimization: "s", Std: "gnu++20"},
+			c:    "g++",
+			args: []string{"-g", "-Os", "-std=gnu++20", "ir.cpp"},
+		},
+		{
+			name: "flags, libraries and output",
+			opts: Options{
+				Compiler:     "clang",
+				CompilerPath: "/usr/bin/clang++-15",
+				CxxFlags:     []string{"-DX", "-Iinclude"},
+				LdFlags:      []string{"-static"},
+				Libs:         []string{"m", "pthread"},
+				OutPath:      "bin/x",
+			},
+			c: "/usr/bin/clang++-15",
+			args: []string{
+				"-O0", "-std=c++17", "-DX", "-Iinclude", "-o", "bin/x",
+				"ir.cpp", "-static", "-lm", "-lpthread",
+			},
+		},
+		{
+			name: "target of clang",
+			opts: Options{Compiler: "clang", Target: "linux/arm64"},
+			c:    "clang++",
+			args: []string{"-O0", "-std=c++17", "--target=aarch64-linux-gnu", "ir.cpp"},
+		},
+		{
+			name: "target of gcc",
+			opts: Options{Compiler: "gcc", Target: "linux/arm64"},
+			c:    "g++",
+			args: []string{"-O0", "-std=c++17", "ir.cpp"},
+		},
+		{
+			name: "sanitizers",
+			opts: Options{Compiler: "gcc", Optimization: "3", Sanitizers: []string{"address", "undefined"}},
+			c:    "g++",
+			args: []string{
+				"-g", "-O1", "-fsanitize=address,undefined", "-fno-omit-frame-pointer",
+				"-std=c++17", "ir.cpp",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, args := CompileCommand("ir.cpp", test.opts)
+			if c != test.c {
+				t.Errorf("got compiler %q, want %q", c, test.c)
+			}
+			if !reflect.DeepEqual(args, test.args) {
+				t.Errorf("got arguments %q, want %q", args, test.args)
+			}
+		})
+	}
+}
+
+func TestCheckSanitizers(t *testing.T) {
+	tests := []struct {
+		sanitizers []string
+		want       string // Error message, empty for no error.
+	}{
+		{sanitizers: nil},
+		{sanitizers: []string{"address"}},
+		{sanitizers: []string{"thread"}},
+		{sanitizers: []string{"address", "undefined"}},
+		{sanitizers: []string{"thread", "undefined"}},
+		{
+			sanitizers: []string{"address", "thread"},
+			want:       "address and thread sanitizers cannot be used together",
+		},
+		{
+			sanitizers: []string{"thread", "undefined", "address"},
+			want:       "address and thread sanitizers cannot be used together",
+		},
+		{sanitizers: []string{"adress"}, want: "unknown sanitizer: adress"},
+		{sanitizers: []string{"address", "memory"}, want: "unknown sanitizer: memory"},
+	}
+	for _, test := range tests {
+		err := check_sanitizers(test.sanitizers)
+		var got string
+		if err != nil {
+			got = err.Error()
+		}
+		if got != test.want {
+			t.Errorf("%v: got error %q, want %q", test.sanitizers, got, test.want)
+		}
+	}
+}
+
+func TestCompileCheckSuggestions(t *testing.T) {
+	const point = "struct Point {\n\tx: int\n\ty: int\n}\n\n" +
+		"impl Point {\n\tfn length(self): int { ret 0 }\n}\n\n"
+	tests := []struct {
+		name string
+		code string
+		// Wanted diagnostics as "row:column key" in report order,
+		// with notes of suggestions.
+		diags []string
+	}{
+		{
+			name:  "local variable",
+			code:  "fn main() {\n\tlet count = 1\n\t_ = count + cuont\n}\n",
+			diags: []string{"3:17 id_not_exist [did you mean `count`?]"},
+		},
+		{
+			name:  "parameter",
+			code:  "fn f(width: int): int {\n\tret widht\n}\n\nfn main() {\n\t_ = f(1)\n}\n",
+			diags: []string{"2:9 id_not_exist [did you mean `width`?]"},
+		},
+		{
+			name:  "function",
+			code:  "fn read_dir() {}\n\nfn main() {\n\tread_dri()\n}\n",
+			diags: []string{"4:5 id_not_exist [did you mean `read_dir`?]"},
+		},
+		{
+			name:  "global",
+			code:  "let COUNTER = 0\n\nfn main() {\n\t_ = counter\n}\n",
+			diags: []string{"4:9 id_not_exist [did you mean `COUNTER`?]"},
+		},
+		{
+			name:  "struct literal",
+			code:  point + "fn main() {\n\t_ = Poitn{}\n}\n",
+			diags: []string{"11:9 invalid_type_source [did you mean `Point`?]"},
+		},
+		{
+			name:  "type",
+			code:  point + "fn main() {\n\tlet p: Poitn = Point{}\n\t_ = p\n}\n",
+			diags: []string{"11:12 invalid_type_source [did you mean `Point`?]"},
+		},
+		{
+			name: "field and method",
+			code: point + "fn main() {\n\tlet p = Point{}\n\t_ = p.lenght()\n\t_ = p.z\n}\n",
+			diags: []string{
+				"12:11 obj_have_not_id [did you mean `length`?]",
+				"13:11 obj_have_not_id [did you mean `x`, `y`?]",
+			},
+		},
+		{
+			name:  "field of struct literal",
+			code:  point + "fn main() {\n\t_ = Point{x: 1, yy: 2}\n}\n",
+			diags: []string{"11:21 id_not_exist [did you mean `y`?]"},
+		},
+		{
+			name:  "trait method",
+			code:  "trait Shape {\n\tfn area(self): f64\n}\n\nstruct Square {}\n\nimpl Shape for Square {\n\tfn aera(self): f64 { ret 0 }\n}\n\nfn main() {}\n",
+			diags: []string{"7:16 trait_hasnt_id [did you mean `area`?]", "7:16 not_impl_trait_def"},
+		},
+		{
+			name:  "namespace",
+			code:  "use std::dummy\n\nfn main() {\n\tsdt::dummy::f()\n}\n",
+			diags: []string{"4:5 namespace_not_exist [did you mean `std`?]"},
+		},
+		{
+			name:  "no close candidate",
+			code:  "fn main() {\n\tlet count = 1\n\t_ = count + totally_unrelated\n}\n",
+			diags: []string{"3:17 id_not_exist"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, _ := Compile(context.Background(), Options{
+				Path:       check_main,
+				StdlibPath: check_std,
+				FS:         check_fs(test.code),
+				Mode:       MODE_CHECK,
+				Compiler:   "gcc",
+			})
+			diags := []string{}
+			for _, l := range r.Diagnostics {
+				diag := fmt.Sprintf("%d:%d %s", l.Row, l.Column, l.Key)
+				for _, note := range l.Notes {
+					diag += " [" + note + "]"
+				}
+				diags = append(diags, diag)
+			}
+			if !reflect.DeepEqual(diags, test.diags) {
+				t.Errorf("got diagnostics %q, want %q", diags, test.diags)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 // Lex is lexer of Jule.
 type Lex struct {
 	firstTokenOfLine bool
+	env              *jule.Env
 
 	File   *juleio.File
 	Pos    int
@@ -19,13 +20,19 @@ type Lex struct {
 	Row    int
 	// Logs are only errors
 	Logs []julelog.CompilerLog
+	// Byte offsets of errors in logs.
+	ErrOffsets []int
 
 	braces []Token
+	// Byte offset of rune position, see offset method.
+	offPos int
+	off    int
 }
 
-// New Lex instance.
-func NewLex(f *juleio.File) *Lex {
+// New Lex instance for environment.
+func NewLex(env *jule.Env, f *juleio.File) *Lex {
 	l := new(Lex)
+	l.env = env
 	l.File = f
 	l.Pos = 0
 	l.Row = -1 // For true row
@@ -34,22 +41,32 @@ func NewLex(f *juleio.File) *Lex {
 }
 
 func (l *Lex) pusherr(key string, args ...any) {
+	l.ErrOffsets = append(l.ErrOffsets, l.offset(l.Pos))
 	l.Logs = append(l.Logs, julelog.CompilerLog{
 		Type:    julelog.ERR,
 		Row:     l.Row,
 		Column:  l.Column,
 		Path:    l.File.Path(),
-		Message: jule.GetError(key, args...),
+		File:    l.File,
+		Message: l.env.GetError(key, args...),
+		Key:     key,
+		Args:    args,
 	})
 }
 
 func (l *Lex) pusherrtok(tok Token, err string) {
+	row, column := tok.End()
+	l.ErrOffsets = append(l.ErrOffsets, tok.Offset)
 	l.Logs = append(l.Logs, julelog.CompilerLog{
-		Type:    julelog.ERR,
-		Row:     tok.Row,
-		Column:  tok.Column,
-		Path:    l.File.Path(),
-		Message: jule.GetError(err),
+		Type:      julelog.ERR,
+		Row:       tok.Row,
+		Column:    tok.Column,
+		EndRow:    row,
+		EndColumn: column,
+		Path:      l.File.Path(),
+		File:      l.File,
+		Message:   l.env.GetError(err),
+		Key:       err,
 	})
 }
 
@@ -57,13 +74,22 @@ func (l *Lex) pusherrtok(tok Token, err string) {
 func (l *Lex) Lex() []Token {
 	var toks []Token
 	l.Logs = nil
+	l.ErrOffsets = nil
 	l.Newln()
 	for l.Pos < len(l.File.Data) {
+		errs := len(l.ErrOffsets)
 		t := l.Token()
 		l.firstTokenOfLine = false
 		if t.Id != ID_NA {
 			toks = append(toks, t)
 		}
+		// Errors are belongs to token, or previous
+		// token if text is not tokenized.
+		if len(toks) > 0 {
+			for i := errs; i < len(l.ErrOffsets); i++ {
+				l.ErrOffsets[i] = toks[len(toks)-1].Offset
+			}
+		}
 	}
 	l.checkRanges()
 	return toks
@@ -174,6 +200,26 @@ func (l *Lex) id(ln string) string {
 	return sb.String()
 }
 
+// offset returns byte offset of rune position in text of file.
+// Position is increases while lexing, so offset is
+// counted from offset of last computed position.
+func (l *Lex) offset(pos int) int {
+	if pos < l.offPos {
+		l.offPos, l.off = 0, 0
+	}
+	for ; l.offPos < pos; l.offPos++ {
+		l.off += juleio.RuneLen(l.File.Data[l.offPos])
+	}
+	return l.off
+}
+
+// end sets end of token to current position.
+func (l *Lex) end(t *Token) {
+	t.EndOffset = l.offset(l.Pos)
+	t.EndRow = l.Row
+	t.EndColumn = l.Column
+}
+
 // resume to lex from position.
 func (l *Lex) resume() string {
 	var ln string
@@ -586,8 +632,8 @@ func (l *Lex) rune(txt string) string {
 	for i := 0; i < len(txt); i++ {
 		if txt[i] == '\n' {
 			l.pusherr("missing_rune_end")
+			// Skip opening mark, newline is lexed by resume.
 			l.Pos++
-			l.Newln()
 			return ""
 		}
 		r := l.getrune(txt[i:], false)
@@ -621,17 +667,21 @@ func (l *Lex) str(txt string) string {
 	for i := 0; i < len(txt); i++ {
 		ch := txt[i]
 		if ch == '\n' {
-			l.Newln()
 			if !raw {
 				l.pusherr("missing_string_end")
+				// Skip opening mark, newline is lexed by resume.
 				l.Pos++
 				return ""
 			}
+			l.Newln()
 		}
 		r := l.getrune(txt[i:], raw)
 		sb.WriteString(r)
 		n := len(r)
-		l.Column += n
+		// Newline is already counted.
+		if ch != '\n' {
+			l.Column += n
+		}
 		if ch == mark {
 			l.Pos++
 			break
@@ -817,21 +867,28 @@ func (l *Lex) Token() Token {
 	// Set token values.
 	t.Column = l.Column
 	t.Row = l.Row
+	t.Offset = l.offset(l.Pos)
 
 	//* lex.Tokenenize
 	switch {
 	case l.lexNumeric(txt, &t):
 	case txt[0] == '\'':
 		t.Kind = l.rune(txt)
-		t.Id = ID_LITERAL
+		// Broken literals are not tokenized.
+		if t.Kind != "" {
+			t.Id = ID_LITERAL
+		}
+		l.end(&t)
 		return t
 	case txt[0] == '"' || txt[0] == '`':
 		t.Kind = l.str(txt)
-		t.Id = ID_LITERAL
+		if t.Kind != "" {
+			t.Id = ID_LITERAL
+		}
+		l.end(&t)
 		return t
 	case strings.HasPrefix(txt, KND_LN_COMMENT):
 		l.lncomment(&t)
-		return t
 	case strings.HasPrefix(txt, KND_RNG_LCOMMENT):
 		l.rangecomment()
 		return t
@@ -859,6 +916,7 @@ func (l *Lex) Token() Token {
 		return t
 	}
 	l.Column += len(t.Kind)
+	l.end(&t)
 	return t
 }
 
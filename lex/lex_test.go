@@ -0,0 +1,96 @@
+package lex
+
+import (
+	"testing"
+
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/juleio"
+)
+
+func lex_text(t *testing.T, text string) []Token {
+	f := &juleio.File{Name: "test.jule", Data: []rune(text)}
+	l := NewLex(jule.NewEnv(), f)
+	toks := l.Lex()
+	if len(l.Logs) > 0 {
+		t.Fatalf("unexpected lexer errors: %v", l.Logs)
+	}
+	return toks
+}
+
+func TestTokenPositions(t *testing.T) {
+	type pos struct {
+		kind                string
+		row, column         int
+		end_row, end_column int
+	}
+	tests := []struct {
+		name string
+		text string
+		want []pos
+	}{
+		{
+			name: "simple",
+			text: "let x = 1",
+			want: []pos{
+				{"let", 1, 1, 1, 4},
+				{"x", 1, 5, 1, 6},
+				{"=", 1, 7, 1, 8},
+				{"1", 1, 9, 1, 10},
+			},
+		},
+		{
+			name: "raw string with newline",
+			text: "let x = `a\nbc` + y",
+			want: []pos{
+				{"let", 1, 1, 1, 4},
+				{"x", 1, 5, 1, 6},
+				{"=", 1, 7, 1, 8},
+				{"`a\nbc`", 1, 9, 2, 4},
+				{"+", 2, 5, 2, 6},
+				{"y", 2, 7, 2, 8},
+			},
+		},
+		{
+			name: "line comment",
+			text: "// note\nx // note",
+			want: []pos{
+				{"// note", 1, 1, 1, 8},
+				{"x", 2, 1, 2, 2},
+			},
+		},
+		{
+			name: "tab is four columns",
+			text: "\tx",
+			want: []pos{
+				{"x", 1, 5, 1, 6},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			toks := lex_text(t, test.text)
+			if len(toks) != len(test.want) {
+				t.Fatalf("got %d tokens, want %d", len(toks), len(test.want))
+			}
+			for i, want := range test.want {
+				tok := toks[i]
+				end_row, end_column := tok.End()
+				got := pos{tok.Kind, tok.Row, tok.Column, end_row, end_column}
+				if got != want {
+					t.Errorf("token %d: got %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenOffsets(t *testing.T) {
+	text := "let s = \"ğü\" // ç\nx"
+	toks := lex_text(t, text)
+	for _, tok := range toks {
+		got := text[tok.Offset:tok.EndOffset]
+		if got != tok.Kind {
+			t.Errorf("%q: offsets select %q", tok.Kind, got)
+		}
+	}
+}
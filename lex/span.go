@@ -0,0 +1,50 @@
+package lex
+
+import "github.com/julelang/jule/pkg/juleio"
+
+// Span is source range.
+// Offsets are byte offsets in text of file, end offset is exclusive.
+// End row and column is the position just after range.
+type Span struct {
+	File      *juleio.File
+	Offset    int
+	EndOffset int
+	Row       int
+	Column    int
+	EndRow    int
+	EndColumn int
+}
+
+// IsZero reports span is not set.
+func (s Span) IsZero() bool { return s.Row == 0 }
+
+// To returns span from beginning of s to end of end.
+//
+// Special cases are;
+//
+//	s.To(end) -> returns end if s is zero
+//	s.To(end) -> returns s if end is zero
+func (s Span) To(end Span) Span {
+	switch {
+	case s.IsZero():
+		return end
+	case end.IsZero():
+		return s
+	}
+	s.EndOffset = end.EndOffset
+	s.EndRow = end.EndRow
+	s.EndColumn = end.EndColumn
+	return s
+}
+
+// SpanOf returns span from first token to last token.
+//
+// Special case is;
+//
+//	SpanOf(toks) -> returns zero span if len(toks) == 0
+func SpanOf(toks []Token) Span {
+	if len(toks) == 0 {
+		return Span{}
+	}
+	return toks[0].Span().To(toks[len(toks)-1].Span())
+}
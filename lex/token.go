@@ -147,4 +147,46 @@ type Token struct {
 	Column int
 	Kind   string
 	Id     uint8
+
+	// Byte offsets of token in text of file.
+	// End offset is exclusive.
+	Offset    int
+	EndOffset int
+	// Row and column of the position just after token.
+	// Zero if token is not lexed from source.
+	EndRow    int
+	EndColumn int
+}
+
+// End returns row and column of the position just after token.
+// Position is computed from kind if token is not lexed from source.
+func (t *Token) End() (row, column int) {
+	if t.EndRow != 0 {
+		return t.EndRow, t.EndColumn
+	}
+	row = t.Row
+	column = t.Column
+	for _, r := range t.Kind {
+		if r == '\n' {
+			row++
+			column = 1
+			continue
+		}
+		column += len(string(r))
+	}
+	return
+}
+
+// Span returns source range of token.
+func (t *Token) Span() Span {
+	row, column := t.End()
+	return Span{
+		File:      t.File,
+		Offset:    t.Offset,
+		EndOffset: t.EndOffset,
+		Row:       t.Row,
+		Column:    t.Column,
+		EndRow:    row,
+		EndColumn: column,
+	}
 }
@@ -0,0 +1,239 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/parser"
+	"github.com/julelang/jule/pkg/juleio"
+	"github.com/julelang/jule/pkg/julelog"
+)
+
+const diagnostic_source = "julec"
+
+// Tabs are counted as 4 columns by lexer.
+const tab_columns = 4
+
+// document is an open text document of client.
+type document struct {
+	uri   string
+	file  *juleio.File
+	lines []string
+	toks  []lex.Token
+	p     *parser.Parser
+}
+
+// uri_to_path returns file system path of file URI.
+// Returns uri as is if uri is not a file URI.
+func uri_to_path(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// path_to_uri returns file URI of file system path.
+func path_to_uri(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return u.String()
+}
+
+func new_document(s *parser.Session, uri, text string) *document {
+	d := &document{uri: uri}
+	d.update(s, text)
+	return d
+}
+
+// update sets text of document and analyzes it from in-memory buffer
+// with session. Other files of package are read from file system.
+func (d *document) update(s *parser.Session, text string) {
+	d.file = new(juleio.File)
+	d.file.Dir, d.file.Name = filepath.Split(uri_to_path(d.uri))
+	d.file.Data = []rune(text)
+	d.lines = strings.Split(text, "\n")
+	d.toks = lex.NewLex(s.Env, d.file).Lex()
+	d.p = analyze(s, d.file)
+}
+
+// analyze parses and checks file.
+//
+// Special case is;
+//
+//	analyze(s, f) -> returns nil if parser panics
+func analyze(s *parser.Session, f *juleio.File) (p *parser.Parser) {
+	defer func() {
+		if recover() != nil {
+			p = nil
+		}
+	}()
+	p = parser.New(s, f)
+	p.SetupPackage()
+	p.Parsef(false, false)
+	return p
+}
+
+// position returns LSP position of token position in lines.
+func position(lines []string, row, column int) Position {
+	pos := Position{Line: row - 1}
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return Position{}
+	}
+	n := 1
+	for _, r := range lines[pos.Line] {
+		if n >= column {
+			break
+		}
+		if r == '\t' {
+			n += tab_columns
+		} else {
+			n += utf8.RuneLen(r)
+		}
+		pos.Character += utf16_len(r)
+	}
+	return pos
+}
+
+// position returns LSP position of token position.
+func (d *document) position(row, column int) Position {
+	return position(d.lines, row, column)
+}
+
+// column returns token row and column of LSP position.
+func (d *document) column(pos Position) (row, column int) {
+	row, column = pos.Line+1, 1
+	if pos.Line < 0 || pos.Line >= len(d.lines) {
+		return
+	}
+	n := 0
+	for _, r := range d.lines[pos.Line] {
+		if n >= pos.Character {
+			break
+		}
+		if r == '\t' {
+			column += tab_columns
+		} else {
+			column += utf8.RuneLen(r)
+		}
+		n += utf16_len(r)
+	}
+	return
+}
+
+func utf16_len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// offset_position returns LSP position of byte offset in file.
+func offset_position(f *juleio.File, offset int) Position {
+	row, column := f.Position(offset)
+	start := f.Lines()[row-1]
+	pos := Position{Line: row - 1}
+	for _, r := range f.Text()[start : start+column-1] {
+		pos.Character += utf16_len(r)
+	}
+	return pos
+}
+
+// span_range returns range of span.
+func span_range(s lex.Span) Range {
+	return Range{
+		Start: offset_position(s.File, s.Offset),
+		End:   offset_position(s.File, s.EndOffset),
+	}
+}
+
+// token_range returns range of token in lines.
+// Range of lexed tokens is computed from byte offsets.
+func token_range(lines []string, tok lex.Token) Range {
+	if tok.File != nil && tok.EndRow != 0 {
+		return span_range(tok.Span())
+	}
+	row, column := tok.End()
+	return Range{
+		Start: position(lines, tok.Row, tok.Column),
+		End:   position(lines, row, column),
+	}
+}
+
+// token_location returns location of token.
+// Token of other files is located by content of file.
+func (d *document) token_location(tok lex.Token) Location {
+	if tok.File == nil || tok.File == d.file {
+		return Location{URI: d.uri, Range: token_range(d.lines, tok)}
+	}
+	var lines []string
+	if tok.EndRow == 0 {
+		lines = strings.Split(string(tok.File.Data), "\n")
+	}
+	return Location{
+		URI:   path_to_uri(tok.File.Path()),
+		Range: token_range(lines, tok),
+	}
+}
+
+// token_at returns index of token at position.
+// Position just after token is accepted to support typing.
+//
+// Special case is;
+//
+//	token_at(pos) -> returns -1 if there is no token at position
+func (d *document) token_at(pos Position) int {
+	row, column := d.column(pos)
+	for i, tok := range d.toks {
+		if tok.Row != row || tok.Id == lex.ID_COMMENT {
+			continue
+		}
+		if column >= tok.Column && column <= tok.Column+len(tok.Kind) {
+			// Prefer identifier if position is between two tokens.
+			if column == tok.Column+len(tok.Kind) && i+1 < len(d.toks) &&
+				d.toks[i+1].Column == column && d.toks[i+1].Row == row {
+				return i + 1
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// diagnostics returns logs of document as diagnostics.
+// Logs of other files are not included.
+func (d *document) diagnostics() []Diagnostic {
+	diags := []Diagnostic{}
+	if d.p == nil {
+		return diags
+	}
+	logs := append(d.p.Errors[:len(d.p.Errors):len(d.p.Errors)], d.p.Warnings...)
+	path := d.file.Path()
+	for _, log := range logs {
+		diag := Diagnostic{
+			Severity: SEVERITY_ERROR,
+			Code:     log.Code(),
+			Source:   diagnostic_source,
+			Message:  log.Message,
+		}
+		switch log.Type {
+		case julelog.FLAT_WARN, julelog.WARN:
+			diag.Severity = SEVERITY_WARNING
+		}
+		switch log.Type {
+		case julelog.ERR, julelog.WARN:
+			if log.Path != path {
+				continue
+			}
+			diag.Range.Start = d.position(log.Row, log.Column)
+			diag.Range.End = diag.Range.Start
+			if log.EndRow > 0 {
+				diag.Range.End = d.position(log.EndRow, log.EndColumn)
+			}
+		}
+		diags = append(diags, diag)
+	}
+	return diags
+}
@@ -0,0 +1,105 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSON-RPC error codes.
+const ERR_PARSE = -32700
+const ERR_INVALID_REQUEST = -32600
+const ERR_METHOD_NOT_FOUND = -32601
+const ERR_INVALID_PARAMS = -32602
+const ERR_SERVER_NOT_INITIALIZED = -32002
+
+const jsonrpc_version = "2.0"
+const content_length = "Content-Length"
+
+// message is incoming JSON-RPC request or notification.
+// Notifications have not id.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+func (m *message) is_notification() bool { return m.Id == nil }
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+}
+
+type error_response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Error   *Error          `json:"error"`
+}
+
+type notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// Error is a JSON-RPC error.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// read_message reads content of next message.
+// Header fields except Content-Length are ignored.
+func read_message(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		i := strings.IndexByte(line, ':')
+		if i == -1 {
+			return nil, errors.New("invalid header: " + line)
+		}
+		if !strings.EqualFold(strings.TrimSpace(line[:i]), content_length) {
+			continue
+		}
+		length, err = strconv.Atoi(strings.TrimSpace(line[i+1:]))
+		if err != nil || length < 0 {
+			return nil, errors.New("invalid content length: " + line[i+1:])
+		}
+	}
+	if length == -1 {
+		return nil, errors.New("missing content length")
+	}
+	content := make([]byte, length)
+	_, err := io.ReadFull(r, content)
+	return content, err
+}
+
+// write_message writes value as message content.
+func write_message(w io.Writer, v any) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s: %d\r\n\r\n", content_length, len(content))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
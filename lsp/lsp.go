@@ -0,0 +1,292 @@
+// Package lsp implements the Language Server Protocol for Jule.
+//
+// Server communicates with JSON-RPC messages over any reader and writer,
+// julec uses stdin and stdout. Documents are analyzed from in-memory
+// buffers of client with the lexer, AST builder and parser of compiler.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/julelang/jule/parser"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/juleio"
+)
+
+const server_name = "julec"
+
+// Server is a language server.
+type Server struct {
+	in          *bufio.Reader
+	out         io.Writer
+	docs        map[string]*document
+	initialized bool
+	shutdown    bool
+
+	// NewSession returns session for each analysis of documents.
+	// Defaults to session of process environment.
+	NewSession func() *parser.Session
+}
+
+// New returns new server reads requests from r and writes responses to w.
+func New(r io.Reader, w io.Writer) *Server {
+	return &Server{
+		in:   bufio.NewReader(r),
+		out:  w,
+		docs: map[string]*document{},
+		NewSession: func() *parser.Session {
+			return parser.NewSession(jule.NewEnv())
+		},
+	}
+}
+
+// Serve handles messages until exit notification or end of input.
+// Returns error if input is broken or exit without shutdown request.
+func (s *Server) Serve() error {
+	for {
+		content, err := read_message(s.in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var msg message
+		err = json.Unmarshal(content, &msg)
+		if err != nil {
+			err = s.reply_error(nil, &Error{Code: ERR_PARSE, Message: err.Error()})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit without shutdown")
+			}
+			return nil
+		}
+		err = s.handle(&msg)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// session returns new session for analysis.
+// Open documents shadows files of file system,
+// so unsaved changes of package files are analyzed.
+func (s *Server) session() *parser.Session {
+	session := s.NewSession()
+	buffers := juleio.MapFS{}
+	for _, d := range s.docs {
+		buffers[d.file.Path()] = []byte(string(d.file.Data))
+	}
+	session.FS = juleio.Overlay{Upper: buffers, Lower: session.FS}
+	return session
+}
+
+func (s *Server) reply(id json.RawMessage, result any) error {
+	return write_message(s.out, response{
+		JSONRPC: jsonrpc_version,
+		Id:      id,
+		Result:  result,
+	})
+}
+
+func (s *Server) reply_error(id json.RawMessage, e *Error) error {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return write_message(s.out, error_response{
+		JSONRPC: jsonrpc_version,
+		Id:      id,
+		Error:   e,
+	})
+}
+
+func (s *Server) notify(method string, params any) error {
+	return write_message(s.out, notification{
+		JSONRPC: jsonrpc_version,
+		Method:  method,
+		Params:  params,
+	})
+}
+
+// handle handles message and writes response if message is request.
+func (s *Server) handle(msg *message) error {
+	var result any
+	var e *Error
+	switch {
+	case msg.Method == "initialize":
+		s.initialized = true
+		result = s.initialize()
+	case !s.initialized:
+		e = &Error{Code: ERR_SERVER_NOT_INITIALIZED, Message: "server is not initialized"}
+	case msg.Method == "shutdown":
+		s.shutdown = true
+	case msg.Method == "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if e = unmarshal_params(msg.Params, &params); e == nil {
+			return s.open(params)
+		}
+	case msg.Method == "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if e = unmarshal_params(msg.Params, &params); e == nil {
+			return s.change(params)
+		}
+	case msg.Method == "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if e = unmarshal_params(msg.Params, &params); e == nil {
+			return s.close(params)
+		}
+	case msg.Method == "textDocument/hover":
+		var params TextDocumentPositionParams
+		if e = unmarshal_params(msg.Params, &params); e == nil {
+			result = s.hover(params)
+		}
+	case msg.Method == "textDocument/definition":
+		var params TextDocumentPositionParams
+		if e = unmarshal_params(msg.Params, &params); e == nil {
+			result = s.definition(params)
+		}
+	case msg.Method == "textDocument/documentSymbol":
+		var params DocumentSymbolParams
+		if e = unmarshal_params(msg.Params, &params); e == nil {
+			result = s.document_symbols(params)
+		}
+	case msg.Method == "textDocument/completion":
+		var params TextDocumentPositionParams
+		if e = unmarshal_params(msg.Params, &params); e == nil {
+			result = s.completion(params)
+		}
+	default:
+		e = &Error{Code: ERR_METHOD_NOT_FOUND, Message: "method not found: " + msg.Method}
+	}
+	// Notifications have not response even if failed.
+	if msg.is_notification() {
+		return nil
+	}
+	if e != nil {
+		return s.reply_error(msg.Id, e)
+	}
+	return s.reply(msg.Id, result)
+}
+
+func (s *Server) initialize() InitializeResult {
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync:       SYNC_FULL,
+			HoverProvider:          true,
+			DefinitionProvider:     true,
+			DocumentSymbolProvider: true,
+			CompletionProvider: &CompletionOptions{
+				TriggerCharacters: []string{".", ":"},
+			},
+		},
+		ServerInfo: ServerInfo{Name: server_name, Version: jule.VERSION},
+	}
+}
+
+func (s *Server) publish_diagnostics(d *document) error {
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         d.uri,
+		Diagnostics: d.diagnostics(),
+	})
+}
+
+func (s *Server) open(params DidOpenTextDocumentParams) error {
+	d := new_document(s.session(), params.TextDocument.URI, params.TextDocument.Text)
+	s.docs[d.uri] = d
+	return s.publish_diagnostics(d)
+}
+
+func (s *Server) change(params DidChangeTextDocumentParams) error {
+	d := s.docs[params.TextDocument.URI]
+	n := len(params.ContentChanges)
+	if d == nil || n == 0 {
+		return nil
+	}
+	// Full synchronization, last change has whole text.
+	d.update(s.session(), params.ContentChanges[n-1].Text)
+	return s.publish_diagnostics(d)
+}
+
+func (s *Server) close(params DidCloseTextDocumentParams) error {
+	uri := params.TextDocument.URI
+	if s.docs[uri] == nil {
+		return nil
+	}
+	delete(s.docs, uri)
+	// Clear diagnostics of closed document.
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: []Diagnostic{},
+	})
+}
+
+// symbol_at returns document and symbol at position.
+//
+// Special case is;
+//
+//	symbol_at(params) -> returns nil symbol if there is no symbol
+func (s *Server) symbol_at(params TextDocumentPositionParams) (*document, *symbol, int) {
+	d := s.docs[params.TextDocument.URI]
+	if d == nil {
+		return nil, nil, -1
+	}
+	i := d.token_at(params.Position)
+	if i == -1 {
+		return d, nil, -1
+	}
+	return d, d.resolve(i), i
+}
+
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	d, sym, i := s.symbol_at(params)
+	if sym == nil {
+		return nil
+	}
+	r := token_range(d.lines, d.toks[i])
+	return &Hover{Contents: sym.hover(), Range: &r}
+}
+
+func (s *Server) definition(params TextDocumentPositionParams) *Location {
+	d, sym, _ := s.symbol_at(params)
+	if sym == nil || sym.token.File == nil {
+		return nil
+	}
+	loc := d.token_location(sym.token)
+	return &loc
+}
+
+func (s *Server) document_symbols(params DocumentSymbolParams) []DocumentSymbol {
+	d := s.docs[params.TextDocument.URI]
+	if d == nil {
+		return []DocumentSymbol{}
+	}
+	return d.document_symbols()
+}
+
+func (s *Server) completion(params TextDocumentPositionParams) CompletionList {
+	list := CompletionList{Items: []CompletionItem{}}
+	d := s.docs[params.TextDocument.URI]
+	if d == nil {
+		return list
+	}
+	for _, sym := range d.completions(params.Position) {
+		item := CompletionItem{
+			Label:  sym.id,
+			Kind:   completion_kind(sym.kind),
+			Detail: sym.detail,
+		}
+		if sym.desc != "" {
+			item.Documentation = &MarkupContent{Kind: MARKUP_MARKDOWN, Value: sym.desc}
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list
+}
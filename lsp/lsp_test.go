@@ -0,0 +1,291 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/julelang/jule/parser"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/juleio"
+)
+
+// Paths of in-memory sources, source paths are absolute.
+var test_std = filepath.FromSlash("/jule/std")
+var test_path = filepath.FromSlash("/jule/src/main.jule")
+var test_uri = path_to_uri(test_path)
+
+const test_text = `// Adds numbers.
+fn add(a: int, b: int): int {
+	ret a + b
+}
+
+struct Point {
+	x: int
+	y: int
+}
+
+fn main() {
+	let p = Point{1, 2}
+	outln(add(p.x, p.y))
+}
+`
+
+// test_message is message of server.
+type test_message struct {
+	Id     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// test_client frames messages of client.
+type test_client struct {
+	in bytes.Buffer
+	id int
+}
+
+func (c *test_client) request(method string, params any) {
+	c.id++
+	write_message(&c.in, map[string]any{
+		"jsonrpc": jsonrpc_version,
+		"id":      c.id,
+		"method":  method,
+		"params":  params,
+	})
+}
+
+func (c *test_client) notify(method string, params any) {
+	write_message(&c.in, notification{
+		JSONRPC: jsonrpc_version,
+		Method:  method,
+		Params:  params,
+	})
+}
+
+// serve serves messages of client and returns messages of server.
+func (c *test_client) serve(t *testing.T) ([]test_message, error) {
+	var out bytes.Buffer
+	s := New(&c.in, &out)
+	s.NewSession = func() *parser.Session {
+		env := jule.NewEnv()
+		env.StdlibPath = test_std
+		session := parser.NewSession(env)
+		session.FS = juleio.MapFS{
+			filepath.Join(test_std, "dummy", "dummy.jule"): nil,
+			test_path: []byte(test_text),
+		}
+		return session
+	}
+	err := s.Serve()
+	var msgs []test_message
+	r := bufio.NewReader(&out)
+	for {
+		content, read_err := read_message(r)
+		if read_err == io.EOF {
+			break
+		}
+		if read_err != nil {
+			t.Fatal(read_err)
+		}
+		var msg test_message
+		if err := json.Unmarshal(content, &msg); err != nil {
+			t.Fatal(err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, err
+}
+
+func position_params(line, character int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{test_uri},
+		Position:     Position{line, character},
+	}
+}
+
+func find_document_symbol(syms []DocumentSymbol, name string) *DocumentSymbol {
+	for i := range syms {
+		if syms[i].Name == name {
+			return &syms[i]
+		}
+	}
+	return nil
+}
+
+func TestServe(t *testing.T) {
+	var c test_client
+	c.request("initialize", map[string]any{})
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: test_uri, LanguageId: "jule", Version: 1, Text: test_text},
+	})
+	// Identifier of add call.
+	c.request("textDocument/hover", position_params(12, 8))
+	c.request("textDocument/definition", position_params(12, 8))
+	c.request("textDocument/documentSymbol", DocumentSymbolParams{TextDocumentIdentifier{test_uri}})
+	// Just after dot of p.x selection.
+	c.request("textDocument/completion", position_params(12, 13))
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument: TextDocumentIdentifier{test_uri},
+		ContentChanges: []TextDocumentContentChangeEvent{
+			{Text: "fn main() {\n\tlet x: int = \"a\"\n\toutln(x)\n}\n"},
+		},
+	})
+	c.request("shutdown", nil)
+	c.notify("exit", nil)
+
+	msgs, err := c.serve(t)
+	if err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	methods := []string{"", "textDocument/publishDiagnostics", "", "", "", "", "textDocument/publishDiagnostics", ""}
+	if len(msgs) != len(methods) {
+		t.Fatalf("got %d messages, want %d", len(msgs), len(methods))
+	}
+	for i, method := range methods {
+		if msgs[i].Method != method {
+			t.Fatalf("message %d has method %q, want %q", i, msgs[i].Method, method)
+		}
+		if msgs[i].Error != nil {
+			t.Fatalf("message %d has error: %v", i, msgs[i].Error)
+		}
+	}
+
+	t.Run("initialize", func(t *testing.T) {
+		var result InitializeResult
+		json.Unmarshal(msgs[0].Result, &result)
+		caps := result.Capabilities
+		if string(msgs[0].Id) != "1" || caps.TextDocumentSync != SYNC_FULL ||
+			!caps.HoverProvider || !caps.DefinitionProvider || !caps.DocumentSymbolProvider ||
+			caps.CompletionProvider == nil {
+			t.Errorf("got initialize result %s", msgs[0].Result)
+		}
+	})
+
+	t.Run("didOpen", func(t *testing.T) {
+		var params PublishDiagnosticsParams
+		json.Unmarshal(msgs[1].Params, &params)
+		if params.URI != test_uri || len(params.Diagnostics) != 0 {
+			t.Errorf("got diagnostics %s", msgs[1].Params)
+		}
+	})
+
+	t.Run("hover", func(t *testing.T) {
+		var hover Hover
+		json.Unmarshal(msgs[2].Result, &hover)
+		if !strings.Contains(hover.Contents.Value, "fn add(int, int)int") ||
+			!strings.Contains(hover.Contents.Value, "Adds numbers.") {
+			t.Errorf("got hover %q", hover.Contents.Value)
+		}
+		want := Range{Position{12, 7}, Position{12, 10}}
+		if hover.Range == nil || *hover.Range != want {
+			t.Errorf("got hover range %v, want %v", hover.Range, want)
+		}
+	})
+
+	t.Run("definition", func(t *testing.T) {
+		var loc Location
+		json.Unmarshal(msgs[3].Result, &loc)
+		want := Location{test_uri, Range{Position{1, 3}, Position{1, 6}}}
+		if loc != want {
+			t.Errorf("got definition %v, want %v", loc, want)
+		}
+	})
+
+	t.Run("documentSymbol", func(t *testing.T) {
+		var syms []DocumentSymbol
+		json.Unmarshal(msgs[4].Result, &syms)
+		tests := []struct {
+			name      string
+			kind      int
+			r         Range
+			selection Range
+			children  []string
+		}{
+			{"add", SYMBOL_FUNCTION, Range{Position{1, 0}, Position{3, 1}}, Range{Position{1, 3}, Position{1, 6}}, nil},
+			{"Point", SYMBOL_STRUCT, Range{Position{5, 0}, Position{8, 1}}, Range{Position{5, 7}, Position{5, 12}}, []string{"x", "y"}},
+			{"main", SYMBOL_FUNCTION, Range{Position{10, 0}, Position{13, 1}}, Range{Position{10, 3}, Position{10, 7}}, nil},
+		}
+		if len(syms) != len(tests) {
+			t.Fatalf("got %d symbols, want %d", len(syms), len(tests))
+		}
+		for _, test := range tests {
+			s := find_document_symbol(syms, test.name)
+			if s == nil {
+				t.Errorf("symbol %s is not found", test.name)
+				continue
+			}
+			if s.Kind != test.kind || s.Range != test.r || s.SelectionRange != test.selection {
+				t.Errorf("got symbol %+v, want %+v", *s, test)
+			}
+			var children []string
+			for _, child := range s.Children {
+				children = append(children, child.Name)
+			}
+			if !reflect.DeepEqual(children, test.children) {
+				t.Errorf("got children %v of %s, want %v", children, test.name, test.children)
+			}
+		}
+	})
+
+	t.Run("completion", func(t *testing.T) {
+		var list CompletionList
+		json.Unmarshal(msgs[5].Result, &list)
+		var labels []string
+		for _, item := range list.Items {
+			labels = append(labels, item.Label)
+		}
+		if !reflect.DeepEqual(labels, []string{"x", "y"}) {
+			t.Errorf("got completion %v, want [x y]", labels)
+		}
+	})
+
+	t.Run("didChange", func(t *testing.T) {
+		var params PublishDiagnosticsParams
+		json.Unmarshal(msgs[6].Params, &params)
+		if len(params.Diagnostics) != 1 {
+			t.Fatalf("got diagnostics %s", msgs[6].Params)
+		}
+		d := params.Diagnostics[0]
+		if d.Code != jule.GetCode("incompatible_types") || d.Range.Start.Line != 1 {
+			t.Errorf("got diagnostic %+v", d)
+		}
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		if string(msgs[7].Id) != "6" || string(msgs[7].Result) != "null" {
+			t.Errorf("got shutdown response id %s and result %s", msgs[7].Id, msgs[7].Result)
+		}
+	})
+}
+
+func TestServeErrors(t *testing.T) {
+	var c test_client
+	c.request("textDocument/hover", position_params(0, 0))
+	c.request("initialize", map[string]any{})
+	c.request("unknown", nil)
+	c.notify("exit", nil)
+
+	msgs, err := c.serve(t)
+	if err == nil {
+		t.Error("exit without shutdown is not failed")
+	}
+	codes := []int{ERR_SERVER_NOT_INITIALIZED, 0, ERR_METHOD_NOT_FOUND}
+	if len(msgs) != len(codes) {
+		t.Fatalf("got %d messages, want %d", len(msgs), len(codes))
+	}
+	for i, code := range codes {
+		switch {
+		case code == 0 && msgs[i].Error != nil:
+			t.Errorf("message %d has error: %v", i, msgs[i].Error)
+		case code != 0 && (msgs[i].Error == nil || msgs[i].Error.Code != code):
+			t.Errorf("message %d has error %v, want code %d", i, msgs[i].Error, code)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package lsp
+
+import "encoding/json"
+
+// Types of Language Server Protocol.
+// Only used fields are declared.
+
+// Text document synchronization kinds.
+const SYNC_FULL = 1
+
+// Diagnostic severities.
+const SEVERITY_ERROR = 1
+const SEVERITY_WARNING = 2
+
+// Symbol kinds.
+const SYMBOL_NAMESPACE = 3
+const SYMBOL_METHOD = 6
+const SYMBOL_FIELD = 8
+const SYMBOL_ENUM = 10
+const SYMBOL_INTERFACE = 11
+const SYMBOL_FUNCTION = 12
+const SYMBOL_VARIABLE = 13
+const SYMBOL_CONSTANT = 14
+const SYMBOL_ENUM_MEMBER = 22
+const SYMBOL_STRUCT = 23
+const SYMBOL_TYPE_PARAMETER = 26
+
+// Completion item kinds.
+const COMPLETION_METHOD = 2
+const COMPLETION_FUNCTION = 3
+const COMPLETION_FIELD = 5
+const COMPLETION_VARIABLE = 6
+const COMPLETION_INTERFACE = 8
+const COMPLETION_MODULE = 9
+const COMPLETION_ENUM = 13
+const COMPLETION_ENUM_MEMBER = 20
+const COMPLETION_CONSTANT = 21
+const COMPLETION_STRUCT = 22
+const COMPLETION_TYPE_PARAMETER = 25
+
+// Markup kinds.
+const MARKUP_MARKDOWN = "markdown"
+
+// Position is zero-based line and UTF-16 based character offset.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+type Location struct {
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+type Diagnostic struct {
+	Range    Range  `json:"range"`
+	Severity int    `json:"severity"`
+	Code     string `json:"code,omitempty"`
+	Source   string `json:"source"`
+	Message  string `json:"message"`
+}
+
+type PublishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+type TextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type TextDocumentItem struct {
+	URI        string `json:"uri"`
+	LanguageId string `json:"languageId"`
+	Version    int    `json:"version"`
+	Text       string `json:"text"`
+}
+
+type TextDocumentPositionParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+}
+
+type DidOpenTextDocumentParams struct {
+	TextDocument TextDocumentItem `json:"textDocument"`
+}
+
+type TextDocumentContentChangeEvent struct {
+	Text string `json:"text"`
+}
+
+type DidChangeTextDocumentParams struct {
+	TextDocument   TextDocumentIdentifier           `json:"textDocument"`
+	ContentChanges []TextDocumentContentChangeEvent `json:"contentChanges"`
+}
+
+type DidCloseTextDocumentParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+type DocumentSymbolParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+	Range    *Range        `json:"range,omitempty"`
+}
+
+type DocumentSymbol struct {
+	Name           string           `json:"name"`
+	Detail         string           `json:"detail,omitempty"`
+	Kind           int              `json:"kind"`
+	Range          Range            `json:"range"`
+	SelectionRange Range            `json:"selectionRange"`
+	Children       []DocumentSymbol `json:"children,omitempty"`
+}
+
+type CompletionItem struct {
+	Label         string         `json:"label"`
+	Kind          int            `json:"kind,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Documentation *MarkupContent `json:"documentation,omitempty"`
+}
+
+type CompletionList struct {
+	IsIncomplete bool             `json:"isIncomplete"`
+	Items        []CompletionItem `json:"items"`
+}
+
+type CompletionOptions struct {
+	TriggerCharacters []string `json:"triggerCharacters"`
+}
+
+type ServerCapabilities struct {
+	TextDocumentSync       int                `json:"textDocumentSync"`
+	HoverProvider          bool               `json:"hoverProvider"`
+	DefinitionProvider     bool               `json:"definitionProvider"`
+	DocumentSymbolProvider bool               `json:"documentSymbolProvider"`
+	CompletionProvider     *CompletionOptions `json:"completionProvider"`
+}
+
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type InitializeResult struct {
+	Capabilities ServerCapabilities `json:"capabilities"`
+	ServerInfo   ServerInfo         `json:"serverInfo"`
+}
+
+func unmarshal_params(params json.RawMessage, v any) *Error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{Code: ERR_INVALID_PARAMS, Message: err.Error()}
+	}
+	return nil
+}
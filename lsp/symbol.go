@@ -0,0 +1,547 @@
+package lsp
+
+import (
+	"strings"
+
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/parser"
+)
+
+// symbol is a definition for hover, definition and completion.
+type symbol struct {
+	id      string
+	kind    int // Symbol kind.
+	token   lex.Token
+	span    lex.Span // Source range of definition.
+	detail  string
+	desc    string
+	t       string // Type kind of variable.
+	members []*symbol
+	ns      *parser.Defmap // Definitions of namespace.
+}
+
+// children returns member symbols.
+// Members of namespace are collected at every call.
+func (s *symbol) children() []*symbol {
+	if s.ns != nil {
+		return defmap_symbols(s.ns)
+	}
+	return s.members
+}
+
+// hover returns hover content of symbol.
+func (s *symbol) hover() MarkupContent {
+	var md strings.Builder
+	md.WriteString("```jule\n")
+	md.WriteString(s.detail)
+	md.WriteString("\n```")
+	desc := strings.TrimSpace(s.desc)
+	if desc != "" {
+		md.WriteString("\n\n")
+		md.WriteString(desc)
+	}
+	return MarkupContent{Kind: MARKUP_MARKDOWN, Value: md.String()}
+}
+
+func find_symbol(syms []*symbol, id string) *symbol {
+	for _, s := range syms {
+		if s.id == id {
+			return s
+		}
+	}
+	return nil
+}
+
+func var_symbol(v *models.Var, kind int) *symbol {
+	var detail strings.Builder
+	switch {
+	case v.Const:
+		detail.WriteString("const ")
+	case kind != SYMBOL_FIELD:
+		detail.WriteString("let ")
+	}
+	if v.Mutable {
+		detail.WriteString("mut ")
+	}
+	detail.WriteString(v.Id)
+	if v.Type.Kind != "" {
+		detail.WriteString(": ")
+		detail.WriteString(v.Type.Kind)
+	}
+	if v.Const && kind != SYMBOL_FIELD {
+		kind = SYMBOL_CONSTANT
+	}
+	return &symbol{
+		id:     v.Id,
+		kind:   kind,
+		token:  v.Token,
+		span:   v.Span,
+		detail: detail.String(),
+		desc:   v.Desc,
+		t:      v.Type.Kind,
+	}
+}
+
+func fn_symbol(f *parser.Fn, kind int) *symbol {
+	return &symbol{
+		id:     f.Ast.Id,
+		kind:   kind,
+		token:  f.Ast.IdTok,
+		span:   f.Ast.Span,
+		detail: f.Ast.DefString(),
+		desc:   f.Desc,
+	}
+}
+
+// defmap_symbols returns symbols of definitions.
+// Side definitions are not included.
+func defmap_symbols(dm *parser.Defmap) []*symbol {
+	var syms []*symbol
+	for _, ns := range dm.Namespaces {
+		syms = append(syms, &symbol{
+			id:     ns.Id,
+			kind:   SYMBOL_NAMESPACE,
+			token:  ns.Token,
+			detail: "use " + ns.Id,
+			ns:     ns.Defines(),
+		})
+	}
+	for _, e := range dm.Enums {
+		s := &symbol{
+			id:     e.Id,
+			kind:   SYMBOL_ENUM,
+			token:  e.Token,
+			span:   e.Span,
+			detail: "enum " + e.Id,
+			desc:   e.Desc,
+		}
+		if e.Type.Kind != "" {
+			s.detail += ": " + e.Type.Kind
+		}
+		for _, item := range e.Items {
+			s.members = append(s.members, &symbol{
+				id:     item.Id,
+				kind:   SYMBOL_ENUM_MEMBER,
+				token:  item.Token,
+				span:   item.Span,
+				detail: e.Id + lex.KND_DOT + item.Id,
+			})
+		}
+		syms = append(syms, s)
+	}
+	for _, st := range dm.Structs {
+		s := &symbol{
+			id:     st.Ast.Id,
+			kind:   SYMBOL_STRUCT,
+			token:  st.Ast.Token,
+			span:   st.Ast.Span,
+			detail: "struct " + st.Ast.Id,
+			desc:   st.Description,
+		}
+		for _, field := range st.Defines.Globals {
+			s.members = append(s.members, var_symbol(field, SYMBOL_FIELD))
+		}
+		for _, f := range st.Defines.Funcs {
+			s.members = append(s.members, fn_symbol(f, SYMBOL_METHOD))
+		}
+		syms = append(syms, s)
+	}
+	for _, t := range dm.Traits {
+		s := &symbol{
+			id:     t.Ast.Id,
+			kind:   SYMBOL_INTERFACE,
+			token:  t.Ast.Token,
+			span:   t.Ast.Span,
+			detail: "trait " + t.Ast.Id,
+			desc:   t.Desc,
+		}
+		for _, f := range t.Defines.Funcs {
+			s.members = append(s.members, fn_symbol(f, SYMBOL_METHOD))
+		}
+		syms = append(syms, s)
+	}
+	for _, t := range dm.Types {
+		syms = append(syms, &symbol{
+			id:     t.Id,
+			kind:   SYMBOL_TYPE_PARAMETER,
+			token:  t.Token,
+			span:   t.Span,
+			detail: "type " + t.Id + ": " + t.Type.Kind,
+			desc:   t.Desc,
+		})
+	}
+	for _, f := range dm.Funcs {
+		syms = append(syms, fn_symbol(f, SYMBOL_FUNCTION))
+	}
+	for _, v := range dm.Globals {
+		syms = append(syms, var_symbol(v, SYMBOL_VARIABLE))
+	}
+	return syms
+}
+
+// top_symbols returns symbols of package and uses.
+func (d *document) top_symbols() []*symbol {
+	if d.p == nil {
+		return nil
+	}
+	var syms []*symbol
+	for _, fp := range d.p.PackageFiles() {
+		syms = append(syms, defmap_symbols(fp.Defines)...)
+	}
+	if side := d.p.Defines.Side(); side != nil {
+		syms = append(syms, defmap_symbols(side)...)
+	}
+	return syms
+}
+
+// file_fns returns functions and methods of document file.
+func (d *document) file_fns() []*models.Fn {
+	if d.p == nil {
+		return nil
+	}
+	var fns []*models.Fn
+	for _, f := range d.p.Defines.Funcs {
+		fns = append(fns, f.Ast)
+	}
+	for _, s := range d.p.Defines.Structs {
+		if s.Ast.Token.File != d.file {
+			continue
+		}
+		for _, f := range s.Defines.Funcs {
+			fns = append(fns, f.Ast)
+		}
+	}
+	return fns
+}
+
+// enclosing_fn returns function of token index.
+// Function is the nearest function declared before token.
+//
+// Special case is;
+//
+//	enclosing_fn(i) -> returns nil if there is no function before token
+func (d *document) enclosing_fn(i int) *models.Fn {
+	tok := d.toks[i]
+	var fn *models.Fn
+	for _, f := range d.file_fns() {
+		if f.Token.File != d.file || f.Token.Row > tok.Row {
+			continue
+		}
+		if fn == nil || f.Token.Row > fn.Token.Row {
+			fn = f
+		}
+	}
+	return fn
+}
+
+// local_symbol returns symbol of local variable or parameter
+// of enclosing function of token index.
+// Type of local variable is detected from declaration.
+//
+// Special case is;
+//
+//	local_symbol(i, id) -> returns nil if id is not local
+func (d *document) local_symbol(i int, id string) *symbol {
+	fn := d.enclosing_fn(i)
+	if fn == nil {
+		return nil
+	}
+	for j := i; j > 0; j-- {
+		tok := d.toks[j]
+		if tok.Row < fn.Token.Row {
+			break
+		}
+		if tok.Id != lex.ID_IDENT || tok.Kind != id {
+			continue
+		}
+		prev := d.toks[j-1]
+		if prev.Id == lex.ID_MUT && j > 1 {
+			prev = d.toks[j-2]
+		}
+		if prev.Id != lex.ID_LET {
+			continue
+		}
+		v := &models.Var{Token: tok, Id: id}
+		v.Mutable = d.toks[j-1].Id == lex.ID_MUT
+		v.Type.Kind = d.decl_type(j)
+		return var_symbol(v, SYMBOL_VARIABLE)
+	}
+	for _, param := range fn.Params {
+		if param.Id == id {
+			v := &models.Var{Token: param.Token, Id: id, Mutable: param.Mutable}
+			v.Type.Kind = param.Type.Kind
+			return var_symbol(v, SYMBOL_VARIABLE)
+		}
+	}
+	if id == lex.KND_SELF && fn.Receiver != nil {
+		v := &models.Var{Token: fn.Token, Id: id}
+		v.Type.Kind = fn.Receiver.Type.Kind
+		return var_symbol(v, SYMBOL_VARIABLE)
+	}
+	return nil
+}
+
+// decl_type returns type kind of variable declaration at token index.
+// Supports explicit types, struct literals and function calls.
+//
+// Special case is;
+//
+//	decl_type(i) -> returns empty string if type is not detected
+func (d *document) decl_type(i int) string {
+	at := func(j int) lex.Token {
+		if j < len(d.toks) {
+			return d.toks[j]
+		}
+		return lex.Token{}
+	}
+	switch at(i + 1).Id {
+	case lex.ID_COLON:
+		var kind strings.Builder
+		for j := i + 2; j < len(d.toks); j++ {
+			tok := d.toks[j]
+			if tok.Row != d.toks[i].Row || tok.Kind == lex.KND_EQ {
+				break
+			}
+			kind.WriteString(tok.Kind)
+		}
+		return kind.String()
+	case lex.ID_OP:
+		if at(i+1).Kind != lex.KND_EQ || at(i+2).Id != lex.ID_IDENT {
+			return ""
+		}
+		id := at(i + 2).Kind
+		switch at(i + 3).Kind {
+		case lex.KND_LBRACE:
+			return id
+		case lex.KND_LPAREN:
+			s := find_symbol(d.top_symbols(), id)
+			if s == nil {
+				return ""
+			}
+			if s.kind == SYMBOL_STRUCT {
+				return id
+			}
+			if s.kind == SYMBOL_FUNCTION {
+				return ret_kind(s.detail)
+			}
+		}
+	}
+	return ""
+}
+
+// ret_kind returns return type kind of function detail.
+func ret_kind(detail string) string {
+	n := 0
+	for i, r := range detail {
+		switch r {
+		case '(':
+			n++
+		case ')':
+			n--
+			if n == 0 {
+				return detail[i+1:]
+			}
+		}
+	}
+	return ""
+}
+
+// type_members returns members of struct or trait of type kind.
+func (d *document) type_members(kind string) []*symbol {
+	kind = strings.TrimLeft(kind, "&*")
+	if i := strings.LastIndex(kind, lex.KND_DBLCOLON); i != -1 {
+		kind = kind[i+len(lex.KND_DBLCOLON):]
+	}
+	if i := strings.IndexByte(kind, '['); i != -1 {
+		kind = kind[:i]
+	}
+	for _, s := range d.top_symbols() {
+		if s.id != kind {
+			continue
+		}
+		switch s.kind {
+		case SYMBOL_STRUCT, SYMBOL_INTERFACE:
+			return s.members
+		}
+	}
+	return nil
+}
+
+// qualified returns members of qualifier before "::" at token index.
+//
+// Special case is;
+//
+//	qualified(i) -> returns nil if qualifier is not exist
+func (d *document) qualified(i int) []*symbol {
+	if i < 1 || d.toks[i-1].Id != lex.ID_IDENT {
+		return nil
+	}
+	var syms []*symbol
+	if i >= 2 && d.toks[i-2].Id == lex.ID_DBLCOLON {
+		syms = d.qualified(i - 2)
+	} else {
+		syms = d.top_symbols()
+	}
+	s := find_symbol(syms, d.toks[i-1].Kind)
+	if s == nil {
+		return nil
+	}
+	return s.children()
+}
+
+// selected returns members of receiver before "." at token index.
+//
+// Special case is;
+//
+//	selected(i) -> returns nil if type of receiver is not detected
+func (d *document) selected(i int) []*symbol {
+	if i < 1 {
+		return nil
+	}
+	tok := d.toks[i-1]
+	if tok.Id != lex.ID_IDENT && tok.Id != lex.ID_SELF {
+		return nil
+	}
+	s := d.local_symbol(i-1, tok.Kind)
+	if s == nil {
+		s = find_symbol(d.top_symbols(), tok.Kind)
+	}
+	switch {
+	case s == nil:
+		return nil
+	case s.kind == SYMBOL_ENUM:
+		return s.members
+	case s.t == "":
+		return nil
+	}
+	return d.type_members(s.t)
+}
+
+// resolve returns symbol of identifier at token index.
+//
+// Special case is;
+//
+//	resolve(i) -> returns nil if symbol is not found
+func (d *document) resolve(i int) *symbol {
+	tok := d.toks[i]
+	if tok.Id != lex.ID_IDENT && tok.Id != lex.ID_SELF {
+		return nil
+	}
+	if i > 0 {
+		switch d.toks[i-1].Id {
+		case lex.ID_DBLCOLON:
+			return find_symbol(d.qualified(i-1), tok.Kind)
+		case lex.ID_DOT:
+			if s := find_symbol(d.selected(i-1), tok.Kind); s != nil {
+				return s
+			}
+			// Type of receiver is unknown, use first member.
+			for _, s := range d.top_symbols() {
+				if m := find_symbol(s.members, tok.Kind); m != nil &&
+					(s.kind == SYMBOL_STRUCT || s.kind == SYMBOL_INTERFACE) {
+					return m
+				}
+			}
+			return nil
+		}
+	}
+	if s := d.local_symbol(i, tok.Kind); s != nil {
+		return s
+	}
+	return find_symbol(d.top_symbols(), tok.Kind)
+}
+
+// completions returns candidate symbols at position.
+func (d *document) completions(pos Position) []*symbol {
+	row, column := d.column(pos)
+	i := -1
+	for j, tok := range d.toks {
+		if tok.Row > row || (tok.Row == row && tok.Column >= column) {
+			break
+		}
+		if tok.Id != lex.ID_COMMENT {
+			i = j
+		}
+	}
+	if i == -1 {
+		return d.top_symbols()
+	}
+	// Skip identifier which is typing.
+	if d.toks[i].Id == lex.ID_IDENT && i > 0 {
+		switch d.toks[i-1].Id {
+		case lex.ID_DBLCOLON, lex.ID_DOT:
+			i--
+		}
+	}
+	switch d.toks[i].Id {
+	case lex.ID_DBLCOLON:
+		return d.qualified(i)
+	case lex.ID_DOT:
+		return d.selected(i)
+	}
+	return d.top_symbols()
+}
+
+// document_symbols returns symbols of document file.
+func (d *document) document_symbols() []DocumentSymbol {
+	syms := []DocumentSymbol{}
+	if d.p == nil {
+		return syms
+	}
+	for _, s := range defmap_symbols(d.p.Defines) {
+		if s.token.File != d.file || s.kind == SYMBOL_NAMESPACE {
+			continue
+		}
+		syms = append(syms, d.document_symbol(s))
+	}
+	return syms
+}
+
+func (d *document) document_symbol(s *symbol) DocumentSymbol {
+	r := token_range(d.lines, s.token)
+	ds := DocumentSymbol{
+		Name:           s.id,
+		Detail:         s.detail,
+		Kind:           s.kind,
+		Range:          r,
+		SelectionRange: r,
+	}
+	if s.span.File == d.file && !s.span.IsZero() {
+		ds.Range = span_range(s.span)
+	}
+	for _, m := range s.members {
+		if m.token.File == d.file {
+			ds.Children = append(ds.Children, d.document_symbol(m))
+		}
+	}
+	return ds
+}
+
+// completion_kind returns completion item kind of symbol kind.
+func completion_kind(kind int) int {
+	switch kind {
+	case SYMBOL_NAMESPACE:
+		return COMPLETION_MODULE
+	case SYMBOL_METHOD:
+		return COMPLETION_METHOD
+	case SYMBOL_FIELD:
+		return COMPLETION_FIELD
+	case SYMBOL_ENUM:
+		return COMPLETION_ENUM
+	case SYMBOL_INTERFACE:
+		return COMPLETION_INTERFACE
+	case SYMBOL_FUNCTION:
+		return COMPLETION_FUNCTION
+	case SYMBOL_CONSTANT:
+		return COMPLETION_CONSTANT
+	case SYMBOL_ENUM_MEMBER:
+		return COMPLETION_ENUM_MEMBER
+	case SYMBOL_STRUCT:
+		return COMPLETION_STRUCT
+	case SYMBOL_TYPE_PARAMETER:
+		return COMPLETION_TYPE_PARAMETER
+	default:
+		return COMPLETION_VARIABLE
+	}
+}
@@ -51,12 +51,18 @@ func (pap *pureArgParser) push_variadic_args(pair *paramMapPair) {
 	model.exprs = append(model.exprs, exprNode{lex.KND_LBRACE})
 	variadiced := false
 	pap.p.parseArg(pap.f, pair, pap.args, &variadiced)
+	if pap.p.eval.has_error {
+		return
+	}
 	model.exprs = append(model.exprs, pair.arg.String())
 	once := false
 	for pap.i++; pap.i < len(pap.args.Src); pap.i++ {
 		pair.arg = &pap.args.Src[pap.i]
 		once = true
 		pap.p.parseArg(pap.f, pair, pap.args, &variadiced)
+		if pap.p.eval.has_error {
+			return
+		}
 		model.exprs = append(model.exprs, exprNode{lex.KND_COMMA})
 		model.exprs = append(model.exprs, pair.arg.String())
 	}
@@ -9,7 +9,7 @@ import (
 	"github.com/julelang/jule/pkg/juletype"
 )
 
-func float_assignable(dt uint8, v value) bool {
+func float_assignable(dt uint8, v value, bits int) bool {
 	switch t := v.expr.(type) {
 	case float64:
 		v.data.Value = strconv.FormatFloat(t, 'e', -1, 64)
@@ -18,12 +18,12 @@ func float_assignable(dt uint8, v value) bool {
 	case uint64:
 		v.data.Value = strconv.FormatFloat(float64(t), 'e', -1, 64)
 	}
-	return checkFloatBit(v.data, julebits.BitsizeType(dt))
+	return checkFloatBit(v.data, julebits.BitsizeType(dt, bits))
 }
 
-func signedAssignable(dt uint8, v value) bool {
-	min := juletype.MinOfType(dt)
-	max := int64(juletype.MaxOfType(dt))
+func signedAssignable(dt uint8, v value, bits int) bool {
+	min := juletype.MinOfType(dt, bits)
+	max := int64(juletype.MaxOfType(dt, bits))
 	switch t := v.expr.(type) {
 	case float64:
 		i, frac := math.Modf(t)
@@ -41,8 +41,8 @@ func signedAssignable(dt uint8, v value) bool {
 	return false
 }
 
-func unsignedAssignable(dt uint8, v value) bool {
-	max := juletype.MaxOfType(dt)
+func unsignedAssignable(dt uint8, v value, bits int) bool {
+	max := juletype.MaxOfType(dt, bits)
 	switch t := v.expr.(type) {
 	case float64:
 		if t < 0 {
@@ -66,12 +66,12 @@ func unsignedAssignable(dt uint8, v value) bool {
 	return false
 }
 
-func int_assignable(dt uint8, v value) bool {
+func int_assignable(dt uint8, v value, bits int) bool {
 	switch {
 	case juletype.IsSignedInteger(dt):
-		return signedAssignable(dt, v)
+		return signedAssignable(dt, v, bits)
 	case juletype.IsUnsignedInteger(dt):
-		return unsignedAssignable(dt, v)
+		return unsignedAssignable(dt, v, bits)
 	}
 	return false
 }
@@ -112,12 +112,12 @@ func (ac *assign_checker) check_const() (ok bool) {
 	ok = true
 	switch {
 	case juletype.IsFloat(ac.expr_t.Id):
-		if !float_assignable(ac.expr_t.Id, ac.v) {
+		if !float_assignable(ac.expr_t.Id, ac.v, ac.p.session.Env.BitSize()) {
 			ac.p.pusherrtok(ac.errtok, "overflow_limits")
 			ok = false
 		}
 	case juletype.IsInteger(ac.expr_t.Id):
-		if !int_assignable(ac.expr_t.Id, ac.v) {
+		if !int_assignable(ac.expr_t.Id, ac.v, ac.p.session.Env.BitSize()) {
 			ac.p.pusherrtok(ac.errtok, "overflow_limits")
 			ok = false
 		}
@@ -269,267 +269,319 @@ var strDefaultFunc = Func{
 	RetType: RetType{Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
 }
 
-var errorTrait = &trait{
-	Ast: &models.Trait{
-		Id: "Error",
-	},
-	Defines: &Defmap{
-		Funcs: []*Fn{
-			{Ast: &models.Fn{
-				Pub:     true,
-				Id:      "error",
-				RetType: models.RetType{
-					Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]},
-				},
-			}},
+// builtins is built-in definitions of session.
+// Parsers are changes built-in definitions, such as marking used
+// and reloading types of generic functions for calls,
+// so every session has own built-in definitions.
+type builtins struct {
+	// Parser instance for built-in generics.
+	file    *Parser
+	defines *Defmap
+	str     *Defmap
+	slice   *Defmap
+	array   *Defmap
+	maps    *Defmap
+	// Built-in definitions of standard library packages by link path.
+	std        map[string]*Defmap
+	panic_fn   *Fn
+	recover_fn *Fn
+}
+
+// new_builtins returns new built-in definitions.
+func new_builtins() *builtins {
+	b := &builtins{
+		file:  &Parser{},
+		str:   new_str_defines(),
+		slice: new_slice_defines(),
+		array: new_array_defines(),
+		maps:  new_map_defines(),
+		std: map[string]*Defmap{
+			"std::mem": new_std_mem_defines(),
 		},
-	},
-}
-
-var errorType = Type{
-	Id:   juletype.TRAIT,
-	Kind: errorTrait.Ast.Id,
-	Tag:  errorTrait,
-	Pure: true,
-}
-
-var panicFunc = &Fn{
-	Ast: &models.Fn{
-		Pub: true,
-		Id:  "panic",
+	}
+	b.defines = new_builtin_defines(b.file)
+	b.panic_fn, _, _ = b.defines.fn_by_id("panic", nil)
+	b.recover_fn, _, _ = b.defines.fn_by_id("recover", nil)
+	return b
+}
+
+// new_error_trait returns built-in Error trait.
+// Owner of functions is file.
+func new_error_trait(file *Parser) *trait {
+	t := &trait{
+		Ast: &models.Trait{
+			Id: "Error",
+		},
+		Defines: &Defmap{
+			Funcs: []*Fn{
+				{Ast: &models.Fn{
+					Pub:     true,
+					Id:      "error",
+					RetType: models.RetType{
+						Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]},
+					},
+				}},
+			},
+		},
+	}
+	receiver := new(Var)
+	receiver.Mutable = false
+	receiver.Tag = t
+	for _, f := range t.Defines.Funcs {
+		f.Ast.Receiver = receiver
+		f.Ast.Owner = file
+	}
+	return t
+}
+
+// new_builtin_defines returns built-in definitions.
+// Owner of generic functions is file.
+func new_builtin_defines(file *Parser) *Defmap {
+	error_trait := new_error_trait(file)
+	error_type := Type{
+		Id:   juletype.TRAIT,
+		Kind: error_trait.Ast.Id,
+		Tag:  error_trait,
+		Pure: true,
+	}
+	error_handler := &models.Fn{
+		Id: "handler",
 		Params: []models.Param{
 			{
 				Id:   "error",
-				Type: Type{Id: juletype.ANY, Kind: juletype.TYPE_MAP[juletype.ANY]},
+				Type: error_type,
 			},
 		},
-	},
-}
-
-var errorHandlerFunc = &models.Fn{
-	Id: "handler",
-	Params: []models.Param{
-		{
-			Id:   "error",
-			Type: errorType,
-		},
-	},
-	RetType: models.RetType{
-		Type: models.Type{
-			Id:   juletype.VOID,
-			Kind: juletype.TYPE_MAP[juletype.VOID],
+		RetType: models.RetType{
+			Type: models.Type{
+				Id:   juletype.VOID,
+				Kind: juletype.TYPE_MAP[juletype.VOID],
+			},
 		},
-	},
-}
-
-var recoverFunc = &Fn{
-	Ast: &models.Fn{
+	}
+	out_fn := &Fn{Ast: &Func{
 		Pub: true,
-		Id:  "recover",
-		Params: []models.Param{
+		Id:  "out",
+		RetType: RetType{
+			Type: Type{Id: juletype.VOID, Kind: juletype.TYPE_MAP[juletype.VOID]},
+		},
+		Params: []Param{{
+			Id:   "expr",
+			Type: Type{Id: juletype.ANY, Kind: juletype.TYPE_MAP[juletype.ANY]},
+		}},
+		BuiltinCaller: caller_out,
+	}}
+	// Copy out function as outln
+	outln_fn := new(Fn)
+	*outln_fn = *out_fn
+	outln_fn.Ast = new(models.Fn)
+	*outln_fn.Ast = *out_fn.Ast
+	outln_fn.Ast.Id = "outln"
+	return &Defmap{
+		Types: []*models.TypeAlias{
 			{
-				Id: "handler",
-				Type: models.Type{
-					Id:   juletype.FN,
-					Kind: errorHandlerFunc.TypeKind(),
-					Tag:  errorHandlerFunc,
-				},
+				Pub:  true,
+				Id:   "byte",
+				Type: Type{Id: juletype.U8, Kind: juletype.TYPE_MAP[juletype.U8]},
+			},
+			{
+				Pub:  true,
+				Id:   "rune",
+				Type: Type{Id: juletype.I32, Kind: juletype.TYPE_MAP[juletype.I32]},
 			},
 		},
-	},
-}
-
-var out_fn = &Fn{Ast: &Func{
-	Pub: true,
-	Id:  "out",
-	RetType: RetType{
-		Type: Type{Id: juletype.VOID, Kind: juletype.TYPE_MAP[juletype.VOID]},
-	},
-	Params: []Param{{
-		Id:   "expr",
-		Type: Type{Id: juletype.ANY, Kind: juletype.TYPE_MAP[juletype.ANY]},
-	}},
-}}
-
-var make_fn = &Fn{Ast: &Func{
-	Pub: true,
-	Id:  "make",
-}}
-
-var outln_fn *Fn
-
-// Parser instance for built-in generics.
-var builtinFile = &Parser{}
-
-// Builtin definitions.
-var Builtin = &Defmap{
-	Types: []*models.TypeAlias{
-		{
-			Pub:  true,
-			Id:   "byte",
-			Type: Type{Id: juletype.U8, Kind: juletype.TYPE_MAP[juletype.U8]},
-		},
-		{
-			Pub:  true,
-			Id:   "rune",
-			Type: Type{Id: juletype.I32, Kind: juletype.TYPE_MAP[juletype.I32]},
-		},
-	},
-	Funcs: []*Fn{
-		out_fn,
-		panicFunc,
-		recoverFunc,
-		make_fn,
-		{Ast: &Func{
-			Pub:   true,
-			Id:    "new",
-			Owner: builtinFile,
-		}},
-		{Ast: &Func{
-			Pub:      true,
-			Id:       "copy",
-			Owner:    builtinFile,
-			Generics: []*GenericType{{Id: "Item"}},
-			RetType:  models.RetType{Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]}},
-			Params: []models.Param{
-				{
-					Mutable: true,
-					Id:      "dest",
-					Type: Type{
-						Id:            juletype.SLICE,
-						Kind:          jule.PREFIX_SLICE + "Item",
-						ComponentType: &Type{Id: juletype.ID, Kind: "Item"},
+		Funcs: []*Fn{
+			out_fn,
+			{Ast: &models.Fn{
+				Pub: true,
+				Id:  "panic",
+				Params: []models.Param{
+					{
+						Id:   "error",
+						Type: Type{Id: juletype.ANY, Kind: juletype.TYPE_MAP[juletype.ANY]},
 					},
 				},
-				{
-					Id: "src",
-					Type: Type{
-						Id:            juletype.SLICE,
-						Kind:          jule.PREFIX_SLICE + "Item",
-						ComponentType: &Type{Id: juletype.ID, Kind: "Item"},
+			}},
+			{Ast: &models.Fn{
+				Pub: true,
+				Id:  "recover",
+				Params: []models.Param{
+					{
+						Id: "handler",
+						Type: models.Type{
+							Id:   juletype.FN,
+							Kind: error_handler.TypeKind(),
+							Tag:  error_handler,
+						},
 					},
 				},
-			},
-		}},
-		{Ast: &Func{
-			Pub:      true,
-			Id:       "append",
-			Owner:    builtinFile,
-			Generics: []*GenericType{{Id: "Item"}},
-			RetType: models.RetType{
-				Type: Type{
-					Id:            juletype.SLICE,
-					Kind:          jule.PREFIX_SLICE + "Item",
-					ComponentType: &Type{Id: juletype.ID, Kind: "Item"},
+			}},
+			{Ast: &Func{
+				Pub:           true,
+				Id:            "make",
+				BuiltinCaller: caller_make,
+			}},
+			{Ast: &Func{
+				Pub:           true,
+				Id:            "new",
+				Owner:         file,
+				BuiltinCaller: caller_new,
+			}},
+			{Ast: &Func{
+				Pub:      true,
+				Id:       "copy",
+				Owner:    file,
+				Generics: []*GenericType{{Id: "Item"}},
+				RetType:  models.RetType{Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]}},
+				Params: []models.Param{
+					{
+						Mutable: true,
+						Id:      "dest",
+						Type: Type{
+							Id:            juletype.SLICE,
+							Kind:          jule.PREFIX_SLICE + "Item",
+							ComponentType: &Type{Id: juletype.ID, Kind: "Item"},
+						},
+					},
+					{
+						Id: "src",
+						Type: Type{
+							Id:            juletype.SLICE,
+							Kind:          jule.PREFIX_SLICE + "Item",
+							ComponentType: &Type{Id: juletype.ID, Kind: "Item"},
+						},
+					},
 				},
-			},
-			Params: []models.Param{
-				{
-					Id: "src",
+			}},
+			{Ast: &Func{
+				Pub:      true,
+				Id:       "append",
+				Owner:    file,
+				Generics: []*GenericType{{Id: "Item"}},
+				RetType: models.RetType{
 					Type: Type{
 						Id:            juletype.SLICE,
 						Kind:          jule.PREFIX_SLICE + "Item",
 						ComponentType: &Type{Id: juletype.ID, Kind: "Item"},
 					},
 				},
-				{
-					Id:       "components",
-					Type:     Type{Id: juletype.ID, Kind: "Item"},
-					Variadic: true,
+				Params: []models.Param{
+					{
+						Id: "src",
+						Type: Type{
+							Id:            juletype.SLICE,
+							Kind:          jule.PREFIX_SLICE + "Item",
+							ComponentType: &Type{Id: juletype.ID, Kind: "Item"},
+						},
+					},
+					{
+						Id:       "components",
+						Type:     Type{Id: juletype.ID, Kind: "Item"},
+						Variadic: true,
+					},
 				},
-			},
-		}},
-	},
-	Traits: []*trait{
-		errorTrait,
-	},
+			}},
+			outln_fn,
+		},
+		Traits: []*trait{
+			error_trait,
+		},
+	}
 }
 
-var strDefines = &Defmap{
-	Globals: []*Var{
-		{
-			Pub:  true,
-			Id:   "len",
-			Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
-			Tag:  "len()",
-		},
-	},
-	Funcs: []*Fn{
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "empty",
-			RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
-		}},
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "has_prefix",
-			Params:  []Param{{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
-			RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
-		}},
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "has_suffix",
-			Params:  []Param{{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
-			RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
-		}},
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "find",
-			Params:  []Param{{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
-			RetType: RetType{Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]}},
-		}},
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "rfind",
-			Params:  []Param{{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
-			RetType: RetType{Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]}},
-		}},
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "trim",
-			Params:  []Param{{Id: "bytes", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
-			RetType: RetType{
-				Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]},
+// new_str_defines returns definitions of str type.
+func new_str_defines() *Defmap {
+	return &Defmap{
+		Globals: []*Var{
+			{
+				Pub:  true,
+				Id:   "len",
+				Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
+				Tag:  "len()",
 			},
-		}},
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "rtrim",
-			Params:  []Param{{Id: "bytes", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
-			RetType: RetType{Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
-		}},
-		{Ast: &Func{
-			Pub: true,
-			Id:  "split",
-			Params: []Param{
-				{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
-				{
-					Id:   "n",
-					Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
+		},
+		Funcs: []*Fn{
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "empty",
+				RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
+			}},
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "has_prefix",
+				Params:  []Param{{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
+				RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
+			}},
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "has_suffix",
+				Params:  []Param{{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
+				RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
+			}},
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "find",
+				Params:  []Param{{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
+				RetType: RetType{Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]}},
+			}},
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "rfind",
+				Params:  []Param{{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
+				RetType: RetType{Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]}},
+			}},
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "trim",
+				Params:  []Param{{Id: "bytes", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
+				RetType: RetType{
+					Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]},
 				},
-			},
-			RetType: RetType{Type: Type{Id: juletype.STR, Kind: jule.PREFIX_SLICE + juletype.TYPE_MAP[juletype.STR]}},
-		}},
-		{Ast: &Func{
-			Pub: true,
-			Id:  "replace",
-			Params: []Param{
-				{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
-				{Id: "new", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
-				{
-					Id:   "n",
-					Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
+			}},
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "rtrim",
+				Params:  []Param{{Id: "bytes", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}}},
+				RetType: RetType{Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
+			}},
+			{Ast: &Func{
+				Pub: true,
+				Id:  "split",
+				Params: []Param{
+					{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
+					{
+						Id:   "n",
+						Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
+					},
 				},
-			},
-			RetType: RetType{Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
-		}},
-	},
+				RetType: RetType{Type: Type{Id: juletype.STR, Kind: jule.PREFIX_SLICE + juletype.TYPE_MAP[juletype.STR]}},
+			}},
+			{Ast: &Func{
+				Pub: true,
+				Id:  "replace",
+				Params: []Param{
+					{Id: "sub", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
+					{Id: "new", Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
+					{
+						Id:   "n",
+						Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
+					},
+				},
+				RetType: RetType{Type: Type{Id: juletype.STR, Kind: juletype.TYPE_MAP[juletype.STR]}},
+			}},
+		},
+	}
 }
 
-// Use this at before use strDefines if necessary.
-// Because some definitions is responsive for str data types.
-func readyStrDefines(s value) {
-	lenVar := strDefines.Globals[0]
+// str_defines returns definitions of str value.
+// Some definitions is responsive for str values, so
+// definitions are copied and b.str is not changed.
+func (b *builtins) str_defines(s value) *Defmap {
+	dm := new(Defmap)
+	*dm = *b.str
+	lenVar := new(Var)
+	*lenVar = *b.str.Globals[0]
+	dm.Globals = []*Var{lenVar}
 	lenVar.Const = s.constExpr
 	if lenVar.Const {
 		lenVar.ExprTag = int64(len(s.expr.(string)))
@@ -538,155 +590,169 @@ func readyStrDefines(s value) {
 			data: models.Data{Type: lenVar.Type},
 		})
 	}
+	return dm
 }
 
-var sliceDefines = &Defmap{
-	Globals: []*Var{
-		{
-			Pub:  true,
-			Id:   "len",
-			Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
-			Tag:  "len()",
+// new_slice_defines returns definitions of slice types.
+func new_slice_defines() *Defmap {
+	return &Defmap{
+		Globals: []*Var{
+			{
+				Pub:  true,
+				Id:   "len",
+				Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
+				Tag:  "len()",
+			},
 		},
-	},
-	Funcs: []*Fn{
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "empty",
-			RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
-		}},
-	},
+		Funcs: []*Fn{
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "empty",
+				RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
+			}},
+		},
+	}
 }
 
-var arrayDefines = &Defmap{
-	Globals: []*Var{
-		{
-			Pub:  true,
-			Id:   "len",
-			Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
-			Tag:  "len()",
+// new_array_defines returns definitions of array types.
+func new_array_defines() *Defmap {
+	return &Defmap{
+		Globals: []*Var{
+			{
+				Pub:  true,
+				Id:   "len",
+				Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
+				Tag:  "len()",
+			},
 		},
-	},
-	Funcs: []*Fn{
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "empty",
-			RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
-		}},
-	},
+		Funcs: []*Fn{
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "empty",
+				RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
+			}},
+		},
+	}
 }
 
-var mapDefines = &Defmap{
-	Globals: []*Var{
-		{
-			Pub:  true,
-			Id:   "len",
-			Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
-			Tag:  "len()",
+// new_map_defines returns definitions of map types.
+func new_map_defines() *Defmap {
+	return &Defmap{
+		Globals: []*Var{
+			{
+				Pub:  true,
+				Id:   "len",
+				Type: Type{Id: juletype.INT, Kind: juletype.TYPE_MAP[juletype.INT]},
+				Tag:  "len()",
+			},
 		},
-	},
-	Funcs: []*Fn{
-		{Ast: &Func{
-			Pub: true,
-			Id:  "clear",
-		}},
-		{Ast: &Func{
-			Pub: true,
-			Id:  "keys",
-		}},
-		{Ast: &Func{
-			Pub: true,
-			Id:  "values",
-		}},
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "empty",
-			RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
-		}},
-		{Ast: &Func{
-			Pub:     true,
-			Id:      "has",
-			Params:  []Param{{Id: "key"}},
-			RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
-		}},
-		{Ast: &Func{
-			Pub:    true,
-			Id:     "del",
-			Params: []Param{{Id: "key"}},
-		}},
-	},
+		Funcs: []*Fn{
+			{Ast: &Func{
+				Pub: true,
+				Id:  "clear",
+			}},
+			{Ast: &Func{
+				Pub: true,
+				Id:  "keys",
+			}},
+			{Ast: &Func{
+				Pub: true,
+				Id:  "values",
+			}},
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "empty",
+				RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
+			}},
+			{Ast: &Func{
+				Pub:     true,
+				Id:      "has",
+				Params:  []Param{{Id: "key"}},
+				RetType: RetType{Type: Type{Id: juletype.BOOL, Kind: juletype.TYPE_MAP[juletype.BOOL]}},
+			}},
+			{Ast: &Func{
+				Pub:    true,
+				Id:     "del",
+				Params: []Param{{Id: "key"}},
+			}},
+		},
+	}
 }
 
-// Use this at before use mapDefines if necessary.
-// Because some definitions is responsive for map data-types.
-func readyMapDefines(mapt Type) {
+// copy_fn returns copy of function in definitions.
+// Parameters are copied too.
+func copy_fn(dm *Defmap, id string) *Fn {
+	i, _, _ := dm.find_fn_by_id(id, nil)
+	f := dm.Funcs[i]
+	fc := new(Fn)
+	*fc = *f
+	fc.Ast = new(Func)
+	*fc.Ast = *f.Ast
+	fc.Ast.Params = append([]Param(nil), f.Ast.Params...)
+	dm.Funcs[i] = fc
+	return fc
+}
+
+// map_defines returns definitions of map type.
+// Some definitions is responsive for map data-types, so
+// definitions are copied and b.maps is not changed.
+func (b *builtins) map_defines(mapt Type) *Defmap {
 	types := mapt.Tag.([]Type)
 	keyt := types[0]
 	valt := types[1]
 
-	keysFunc, _, _ := mapDefines.fn_by_id("keys", nil)
+	dm := new(Defmap)
+	*dm = *b.maps
+	dm.Funcs = append([]*Fn(nil), b.maps.Funcs...)
+
+	keysFunc := copy_fn(dm, "keys")
 	keysFunc.Ast.RetType.Type = keyt
 	keysFunc.Ast.RetType.Type.Kind = jule.PREFIX_SLICE + keysFunc.Ast.RetType.Type.Kind
 
-	valuesFunc, _, _ := mapDefines.fn_by_id("values", nil)
+	valuesFunc := copy_fn(dm, "values")
 	valuesFunc.Ast.RetType.Type = valt
 	valuesFunc.Ast.RetType.Type.Kind = jule.PREFIX_SLICE + valuesFunc.Ast.RetType.Type.Kind
 
-	hasFunc, _, _ := mapDefines.fn_by_id("has", nil)
+	hasFunc := copy_fn(dm, "has")
 	hasFunc.Ast.Params[0].Type = keyt
 
-	delFunc, _, _ := mapDefines.fn_by_id("del", nil)
+	delFunc := copy_fn(dm, "del")
 	delFunc.Ast.Params[0].Type = keyt
+	return dm
+}
+
+// copy_statics returns copy of limit statics of platform-dependent
+// integer type from statics of bit-specific integer type.
+func copy_statics(dm *Defmap, bitdm *Defmap) *Defmap {
+	cdm := new(Defmap)
+	*cdm = *dm
+	cdm.Globals = make([]*Var, len(dm.Globals))
+	for i, g := range dm.Globals {
+		cg := new(Var)
+		*cg = *g
+		cg.Expr = bitdm.Globals[i].Expr
+		cg.ExprTag = bitdm.Globals[i].ExprTag
+		cdm.Globals[i] = cg
+	}
+	return cdm
 }
 
-func init() {
-	// Copy out function as outln
-	out_fn.Ast.BuiltinCaller = caller_out
-	outln_fn = new(Fn)
-	*outln_fn = *out_fn
-	outln_fn.Ast = new(models.Fn)
-	*outln_fn.Ast = *out_fn.Ast
-	outln_fn.Ast.Id = "outln"
-	Builtin.Funcs = append(Builtin.Funcs, outln_fn)
-
-	// Setup make function
-	make_fn.Ast.BuiltinCaller = caller_make
-
-	// Setup new function
-	fn_new, _, _ := Builtin.fn_by_id("new", nil)
-	fn_new.Ast.BuiltinCaller = caller_new
-
-	// Setup Error trait
-	receiver := new(Var)
-	receiver.Mutable = false
-	for _, f := range errorTrait.Defines.Funcs {
-		f.Ast.Receiver = receiver
-		f.Ast.Receiver.Tag = errorTrait
-		f.Ast.Owner = builtinFile
+// int_statics returns statics of int type for bit size of architecture.
+// Limits of platform-dependent types is responsive for bit size.
+func int_statics(bits int) *Defmap {
+	if bits == 32 {
+		return copy_statics(intStatics, i32statics)
 	}
+	return copy_statics(intStatics, i64statics)
+}
 
-	// Set bits of platform-dependent types
-	intMax := intStatics.Globals[0]
-	intMin := intStatics.Globals[1]
-	uintMax := uintStatics.Globals[0]
-	switch juletype.BIT_SIZE {
-	case 32:
-		intMax.Expr = i32statics.Globals[0].Expr
-		intMax.ExprTag = i32statics.Globals[0].ExprTag
-		intMin.Expr = i32statics.Globals[1].Expr
-		intMin.ExprTag = i32statics.Globals[1].ExprTag
-
-		uintMax.Expr = u32statics.Globals[0].Expr
-		uintMax.ExprTag = u32statics.Globals[0].ExprTag
-	case 64:
-		intMax.Expr = i64statics.Globals[0].Expr
-		intMax.ExprTag = i64statics.Globals[0].ExprTag
-		intMin.Expr = i64statics.Globals[1].Expr
-		intMin.ExprTag = i64statics.Globals[1].ExprTag
-
-		uintMax.Expr = u64statics.Globals[0].Expr
-		uintMax.ExprTag = u64statics.Globals[0].ExprTag
+// uint_statics returns statics of uint type for bit size of architecture.
+// Limits of platform-dependent types is responsive for bit size.
+func uint_statics(bits int) *Defmap {
+	if bits == 32 {
+		return copy_statics(uintStatics, u32statics)
 	}
+	return copy_statics(uintStatics, u64statics)
 }
 
 // Standard Library Builtin Callers
@@ -698,8 +764,14 @@ func caller_out(p *Parser, f *Func, data callData, m *exprModel) (v value) {
 	v.data.Type = f.RetType.Type
 	// Remove parentheses
 	data.args = data.args[1 : len(data.args)-1]
+	if len(data.args) == 0 {
+		p.eval.pusherrtok(errtok, "missing_expr_for", f.Params[0].Id)
+		return
+	}
 	arg, model := p.evalToks(data.args, nil)
-	if type_is_fn(arg.data.Type) {
+	if p.eval.has_error {
+		return
+	} else if type_is_fn(arg.data.Type) {
 		p.pusherrtok(errtok, "invalid_expr")
 	}
 	m.append_sub(exprNode{"(" + model.String() + ")"})
@@ -710,12 +782,14 @@ func caller_out(p *Parser, f *Func, data callData, m *exprModel) (v value) {
 func caller_make(p *Parser, _ *Func, data callData, m *exprModel) (v value) {
 	errtok := data.args[0]
 	args := p.get_args(data.args, false)
-	if len(args.Src) == 0 {
+	if args == nil {
+		return
+	} else if len(args.Src) == 0 {
 		p.pusherrtok(errtok, "missing_expr")
 		return
 	}
 	type_tokens := args.Src[0].Expr.Tokens
-	b := ast.NewBuilder(nil)
+	b := ast.NewBuilder(p.session.Env, nil)
 	i := 0
 	t, ok := b.DataType(type_tokens, &i, true)
 	b.Wait()
@@ -743,7 +817,7 @@ func caller_new(p *Parser, _ *Func, data callData, m *exprModel) (v value) {
 	errtok := data.args[0]
 	// Remove parentheses
 	data.args = data.args[1 : len(data.args)-1]
-	b := ast.NewBuilder(nil)
+	b := ast.NewBuilder(p.session.Env, nil)
 	i := 0
 	t, ok := b.DataType(data.args, &i, true)
 	b.Wait()
@@ -776,28 +850,39 @@ func caller_new(p *Parser, _ *Func, data callData, m *exprModel) (v value) {
 
 // std::mem
 
-var std_mem_builtin = &Defmap{
-	Funcs: []*Fn{
-		{Ast: &models.Fn{Id: "size_of", BuiltinCaller: caller_mem_size_of}},
-		{Ast: &models.Fn{Id: "align_of", BuiltinCaller: caller_mem_align_of}},
-	},
+// new_std_mem_defines returns built-in definitions of std::mem.
+func new_std_mem_defines() *Defmap {
+	return &Defmap{
+		Funcs: []*Fn{
+			{Ast: &models.Fn{Id: "size_of", BuiltinCaller: caller_mem_size_of}},
+			{Ast: &models.Fn{Id: "align_of", BuiltinCaller: caller_mem_align_of}},
+		},
+	}
 }
 
 func caller_mem_size_of(p *Parser, _ *Func, data callData, m *exprModel) (v value) {
+	errtok := data.args[0]
 	// Remove parentheses
 	data.args = data.args[1 : len(data.args)-1]
 	v.data.Type = Type{
 		Id:   juletype.UINT,
 		Kind: juletype.TYPE_MAP[juletype.UINT],
 	}
+	if len(data.args) == 0 {
+		p.eval.pusherrtok(errtok, "missing_expr")
+		return
+	}
 	nodes := m.nodes[m.index].nodes
 	node := &nodes[len(nodes)-1]
-	b := ast.NewBuilder(nil)
+	b := ast.NewBuilder(p.session.Env, nil)
 	i := 0
 	t, ok := b.DataType(data.args, &i, true)
 	b.Wait()
 	if !ok {
 		v, model := p.evalToks(data.args, nil)
+		if p.eval.has_error {
+			return v
+		}
 		*node = exprNode{"sizeof(" + model.String() + ")"}
 		v.constExpr = false
 		return v
@@ -812,20 +897,28 @@ func caller_mem_size_of(p *Parser, _ *Func, data callData, m *exprModel) (v valu
 }
 
 func caller_mem_align_of(p *Parser, _ *Func, data callData, m *exprModel) (v value) {
+	errtok := data.args[0]
 	// Remove parentheses
 	data.args = data.args[1 : len(data.args)-1]
 	v.data.Type = Type{
 		Id:   juletype.UINT,
 		Kind: juletype.TYPE_MAP[juletype.UINT],
 	}
+	if len(data.args) == 0 {
+		p.eval.pusherrtok(errtok, "missing_expr")
+		return
+	}
 	nodes := m.nodes[m.index].nodes
 	node := &nodes[len(nodes)-1]
-	b := ast.NewBuilder(nil)
+	b := ast.NewBuilder(p.session.Env, nil)
 	i := 0
 	t, ok := b.DataType(data.args, &i, true)
 	b.Wait()
 	if !ok {
 		v, model := p.evalToks(data.args, nil)
+		if p.eval.has_error {
+			return v
+		}
 		*node = exprNode{"alignof(" + model.String() + ")"}
 		v.constExpr = false
 		return v
@@ -838,7 +931,3 @@ func caller_mem_align_of(p *Parser, _ *Func, data callData, m *exprModel) (v val
 	*node = exprNode{"alignof(" + t.String() + ")"}
 	return
 }
-
-var std_builtin_defines = map[string]*Defmap{
-	"std::mem": std_mem_builtin,
-}
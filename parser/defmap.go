@@ -21,6 +21,10 @@ type Defmap struct {
 	side       *Defmap
 }
 
+// Side returns definitions of selected and full uses.
+// Side definitions are not owned by map but accessible from map.
+func (dm *Defmap) Side() *Defmap { return dm.side }
+
 func (dm *Defmap) find_ns_by_id(id string) int {
 	for i, t := range dm.Namespaces {
 		if t != nil && t.Id == id {
@@ -210,6 +214,50 @@ func (dm *Defmap) find_by_id(id string, f *File) (int, *Defmap, byte) {
 	return -1, nil, ' '
 }
 
+// has reports definition is exist in map or not.
+// Side defines are not included.
+func (dm *Defmap) has(def any) bool {
+	switch t := def.(type) {
+	case *TypeAlias:
+		for _, d := range dm.Types {
+			if d == t {
+				return true
+			}
+		}
+	case *trait:
+		for _, d := range dm.Traits {
+			if d == t {
+				return true
+			}
+		}
+	case *structure:
+		for _, d := range dm.Structs {
+			if d == t {
+				return true
+			}
+		}
+	case *Enum:
+		for _, d := range dm.Enums {
+			if d == t {
+				return true
+			}
+		}
+	case *Var:
+		for _, d := range dm.Globals {
+			if d == t {
+				return true
+			}
+		}
+	case *Fn:
+		for _, d := range dm.Funcs {
+			if d == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func push_defines(dest, src *Defmap) {
 	dest.Types = append(dest.Types, src.Types...)
 	dest.Traits = append(dest.Traits, src.Traits...)
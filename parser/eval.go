@@ -37,9 +37,24 @@ func (e *eval) pusherrtok(tok lex.Token, err string, args ...any) {
 	e.p.pusherrtok(tok, err, args...)
 }
 
+// pusherrsuggest appends new error by token with suggestions for id.
+func (e *eval) pusherrsuggest(tok lex.Token, id string, candidates []string, err string, args ...any) {
+	if e.has_error {
+		return
+	}
+	e.has_error = true
+	e.p.pusherrsuggest(tok, id, candidates, err, args...)
+}
+
 func (e *eval) eval_toks(toks []lex.Token) (value, iExpr) {
-	builder := ast.Builder{}
-	return e.eval_expr(builder.Expr(toks))
+	builder := ast.NewBuilder(e.p.session.Env, nil)
+	expr := builder.Expr(toks)
+	if len(builder.Errors) > 0 {
+		e.p.pusherrs(builder.Errors...)
+		e.has_error = true
+		return value{}, nil
+	}
+	return e.eval_expr(expr)
 }
 
 func (e *eval) eval_expr(expr Expr) (value, iExpr) { return e.eval(expr.Op) }
@@ -91,6 +106,7 @@ func (e *eval) eval_op(op any) (v value, model iExpr) {
 	v = process.solve()
 	v.lvalue = type_is_lvalue(v.data.Type)
 	model = get_bop_model(v, bop, lm, rm)
+	e.check_compare(bop, l, r)
 	return
 }
 
@@ -106,7 +122,7 @@ func (e *eval) eval(op any) (v value, model iExpr) {
 					Id:   juletype.INT,
 					Kind: juletype.TYPE_MAP[juletype.INT],
 				}
-				if int_assignable(dt.Id, v) {
+				if int_assignable(dt.Id, v, e.p.session.Env.BitSize()) {
 					v.data.Type = dt
 				}
 			case uint64:
@@ -114,7 +130,7 @@ func (e *eval) eval(op any) (v value, model iExpr) {
 					Id:   juletype.UINT,
 					Kind: juletype.TYPE_MAP[juletype.UINT],
 				}
-				if int_assignable(dt.Id, v) {
+				if int_assignable(dt.Id, v, e.p.session.Env.BitSize()) {
 					v.data.Type = dt
 				}
 			}
@@ -234,6 +250,7 @@ func (e *eval) dataTypeFunc(expr lex.Token, callRange []lex.Token, m *exprModel)
 		}
 		switch t := def.(type) {
 		case *TypeAlias:
+			t.Used = true
 			dt, ok := e.p.realType(t.Type, true)
 			if !ok || type_is_struct(dt) {
 				return
@@ -333,7 +350,7 @@ func (e *eval) try_cpp_linked_var(toks []lex.Token, m *exprModel) (v value, ok b
 	}
 	def, def_t := e.p.linkById(tok.Kind)
 	if def_t == ' ' {
-		e.pusherrtok(tok, "id_not_exist", tok.Kind)
+		e.pusherrsuggest(tok, tok.Kind, e.p.linked_ids(), "id_not_exist", tok.Kind)
 		return
 	}
 	m.append_sub(exprNode{tok.Kind})
@@ -472,8 +489,16 @@ end:
 }
 
 func (e *eval) castExpr(dt Type, exprToks []lex.Token, m *exprModel, errTok lex.Token) value {
+	if len(exprToks) == 0 {
+		e.pusherrtok(errTok, "missing_expr")
+		return value{}
+	}
 	val, model := e.eval_toks(exprToks)
+	if e.has_error {
+		return val
+	}
 	m.append_sub(e.get_cast_expr_model(dt, val.data.Type, model))
+	e.check_cast(val, dt, exprToks, errTok)
 	val = e.cast(val, dt, errTok)
 	return val
 }
@@ -496,7 +521,7 @@ func (e *eval) tryCast(toks []lex.Token, m *exprModel) (v value, _ bool) {
 		} else if i+1 == len(toks) {
 			return
 		}
-		b := ast.NewBuilder(nil)
+		b := ast.NewBuilder(e.p.session.Env, nil)
 		dtindex := 0
 		typeToks := toks[1:i]
 		dt, ok := b.DataType(typeToks, &dtindex, false)
@@ -632,9 +657,9 @@ func (e *eval) castInteger(t Type, v *value, errtok lex.Token) {
 	if v.constExpr {
 		switch {
 		case juletype.IsSignedInteger(t.Id):
-			v.expr = tonums(v)
+			v.expr = tonums(v.expr)
 		default:
-			v.expr = tonumu(v)
+			v.expr = tonumu(v.expr)
 		}
 	}
 	if type_is_enum(v.data.Type) {
@@ -665,11 +690,11 @@ func (e *eval) castNumeric(t Type, v *value, errtok lex.Token) {
 	if v.constExpr {
 		switch {
 		case juletype.IsFloat(t.Id):
-			v.expr = tonumf(v)
+			v.expr = tonumf(v.expr)
 		case juletype.IsSignedInteger(t.Id):
-			v.expr = tonums(v)
+			v.expr = tonums(v.expr)
 		default:
-			v.expr = tonumu(v)
+			v.expr = tonumu(v.expr)
 		}
 	}
 	if type_is_enum(v.data.Type) {
@@ -696,9 +721,10 @@ func (e *eval) castSlice(t, vt Type, errtok lex.Token) {
 }
 
 func (e *eval) juletypeSubId(dm *Defmap, idTok lex.Token, m *exprModel) (v value) {
+	defs := dm
 	i, dm, t := dm.find_by_id(idTok.Kind, nil)
 	if i == -1 {
-		e.pusherrtok(idTok, "obj_have_not_id", idTok.Kind)
+		e.pusherrsuggest(idTok, idTok.Kind, defs.ids(nil), "obj_have_not_id", idTok.Kind)
 		return
 	}
 	v.lvalue = false
@@ -752,11 +778,11 @@ func (e *eval) u64SubId(idTok lex.Token, m *exprModel) value {
 }
 
 func (e *eval) uintSubId(idTok lex.Token, m *exprModel) value {
-	return e.juletypeSubId(uintStatics, idTok, m)
+	return e.juletypeSubId(uint_statics(e.p.session.Env.BitSize()), idTok, m)
 }
 
 func (e *eval) intSubId(idTok lex.Token, m *exprModel) value {
-	return e.juletypeSubId(intStatics, idTok, m)
+	return e.juletypeSubId(int_statics(e.p.session.Env.BitSize()), idTok, m)
 }
 
 func (e *eval) f32SubId(idTok lex.Token, m *exprModel) value {
@@ -801,7 +827,7 @@ func (e *eval) typeSubId(typeTok, idTok lex.Token, m *exprModel) (v value) {
 func (e *eval) typeId(toks []lex.Token, m *exprModel) (v value) {
 	v.data.Type.Id = juletype.VOID
 	v.data.Type.Kind = juletype.TYPE_MAP[v.data.Type.Id]
-	b := ast.NewBuilder(nil)
+	b := ast.NewBuilder(e.p.session.Env, nil)
 	i := 0
 	t, ok := b.DataType(toks, &i, true)
 	b.Wait()
@@ -833,9 +859,10 @@ func (e *eval) typeId(toks []lex.Token, m *exprModel) (v value) {
 }
 
 func (e *eval) xObjSubId(dm *Defmap, val value, interior_mutability bool, idTok lex.Token, m *exprModel) (v value) {
+	defs := dm
 	i, dm, t := dm.find_by_id(idTok.Kind, idTok.File)
 	if i == -1 {
-		e.pusherrtok(idTok, "obj_have_not_id", idTok.Kind)
+		e.pusherrsuggest(idTok, idTok.Kind, defs.ids(idTok.File), "obj_have_not_id", idTok.Kind)
 		return
 	}
 	v = val
@@ -870,27 +897,25 @@ func (e *eval) xObjSubId(dm *Defmap, val value, interior_mutability bool, idTok
 }
 
 func (e *eval) strObjSubId(val value, idTok lex.Token, m *exprModel) value {
-	readyStrDefines(val)
-	v := e.xObjSubId(strDefines, val, false, idTok, m)
+	v := e.xObjSubId(e.p.session.builtin.str_defines(val), val, false, idTok, m)
 	v.lvalue = false
 	return v
 }
 
 func (e *eval) sliceObjSubId(val value, idTok lex.Token, m *exprModel) value {
-	v := e.xObjSubId(sliceDefines, val, false, idTok, m)
+	v := e.xObjSubId(e.p.session.builtin.slice, val, false, idTok, m)
 	v.lvalue = false
 	return v
 }
 
 func (e *eval) arrayObjSubId(val value, idTok lex.Token, m *exprModel) value {
-	v := e.xObjSubId(arrayDefines, val, false, idTok, m)
+	v := e.xObjSubId(e.p.session.builtin.array, val, false, idTok, m)
 	v.lvalue = false
 	return v
 }
 
 func (e *eval) mapObjSubId(val value, idTok lex.Token, m *exprModel) value {
-	readyMapDefines(val.data.Type)
-	v := e.xObjSubId(mapDefines, val, false, idTok, m)
+	v := e.xObjSubId(e.p.session.builtin.map_defines(val.data.Type), val, false, idTok, m)
 	v.lvalue = false
 	return v
 }
@@ -902,7 +927,14 @@ func (e *eval) enumSubId(val value, idTok lex.Token, m *exprModel) (v value) {
 	v.is_type = false
 	item := enum.ItemById(idTok.Kind)
 	if item == nil {
-		e.pusherrtok(idTok, "obj_have_not_id", idTok.Kind)
+		ids := make([]string, len(enum.Items))
+		for i, item := range enum.Items {
+			ids[i] = item.Id
+		}
+		e.pusherrsuggest(idTok, idTok.Kind, ids, "obj_have_not_id", idTok.Kind)
+	} else if item.ExprTag == nil {
+		// Expression of item has errors, reported by enum.
+		e.has_error = true
 	} else {
 		v.expr = item.ExprTag
 		v.model = getModel(v)
@@ -921,6 +953,10 @@ func (e *eval) structObjSubId(val value, idTok lex.Token, m *exprModel) value {
 		nodes := &m.nodes[m.index].nodes
 		n := len(*nodes)
 		defer func() {
+			// Nodes are not appended if has error.
+			if e.has_error {
+				return
+			}
 			// Save unary
 			if ast.IsUnaryOp((*nodes)[0].String()) {
 				*nodes = append([]iExpr{(*nodes)[0], exprNode{"this->"}}, (*nodes)[n+1:]...)
@@ -974,9 +1010,10 @@ func (e *eval) getNs(toks *[]lex.Token) *Defmap {
 			if src == nil {
 				if ns != nil {
 					*toks = (*toks)[i:]
+					e.p.mark_use_ns(ns)
 					return ns.defines
 				}
-				e.pusherrtok(tok, "namespace_not_exist", tok.Kind)
+				e.pusherrsuggest(tok, tok.Kind, e.p.ns_ids(), "namespace_not_exist", tok.Kind)
 				return nil
 			}
 			prev = src.defines
@@ -984,9 +1021,11 @@ func (e *eval) getNs(toks *[]lex.Token) *Defmap {
 			continue
 		}
 		if tok.Id != lex.ID_DBLCOLON {
+			e.p.mark_use_ns(ns)
 			return ns.defines
 		}
 	}
+	e.p.mark_use_ns(ns)
 	return ns.defines
 }
 
@@ -1300,7 +1339,7 @@ func (e *eval) slicingStr(v, leftv, rightv value, errtok lex.Token) value {
 // ! IMPORTANT: lex.Tokenens is should be store enumerable parentheses.
 func (e *eval) enumerableParts(toks []lex.Token) [][]lex.Token {
 	toks = toks[1 : len(toks)-1]
-	parts, errs := ast.Parts(toks, lex.ID_COMMA, true)
+	parts, errs := ast.Parts(e.p.session.Env, toks, lex.ID_COMMA, true)
 	e.p.pusherrs(errs...)
 	return parts
 }
@@ -1470,7 +1509,7 @@ func (e *eval) enumerable(exprToks []lex.Token, t Type, m *exprModel) (v value)
 }
 
 func (e *eval) anonymousFn(toks []lex.Token, m *exprModel) (v value) {
-	b := ast.NewBuilder(toks)
+	b := ast.NewBuilder(e.p.session.Env, toks)
 	f := b.Fn(b.Tokens, false, true, false)
 	b.Wait()
 	if len(b.Errors) > 0 {
@@ -1483,7 +1522,7 @@ func (e *eval) anonymousFn(toks []lex.Token, m *exprModel) (v value) {
 	v.data.Type.Tag = &f
 	v.data.Type.Id = juletype.FN
 	v.data.Type.Kind = f.TypeKind()
-	m.append_sub(anonFuncExpr{&f})
+	m.append_sub(anonFuncExpr{&f, e.p.session.Writer})
 	return
 }
 
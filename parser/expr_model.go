@@ -60,6 +60,8 @@ func (node exprNode) String() string {
 
 type anonFuncExpr struct {
 	ast  *Func
+	// Writer of session, anonymous functions have blocks.
+	w    *models.Writer
 }
 
 func (af anonFuncExpr) String() string {
@@ -75,8 +77,9 @@ func (af anonFuncExpr) String() string {
 	cpp.WriteString(" mutable -> ")
 	cpp.WriteString(af.ast.RetType.String())
 	cpp.WriteByte(' ')
+	frame := &models.TraceFrame{Token: af.ast.Token, Fn: af.ast.Id}
 	vars := af.ast.RetType.Vars(af.ast.Block)
-	cpp.WriteString(fnBlockToString(vars, af.ast.Block))
+	cpp.WriteString(fnBlockToString(frame, vars, af.ast.Block, af.w))
 	cpp.WriteByte(')')
 	return cpp.String()
 }
@@ -0,0 +1,680 @@
+package parser
+
+import (
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/julelint"
+)
+
+// Flow analysis tracks pointers and functions that always nil,
+// and reports dereferences and calls of them. Variables must
+// initialize at declaration, so a variable is "not assigned yet"
+// if it is initialized with nil and not written after that.
+//
+// Reads of mutable variables before a guaranteed write are not checked
+// separately. Declarations must have initializers, so every read has a
+// written value, and nil is the only value that is "not assigned yet".
+//
+// Analysis is conservative, just definitely nil variables are reported.
+// Facts are dropped at joins of control flow if not exist in all paths,
+// at labels because of gotos, for variables assigned in iterations,
+// and for variables that escapes with reference or anonymous functions.
+
+// nil_fact is the fact of variable which is always nil.
+type nil_fact struct {
+	site  lex.Token // Token of nil assignment or nil check.
+	label string    // Key of label message of site.
+}
+
+// flow_var is the variable tracked by flow analysis.
+type flow_var struct {
+	id      string
+	fn      bool // Function value, pointer otherwise.
+	global  bool
+	mutable bool
+	site    lex.Token // Token of nil initializer of global.
+}
+
+// flow_state is the state of control flow.
+// Nothing is reported for dead states, they are unreachable.
+type flow_state struct {
+	dead bool
+	nils map[*flow_var]nil_fact
+}
+
+func new_flow_state() flow_state {
+	return flow_state{nils: map[*flow_var]nil_fact{}}
+}
+
+func (s flow_state) copy() flow_state {
+	c := flow_state{dead: s.dead, nils: make(map[*flow_var]nil_fact, len(s.nils))}
+	for v, f := range s.nils {
+		c.nils[v] = f
+	}
+	return c
+}
+
+// join_flow returns state of facts that exist in both states.
+func join_flow(a, b flow_state) flow_state {
+	switch {
+	case a.dead:
+		return b.copy()
+	case b.dead:
+		return a.copy()
+	}
+	s := new_flow_state()
+	for v, f := range a.nils {
+		if _, ok := b.nils[v]; ok {
+			s.nils[v] = f
+		}
+	}
+	return s
+}
+
+type flow struct {
+	p       *Parser
+	scopes  []map[string]*flow_var // Variable is nil if not tracked.
+	globals map[*Var]*flow_var
+	escaped map[string]bool
+	state   flow_state
+	fall    *flow_state // State of fallthrough into next case.
+	// Global initializers are evaluated before init.
+	before_init bool
+}
+
+func new_flow(p *Parser) *flow {
+	return &flow{
+		p:       p,
+		globals: map[*Var]*flow_var{},
+		escaped: map[string]bool{},
+		state:   new_flow_state(),
+	}
+}
+
+func is_nil_expr(e models.Expr) bool {
+	return len(e.Tokens) == 1 && e.Tokens[0].Id == lex.ID_LITERAL &&
+		e.Tokens[0].Kind == lex.KND_NIL
+}
+
+func is_tracked_type(t Type) bool { return type_is_ptr(t) || type_is_fn(t) }
+
+func (f *flow) package_files() []*Parser {
+	if f.p.package_files == nil {
+		return []*Parser{f.p}
+	}
+	return *f.p.package_files
+}
+
+// global_var returns tracked variable of global.
+// Just globals initialized with nil are tracked.
+func (f *flow) global_var(g *Var) *flow_var {
+	if v, ok := f.globals[g]; ok {
+		return v
+	}
+	var v *flow_var
+	if is_tracked_type(g.Type) && is_nil_expr(g.Expr) {
+		v = &flow_var{
+			id:      g.Id,
+			fn:      type_is_fn(g.Type),
+			global:  true,
+			mutable: g.Mutable,
+			site:    g.Expr.Tokens[0],
+		}
+	}
+	f.globals[g] = v
+	return v
+}
+
+// push_mut_globals appends facts of mutable globals initialized with nil.
+// Mutable globals are nil until assigned, so used for init.
+func (f *flow) push_mut_globals() {
+	for _, fp := range f.package_files() {
+		for _, g := range fp.Defines.Globals {
+			v := f.global_var(g)
+			if v != nil && v.mutable {
+				f.state.nils[v] = nil_fact{v.site, "nil_assigned"}
+			}
+		}
+	}
+}
+
+// clear_mut_globals removes facts of mutable globals,
+// because they may assigned by function calls.
+func (f *flow) clear_mut_globals() {
+	for v := range f.state.nils {
+		if v.global && v.mutable {
+			delete(f.state.nils, v)
+		}
+	}
+}
+
+func (f *flow) push_scope() { f.scopes = append(f.scopes, map[string]*flow_var{}) }
+func (f *flow) pop_scope()  { f.scopes = f.scopes[:len(f.scopes)-1] }
+
+// declare declares variable to current scope.
+// Variable is not tracked if type is not pointer or function,
+// or if variable is escaped.
+func (f *flow) declare(id string, t Type) *flow_var {
+	var v *flow_var
+	if is_tracked_type(t) && !f.escaped[id] {
+		v = &flow_var{id: id, fn: type_is_fn(t), mutable: true}
+	}
+	f.scopes[len(f.scopes)-1][id] = v
+	return v
+}
+
+func (f *flow) resolve(id string) *flow_var {
+	for i := len(f.scopes) - 1; i >= 0; i-- {
+		if v, ok := f.scopes[i][id]; ok {
+			return v
+		}
+	}
+	for _, fp := range f.package_files() {
+		g, dm, _ := fp.Defines.global_by_id(id, fp.File)
+		if g != nil && dm == fp.Defines {
+			return f.global_var(g)
+		}
+	}
+	return nil
+}
+
+// fact returns nil fact of variable if exist.
+// Immutable globals initialized with nil are always nil.
+func (f *flow) fact(v *flow_var) (nil_fact, bool) {
+	if f.state.dead {
+		return nil_fact{}, false
+	}
+	if v.global && !v.mutable {
+		return nil_fact{v.site, "nil_assigned"}, true
+	}
+	fact, ok := f.state.nils[v]
+	return fact, ok
+}
+
+// is_unary reports operator at index i is unary operator.
+func is_unary(toks []lex.Token, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := toks[i-1]
+	switch prev.Id {
+	case lex.ID_OP, lex.ID_COMMA, lex.ID_COLON:
+		return true
+	case lex.ID_BRACE:
+		switch prev.Kind {
+		case lex.KND_LPAREN, lex.KND_LBRACKET, lex.KND_LBRACE:
+			return true
+		}
+	}
+	return false
+}
+
+// is_unary_star reports token at index i is unary star operator.
+func is_unary_star(toks []lex.Token, i int) bool {
+	return i >= 0 && toks[i].Id == lex.ID_OP &&
+		toks[i].Kind == lex.KND_STAR && is_unary(toks, i)
+}
+
+// skip_anon_fn returns index of end of anonymous function at index i.
+// Anonymous functions are not evaluated where defined.
+func skip_anon_fn(toks []lex.Token, i int) int {
+	brace_n := 0
+	for ; i < len(toks); i++ {
+		tok := toks[i]
+		if tok.Id != lex.ID_BRACE {
+			continue
+		}
+		switch tok.Kind {
+		case lex.KND_LBRACE:
+			brace_n++
+		case lex.KND_RBRACE:
+			brace_n--
+			if brace_n == 0 {
+				return i
+			}
+		}
+	}
+	return i
+}
+
+func (f *flow) report(tok lex.Token, v *flow_var, fact nil_fact) {
+	key := "nil_dereference"
+	switch {
+	case v.global && f.before_init:
+		key = "nil_before_init"
+	case v.fn:
+		key = "nil_call"
+	}
+	log := f.p.warntok(tok, julelint.NIL_DEREF, key, v.id)
+	log.Labels = append(log.Labels, f.p.labeltok(fact.site, fact.label))
+	f.p.pushwarn(log)
+	// Reported once, the rest of flow panics anyway.
+	delete(f.state.nils, v)
+}
+
+// expr checks dereferences and calls of expression tokens.
+func (f *flow) expr(toks []lex.Token) {
+	call := false
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		var next lex.Token
+		if i+1 < len(toks) {
+			next = toks[i+1]
+		}
+		switch tok.Id {
+		case lex.ID_FN:
+			i = skip_anon_fn(toks, i)
+			continue
+		case lex.ID_BRACE:
+			if tok.Kind == lex.KND_LPAREN && i > 0 {
+				switch prev := toks[i-1]; {
+				case prev.Id == lex.ID_IDENT,
+					prev.Kind == lex.KND_RPARENT, prev.Kind == lex.KND_RBRACKET:
+					call = true
+				}
+			}
+			continue
+		case lex.ID_IDENT:
+		default:
+			continue
+		}
+		if i > 0 && (toks[i-1].Id == lex.ID_DOT || toks[i-1].Id == lex.ID_DBLCOLON) {
+			continue
+		}
+		v := f.resolve(tok.Kind)
+		if v == nil {
+			continue
+		}
+		fact, ok := f.fact(v)
+		if !ok {
+			continue
+		}
+		switch {
+		case v.fn:
+			if next.Id == lex.ID_BRACE && next.Kind == lex.KND_LPAREN {
+				f.report(tok, v, fact)
+			}
+		case is_unary_star(toks, i-1), next.Id == lex.ID_DOT:
+			f.report(tok, v, fact)
+		}
+	}
+	if call {
+		f.clear_mut_globals()
+	}
+}
+
+// refine returns states of then and else branches of condition.
+// Conditions are refined just for comparisons of variable with nil.
+func (f *flow) refine(toks []lex.Token) (then flow_state, els flow_state) {
+	then, els = f.state.copy(), f.state.copy()
+	if len(toks) != 3 || toks[1].Id != lex.ID_OP {
+		return
+	}
+	var id lex.Token
+	switch {
+	case toks[0].Id == lex.ID_IDENT && is_nil_expr(models.Expr{Tokens: toks[2:]}):
+		id = toks[0]
+	case toks[2].Id == lex.ID_IDENT && is_nil_expr(models.Expr{Tokens: toks[:1]}):
+		id = toks[2]
+	default:
+		return
+	}
+	v := f.resolve(id.Kind)
+	if v == nil || (v.global && !v.mutable) {
+		return
+	}
+	switch toks[1].Kind {
+	case lex.KND_EQS:
+	case lex.KND_NOT_EQ:
+		then, els = els, then
+	default:
+		return
+	}
+	then.nils[v] = nil_fact{toks[1], "nil_checked"}
+	delete(els.nils, v)
+	return
+}
+
+func (f *flow) var_st(v Var) {
+	f.expr(v.Expr.Tokens)
+	fv := f.declare(v.Id, v.Type)
+	if fv != nil && is_nil_expr(v.Expr) {
+		f.state.nils[fv] = nil_fact{v.Expr.Tokens[0], "nil_assigned"}
+	}
+}
+
+func (f *flow) assign(a models.Assign) {
+	for _, l := range a.Left {
+		if !l.Var.New {
+			f.expr(l.Expr.Tokens)
+		}
+	}
+	for _, r := range a.Right {
+		f.expr(r.Tokens)
+	}
+	for i, l := range a.Left {
+		if l.Ignore {
+			continue
+		}
+		var v *flow_var
+		if l.Var.New {
+			v = f.declare(l.Var.Id, l.Var.Type)
+		} else if toks := l.Expr.Tokens; len(toks) == 1 && toks[0].Id == lex.ID_IDENT {
+			v = f.resolve(toks[0].Kind)
+		}
+		if v == nil {
+			continue
+		}
+		delete(f.state.nils, v)
+		if a.Setter.Kind == lex.KND_EQ && !a.MultipleRet &&
+			i < len(a.Right) && is_nil_expr(a.Right[i]) {
+			f.state.nils[v] = nil_fact{a.Right[i].Tokens[0], "nil_assigned"}
+		}
+	}
+}
+
+func (f *flow) conditional(c models.Conditional) {
+	var outs []flow_state
+	ifs := append([]*models.If{c.If}, c.Elifs...)
+	for _, i := range ifs {
+		f.expr(i.Expr.Tokens)
+		then, els := f.refine(i.Expr.Tokens)
+		f.state = then
+		f.block(i.Block)
+		outs = append(outs, f.state)
+		f.state = els
+	}
+	if c.Default != nil {
+		f.block(c.Default.Block)
+	}
+	for _, out := range outs {
+		f.state = join_flow(f.state, out)
+	}
+}
+
+func (f *flow) match(m *models.Match) {
+	f.expr(m.Expr.Tokens)
+	fall := f.fall
+	in := f.state
+	out := flow_state{dead: true}
+	cases := m.Cases
+	if m.Default != nil {
+		cases = append(cases[:len(cases):len(cases)], *m.Default)
+	}
+	f.fall = nil
+	for _, c := range cases {
+		f.state = in.copy()
+		for _, expr := range c.Exprs {
+			f.expr(expr.Tokens)
+		}
+		if f.fall != nil {
+			f.state = join_flow(f.state, *f.fall)
+			f.fall = nil
+		}
+		f.block(c.Block)
+		out = join_flow(out, f.state)
+	}
+	if m.Default == nil {
+		out = join_flow(out, in)
+	}
+	f.state = out
+	f.fall = fall
+}
+
+// assigned_ids appends identifiers of assigned variables of block.
+func assigned_ids(b *models.Block, ids map[string]bool) {
+	walk_block(b, func(s *models.Statement) {
+		a, ok := s.Data.(models.Assign)
+		if !ok {
+			return
+		}
+		for _, l := range a.Left {
+			if toks := l.Expr.Tokens; len(toks) == 1 {
+				ids[toks[0].Kind] = true
+			}
+		}
+	})
+}
+
+func (f *flow) iter(it models.Iter) {
+	ids := map[string]bool{}
+	assigned_ids(it.Block, ids)
+	while, is_while := it.Profile.(models.IterWhile)
+	if is_while && while.Next.Data != nil {
+		assigned_ids(&models.Block{Tree: []models.Statement{while.Next}}, ids)
+	}
+	for v := range f.state.nils {
+		if ids[v.id] {
+			delete(f.state.nils, v)
+		}
+	}
+	// Functions may called by previous iterations.
+	f.clear_mut_globals()
+	entry := f.state.copy()
+	f.push_scope()
+	switch t := it.Profile.(type) {
+	case models.IterWhile:
+		f.expr(t.Expr.Tokens)
+	case models.IterForeach:
+		f.expr(t.Expr.Tokens)
+		f.declare(t.KeyA.Id, t.KeyA.Type)
+		f.declare(t.KeyB.Id, t.KeyB.Type)
+	}
+	f.block(it.Block)
+	if is_while && while.Next.Data != nil {
+		f.st(&while.Next)
+	}
+	f.pop_scope()
+	f.state = entry
+}
+
+func (f *flow) block(b *models.Block) {
+	if b == nil {
+		return
+	}
+	if b.Deferred {
+		// Deferred blocks are executed at end of function.
+		state := f.state
+		f.state = new_flow_state()
+		f.stmts(b)
+		f.state = state
+		return
+	}
+	f.stmts(b)
+}
+
+func (f *flow) stmts(b *models.Block) {
+	f.push_scope()
+	for i := range b.Tree {
+		f.st(&b.Tree[i])
+	}
+	f.pop_scope()
+}
+
+func (f *flow) st(s *models.Statement) {
+	if s.Poisoned {
+		return
+	}
+	switch t := s.Data.(type) {
+	case models.ExprStatement:
+		f.expr(t.Expr.Tokens)
+		if f.p.is_terminator(s) {
+			f.state.dead = true
+		}
+	case Var:
+		f.var_st(t)
+	case models.Assign:
+		f.assign(t)
+	case models.ConcurrentCall:
+		f.expr(t.Expr.Tokens)
+	case models.Conditional:
+		f.conditional(t)
+	case *models.Match:
+		f.match(t)
+	case models.Iter:
+		f.iter(t)
+	case *models.Block:
+		f.block(t)
+	case models.Ret:
+		f.expr(t.Expr.Tokens)
+		f.state.dead = true
+	case models.Break, models.Continue, models.Goto:
+		f.state.dead = true
+	case models.Fallthrough:
+		fall := f.state.copy()
+		f.fall = &fall
+		f.state.dead = true
+	case models.Label:
+		// Facts of gotos are unknown.
+		f.state = new_flow_state()
+	}
+}
+
+// walk_block calls fn for each statement of block and sub-blocks.
+func walk_block(b *models.Block, fn func(*models.Statement)) {
+	if b == nil {
+		return
+	}
+	for i := range b.Tree {
+		walk_st(&b.Tree[i], fn)
+	}
+}
+
+func walk_st(s *models.Statement, fn func(*models.Statement)) {
+	fn(s)
+	switch t := s.Data.(type) {
+	case models.Conditional:
+		walk_block(t.If.Block, fn)
+		for _, elif := range t.Elifs {
+			walk_block(elif.Block, fn)
+		}
+		if t.Default != nil {
+			walk_block(t.Default.Block, fn)
+		}
+	case *models.Match:
+		for _, c := range t.Cases {
+			walk_block(c.Block, fn)
+		}
+		if t.Default != nil {
+			walk_block(t.Default.Block, fn)
+		}
+	case models.Iter:
+		if while, ok := t.Profile.(models.IterWhile); ok && while.Next.Data != nil {
+			walk_st(&while.Next, fn)
+		}
+		walk_block(t.Block, fn)
+	case *models.Block:
+		walk_block(t, fn)
+	}
+}
+
+// st_exprs returns expressions of statement, not includes sub-blocks.
+func st_exprs(s *models.Statement) []models.Expr {
+	switch t := s.Data.(type) {
+	case models.ExprStatement:
+		return []models.Expr{t.Expr}
+	case Var:
+		return []models.Expr{t.Expr}
+	case models.Assign:
+		exprs := append([]models.Expr{}, t.Right...)
+		for _, l := range t.Left {
+			exprs = append(exprs, l.Expr)
+		}
+		return exprs
+	case models.ConcurrentCall:
+		return []models.Expr{t.Expr}
+	case models.Ret:
+		return []models.Expr{t.Expr}
+	case models.Conditional:
+		exprs := []models.Expr{t.If.Expr}
+		for _, elif := range t.Elifs {
+			exprs = append(exprs, elif.Expr)
+		}
+		return exprs
+	case *models.Match:
+		exprs := []models.Expr{t.Expr}
+		for _, c := range t.Cases {
+			exprs = append(exprs, c.Exprs...)
+		}
+		return exprs
+	case models.Iter:
+		switch p := t.Profile.(type) {
+		case models.IterWhile:
+			return []models.Expr{p.Expr}
+		case models.IterForeach:
+			return []models.Expr{p.Expr}
+		}
+	}
+	return nil
+}
+
+// escape marks escaped variables of expression tokens.
+// Variables are escaped by references and anonymous functions,
+// they may assigned anywhere.
+func (f *flow) escape(toks []lex.Token) {
+	for i, tok := range toks {
+		switch {
+		case tok.Id == lex.ID_FN:
+			for _, tok := range toks[i+1:] {
+				if tok.Id == lex.ID_IDENT {
+					f.escaped[tok.Kind] = true
+				}
+			}
+			return
+		case tok.Id == lex.ID_OP && tok.Kind == lex.KND_AMPER &&
+			i+1 < len(toks) && toks[i+1].Id == lex.ID_IDENT:
+			if is_unary(toks, i) {
+				f.escaped[toks[i+1].Kind] = true
+			}
+		}
+	}
+}
+
+// check_flow checks flow of function.
+func (p *Parser) check_flow(fn *Func) {
+	if fn.Block == nil || !p.session.Lints.IsEnabled(julelint.NIL_DEREF) {
+		return
+	}
+	f := new_flow(p)
+	walk_block(fn.Block, func(s *models.Statement) {
+		for _, expr := range st_exprs(s) {
+			f.escape(expr.Tokens)
+		}
+	})
+	f.push_scope()
+	for _, v := range p.block_variables_of_fn(fn) {
+		f.declare(v.Id, v.Type)
+	}
+	if fn.Id == jule.INIT_FN && fn.Receiver == nil {
+		f.push_mut_globals()
+	}
+	f.block(fn.Block)
+}
+
+// check_globals_flow checks initializer expressions of globals.
+func (p *Parser) check_globals_flow() {
+	if !p.session.Lints.IsEnabled(julelint.NIL_DEREF) {
+		return
+	}
+	for _, g := range p.Defines.Globals {
+		if g.Token.File != p.File || is_nil_expr(g.Expr) {
+			continue
+		}
+		f := new_flow(p)
+		f.before_init = true
+		f.push_mut_globals()
+		f.expr(g.Expr.Tokens)
+	}
+}
+
+// check_package_flow checks flow of global initializers of all package files.
+// Flow is unknown if package has errors.
+func (p *Parser) check_package_flow() {
+	if len(p.Errors) > 0 {
+		return
+	}
+	for _, pf := range *p.package_files {
+		pf.check_globals_flow()
+	}
+}
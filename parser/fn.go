@@ -23,7 +23,7 @@ func (f *Fn) outId() string {
 	return f.Ast.OutId()
 }
 
-func fnBlockToString(vars []*Var, b *models.Block) string {
+func fnBlockToString(frame *models.TraceFrame, vars []*Var, b *models.Block, w *models.Writer) string {
 	var cpp strings.Builder
 	if vars != nil {
 		statements := make([]models.Statement, len(vars))
@@ -32,37 +32,48 @@ func fnBlockToString(vars []*Var, b *models.Block) string {
 		}
 		b.Tree = append(statements, b.Tree...)
 	}
-	cpp.WriteString(b.String())
+	if w.PanicTrace {
+		// Frame statement has not token, so it is not position update.
+		block := *b
+		block.Tree = append([]models.Statement{{Data: *frame}}, b.Tree...)
+		b = &block
+	}
+	cpp.WriteString(b.String(w))
 	return cpp.String()
 }
 
-func (f Fn) stringOwner(owner string) string {
+func (f Fn) stringOwner(owner string, w *models.Writer) string {
 	var cpp strings.Builder
-	cpp.WriteString(f.Head(owner))
+	cpp.WriteString(w.LineDirective(f.Ast.Token))
+	cpp.WriteString(f.Head(owner, w))
 	cpp.WriteByte(' ')
+	frame := &models.TraceFrame{Token: f.Ast.Token, Fn: f.outId()}
+	if owner != "" {
+		frame.Fn = owner + "." + frame.Fn
+	}
 	vars := f.Ast.RetType.Vars(f.Ast.Block)
-	cpp.WriteString(fnBlockToString(vars, f.Ast.Block))
+	cpp.WriteString(fnBlockToString(frame, vars, f.Ast.Block, w))
 	return cpp.String()
 }
 
-func (f Fn) String() string {
-	return f.stringOwner("")
+func (f Fn) String(w *models.Writer) string {
+	return f.stringOwner("", w)
 }
 
 // Head returns declaration head of function.
-func (f *Fn) Head(owner string) string {
+func (f *Fn) Head(owner string, w *models.Writer) string {
 	var cpp strings.Builder
-	cpp.WriteString(f.declHead(owner))
+	cpp.WriteString(f.declHead(owner, w))
 	cpp.WriteString(paramsToCpp(f.Ast.Params))
 	return cpp.String()
 }
 
-func (f *Fn) declHead(owner string) string {
+func (f *Fn) declHead(owner string, w *models.Writer) string {
 	var cpp strings.Builder
 	cpp.WriteString(genericsToCpp(f.Ast.Generics))
 	if cpp.Len() > 0 {
 		cpp.WriteByte('\n')
-		cpp.WriteString(models.IndentString())
+		cpp.WriteString(w.IndentString())
 	}
 	if !f.isEntryPoint {
 		cpp.WriteString("inline ")
@@ -79,9 +90,9 @@ func (f *Fn) declHead(owner string) string {
 }
 
 // Prototype returns prototype cpp code of function.
-func (f *Fn) Prototype(owner string) string {
+func (f *Fn) Prototype(owner string, w *models.Writer) string {
 	var cpp strings.Builder
-	cpp.WriteString(f.declHead(owner))
+	cpp.WriteString(f.declHead(owner, w))
 	cpp.WriteString(f.PrototypeParams())
 	cpp.WriteByte(';')
 	return cpp.String()
@@ -0,0 +1,299 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/julelint"
+	"github.com/julelang/jule/pkg/julelog"
+	"github.com/julelang/jule/pkg/juletype"
+)
+
+const allow_pragma_prefix = jule.PRAGMA_COMMENT_PREFIX + julelint.ALLOW_PRAGMA + lex.KND_LPAREN
+
+// warntok returns new warning log of lint by token.
+func (p *Parser) warntok(tok lex.Token, lint, key string, args ...any) julelog.CompilerLog {
+	log := p.errtok(tok, key, args...)
+	log.Type = julelog.WARN
+	log.Lint = lint
+	return log
+}
+
+// is_allowed reports lint is allowed by allow pragmas of scope.
+func (p *Parser) is_allowed(lint string) bool {
+	for _, name := range p.allowed {
+		if name == lint || name == julelint.ALL {
+			return true
+		}
+	}
+	return false
+}
+
+// pushwarn appends warning if lint of warning is enabled
+// and not allowed in scope. Same warnings are appended once,
+// because generic functions are checked for each combination.
+func (p *Parser) pushwarn(log julelog.CompilerLog) {
+	if !p.session.Lints.IsEnabled(log.Lint) || p.is_allowed(log.Lint) {
+		return
+	}
+	for _, w := range p.Warnings {
+		if w.Key == log.Key && w.Path == log.Path &&
+			w.Row == log.Row && w.Column == log.Column {
+			return
+		}
+	}
+	p.Warnings = append(p.Warnings, log)
+}
+
+// pushwarntok appends new warning of lint by token.
+func (p *Parser) pushwarntok(tok lex.Token, lint, key string, args ...any) {
+	p.pushwarn(p.warntok(tok, lint, key, args...))
+}
+
+// pushwarnexist appends new warning of lint by token with
+// label of previous declaration at prev.
+func (p *Parser) pushwarnexist(tok, prev lex.Token, lint, key string, args ...any) {
+	log := p.warntok(tok, lint, key, args...)
+	if prev.File != nil {
+		log.Labels = append(log.Labels, p.labeltok(prev, "previous_declaration"))
+	}
+	p.pushwarn(log)
+}
+
+func is_allow_pragma(c models.Comment) bool {
+	return strings.HasPrefix(c.Content, allow_pragma_prefix)
+}
+
+// push_allows appends lints of allow pragma to waiting allows.
+// Waiting allows are used by the next declaration or statement.
+func (p *Parser) push_allows(c models.Comment) {
+	content := strings.TrimSpace(c.Content[len(allow_pragma_prefix):])
+	if !strings.HasSuffix(content, lex.KND_RPARENT) {
+		p.pusherrtok(c.Token, "invalid_syntax")
+		return
+	}
+	content = content[:len(content)-len(lex.KND_RPARENT)]
+	for _, name := range strings.Split(content, lex.KND_COMMA) {
+		name = strings.TrimSpace(name)
+		if name != julelint.ALL && !julelint.IsLint(name) {
+			p.pusherrtok(c.Token, "unknown_lint", name)
+			continue
+		}
+		p.allows = append(p.allows, name)
+	}
+}
+
+func (p *Parser) checkAllows(obj models.Object) {
+	if p.allows == nil {
+		return
+	}
+	switch obj.Data.(type) {
+	case models.Attribute, models.Comment, []GenericType:
+		return
+	}
+	p.allows = nil
+}
+
+// in_generic reports generic types are exist in scope.
+// Some lints are not reliable for generic types,
+// because generic functions are checked with real types.
+func (p *Parser) in_generic() bool {
+	for _, t := range p.blockTypes {
+		if t.Generic {
+			return true
+		}
+	}
+	return false
+}
+
+// is_terminator reports statement terminates control flow
+// of block or not.
+func (p *Parser) is_terminator(s *models.Statement) bool {
+	if s.Poisoned {
+		return false
+	}
+	switch t := s.Data.(type) {
+	case models.Ret, models.Break, models.Continue, models.Goto:
+		return true
+	case models.ExprStatement:
+		toks := t.Expr.Tokens
+		if len(toks) == 0 || toks[0].Id != lex.ID_IDENT ||
+			toks[0].Kind != p.session.builtin.panic_fn.Ast.Id || ast.IsFnCall(toks) == nil {
+			return false
+		}
+		def, _, _ := p.defined_by_id(toks[0].Kind)
+		return def == p.session.builtin.panic_fn
+	}
+	return false
+}
+
+// check_unreachable checks statements after terminator at index i.
+// Label makes following statements reachable by goto.
+func (p *Parser) check_unreachable(b *models.Block, i int) {
+	for _, s := range b.Tree[i+1:] {
+		switch s.Data.(type) {
+		case models.Comment:
+			continue
+		case models.Label:
+			return
+		}
+		if s.Token.File != nil {
+			p.pushwarntok(s.Token, julelint.UNREACHABLE, "unreachable_code")
+		}
+		return
+	}
+}
+
+// check_empty_block checks block is empty or not.
+// Blocks with comments are not empty, comments can explain emptiness.
+func (p *Parser) check_empty_block(b *models.Block, tok lex.Token) {
+	if b != nil && len(b.Tree) == 0 {
+		p.pushwarntok(tok, julelint.EMPTY_BLOCK, "empty_block")
+	}
+}
+
+// is_pure_toks reports tokens are just a selection of
+// identifiers which is free of side effects and literals.
+func is_pure_toks(toks []lex.Token) bool {
+	if len(toks) == 0 {
+		return false
+	}
+	for _, tok := range toks {
+		switch tok.Id {
+		case lex.ID_IDENT, lex.ID_SELF, lex.ID_DOT:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func is_pure_operand(op any) bool {
+	expr, ok := op.(models.BinopExpr)
+	return ok && is_pure_toks(expr.Tokens)
+}
+
+// is_literal_operand reports operand is just a literal.
+func is_literal_operand(op any) bool {
+	expr, ok := op.(models.BinopExpr)
+	return ok && len(expr.Tokens) == 1 && expr.Tokens[0].Id == lex.ID_LITERAL
+}
+
+func same_operands(l, r any) bool {
+	if !is_pure_operand(l) || !is_pure_operand(r) {
+		return false
+	}
+	ltoks := l.(models.BinopExpr).Tokens
+	rtoks := r.(models.BinopExpr).Tokens
+	if len(ltoks) != len(rtoks) {
+		return false
+	}
+	for i := range ltoks {
+		if ltoks[i].Kind != rtoks[i].Kind {
+			return false
+		}
+	}
+	return true
+}
+
+func is_zero(v value) bool {
+	if !v.constExpr {
+		return false
+	}
+	switch t := v.expr.(type) {
+	case int64:
+		return t == 0
+	case uint64:
+		return t == 0
+	}
+	return false
+}
+
+// compare_result returns constant result of comparison.
+//
+// Special case is;
+//
+//	compare_result(bop, l, r) -> returns false for ok if result is not constant.
+func compare_result(bop models.Binop, l, r value) (result bool, ok bool) {
+	op := bop.Op.Kind
+	switch {
+	case l.constExpr && r.constExpr:
+		// Constant expressions are intentional,
+		// except comparisons of bool and str literals.
+		switch l.expr.(type) {
+		case bool, string:
+		default:
+			return false, false
+		}
+		if !is_literal_operand(bop.L) || !is_literal_operand(bop.R) {
+			return false, false
+		}
+		switch op {
+		case lex.KND_EQS:
+			return l.expr == r.expr, true
+		case lex.KND_NOT_EQ:
+			return l.expr != r.expr, true
+		}
+	case same_operands(bop.L, bop.R) && !juletype.IsFloat(l.data.Type.Id):
+		switch op {
+		case lex.KND_EQS, lex.KND_LESS_EQ, lex.KND_GREAT_EQ:
+			return true, true
+		case lex.KND_NOT_EQ, lex.KND_LT, lex.KND_GT:
+			return false, true
+		}
+	case juletype.IsUnsignedInteger(l.data.Type.Id) && is_zero(r):
+		switch op {
+		case lex.KND_GREAT_EQ:
+			return true, true
+		case lex.KND_LT:
+			return false, true
+		}
+	case juletype.IsUnsignedInteger(r.data.Type.Id) && is_zero(l):
+		switch op {
+		case lex.KND_LESS_EQ:
+			return true, true
+		case lex.KND_GT:
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// check_compare checks comparisons that are always true or false.
+func (e *eval) check_compare(bop models.Binop, l, r value) {
+	if e.has_error || e.p.in_generic() || !type_is_pure(l.data.Type) || !type_is_pure(r.data.Type) {
+		return
+	}
+	result, ok := compare_result(bop, l, r)
+	if !ok {
+		return
+	}
+	kind := lex.KND_FALSE
+	if result {
+		kind = lex.KND_TRUE
+	}
+	e.p.pushwarntok(bop.Op, julelint.CONST_COMPARE, "comparison_always", kind)
+}
+
+// check_cast checks cast to type of value.
+// Just casts of identifiers are checked, because types of
+// expressions with literals may be different in C++.
+func (e *eval) check_cast(v value, t Type, toks []lex.Token, errtok lex.Token) {
+	if len(toks) > 1 && toks[0].Kind == lex.KND_LPAREN &&
+		toks[len(toks)-1].Kind == lex.KND_RPARENT {
+		toks = toks[1 : len(toks)-1]
+	}
+	if e.has_error || v.constExpr || e.p.in_generic() || !is_pure_toks(toks) {
+		return
+	}
+	if !types_equals(v.data.Type, t) {
+		return
+	}
+	if t.Token.File != nil {
+		errtok = t.Token
+	}
+	e.p.pushwarntok(errtok, julelint.REDUNDANT_CAST, "redundant_cast", t.Kind)
+}
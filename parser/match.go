@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/pkg/julelint"
+	"github.com/julelang/jule/pkg/juletype"
+)
+
+// is_after reports token a is after token b in source file.
+func is_after(a, b lex.Token) bool {
+	if a.Row != b.Row {
+		return a.Row > b.Row
+	}
+	return a.Column > b.Column
+}
+
+// const_case_key returns key of constant case value.
+//
+// Special case is;
+//
+//	const_case_key(v) -> returns false if value is not constant.
+func const_case_key(v *value) (any, bool) {
+	if v == nil || (!v.constExpr && !type_is_enum(v.data.Type)) {
+		return nil, false
+	}
+	switch v.expr.(type) {
+	case int64, uint64, float64, string, bool:
+		return v.expr, true
+	}
+	return nil, false
+}
+
+// check_match checks cases of match by values of case expressions.
+// Cases of match without expression are conditions,
+// so just order of cases is checked for them.
+func (p *Parser) check_match(m *models.Match, values [][]*value) {
+	if m.Default != nil {
+		for _, c := range m.Cases {
+			if is_after(c.Token, m.Default.Token) {
+				p.pushwarntok(c.Token, julelint.CASE_ORDER, "case_after_default")
+			}
+		}
+	}
+	if m.Expr.IsEmpty() {
+		return
+	}
+	covered := map[any]lex.Token{}
+	// Coverage is unknown if any case is not constant.
+	known := true
+	for i, c := range m.Cases {
+		for j, v := range values[i] {
+			key, ok := const_case_key(v)
+			if !ok {
+				known = false
+				continue
+			}
+			tok := c.Exprs[j].Tokens[0]
+			prev, exist := covered[key]
+			if !exist {
+				covered[key] = tok
+				continue
+			}
+			log := p.errtok(tok, "duplicate_case")
+			log.Labels = append(log.Labels, p.labeltok(prev, "previous_case"))
+			p.pusherrs(log)
+		}
+	}
+	if m.Default != nil || !known {
+		return
+	}
+	var missing []string
+	switch {
+	case type_is_enum(m.ExprType):
+		enum, ok := m.ExprType.Tag.(*Enum)
+		if !ok {
+			return
+		}
+		for _, item := range enum.Items {
+			if _, exist := covered[item.ExprTag]; !exist {
+				missing = append(missing, item.Id)
+			}
+		}
+	case m.ExprType.Id == juletype.BOOL && type_is_pure(m.ExprType):
+		if _, exist := covered[true]; !exist {
+			missing = append(missing, lex.KND_TRUE)
+		}
+		if _, exist := covered[false]; !exist {
+			missing = append(missing, lex.KND_FALSE)
+		}
+	}
+	if len(missing) > 0 {
+		p.pushwarntok(m.Token, julelint.NON_EXHAUSTIVE, "non_exhaustive_match", strings.Join(missing, ", "))
+	}
+}
@@ -7,3 +7,6 @@ type namespace struct {
 	Token   lex.Token
 	defines *Defmap
 }
+
+// Defines returns definitions of namespace.
+func (ns *namespace) Defines() *Defmap { return ns.defines }
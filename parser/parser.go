@@ -14,6 +14,7 @@ import (
 	"github.com/julelang/jule/pkg/jule"
 	"github.com/julelang/jule/pkg/juleapi"
 	"github.com/julelang/jule/pkg/juleio"
+	"github.com/julelang/jule/pkg/julelint"
 	"github.com/julelang/jule/pkg/julelog"
 	"github.com/julelang/jule/pkg/juletype"
 	"github.com/julelang/jule/preprocessor"
@@ -32,8 +33,6 @@ type Struct = models.Struct
 type GenericType = models.GenericType
 type RetType = models.RetType
 
-var used []*use
-
 // Parser is parser of Jule code.
 type Parser struct {
 	attributes       []models.Attribute
@@ -54,11 +53,19 @@ type Parser struct {
 	linked_structs   []*structure
 	allowBuiltin     bool
 	package_files    *[]*Parser
+	poisoned_n       int             // Count of skipped poisoned statements.
+	poisoned_ids     map[string]bool // Identifiers of broken definitions.
+	lex_errors       []int           // Byte offsets of errors of lexer.
+	allows           []string        // Lints of waiting allow pragmas.
+	allowed          []string        // Allowed lints of scope.
+	use_decls        []*use_decl
+	session          *Session
 
 	NoLocalPkg  bool
 	JustDefines bool
 	NoCheck     bool
 	IsMain      bool
+	Excluded    bool // File is excluded by build constraints.
 	Uses        []*use
 	Defines     *Defmap
 	Errors      []julelog.CompilerLog
@@ -66,9 +73,10 @@ type Parser struct {
 	File        *File
 }
 
-// New returns new instance of Parser.
-func New(f *File) *Parser {
+// New returns new instance of Parser for session.
+func New(s *Session, f *File) *Parser {
 	p := new(Parser)
+	p.session = s
 	p.File = f
 	p.allowBuiltin = true
 	p.Defines = new(Defmap)
@@ -77,20 +85,56 @@ func New(f *File) *Parser {
 	return p
 }
 
+// errtok returns new error log by token.
+func (p *Parser) errtok(tok lex.Token, key string, args ...any) julelog.CompilerLog {
+	row, column := tok.End()
+	return julelog.CompilerLog{
+		Type:      julelog.ERR,
+		Row:       tok.Row,
+		Column:    tok.Column,
+		EndRow:    row,
+		EndColumn: column,
+		Path:      tok.File.Path(),
+		File:      tok.File,
+		Message:   p.session.Env.GetError(key, args...),
+		Key:       key,
+		Args:      args,
+	}
+}
+
+// labeltok returns new label by token.
+func (p *Parser) labeltok(tok lex.Token, key string, args ...any) julelog.Label {
+	row, column := tok.End()
+	if row != tok.Row {
+		column = -1
+	}
+	return julelog.Label{
+		Row:       tok.Row,
+		Column:    tok.Column,
+		EndColumn: column,
+		Path:      tok.File.Path(),
+		File:      tok.File,
+		Message:   p.session.Env.GetError(key, args...),
+	}
+}
+
 // pusherrtok appends new error by token.
 func (p *Parser) pusherrtok(tok lex.Token, key string, args ...any) {
-	p.pusherrmsgtok(tok, jule.GetError(key, args...))
+	p.Errors = append(p.Errors, p.errtok(tok, key, args...))
 }
 
-// pusherrtok appends new error message by token.
-func (p *Parser) pusherrmsgtok(tok lex.Token, msg string) {
-	p.Errors = append(p.Errors, julelog.CompilerLog{
-		Type:    julelog.ERR,
-		Row:     tok.Row,
-		Column:  tok.Column,
-		Path:    tok.File.Path(),
-		Message: msg,
-	})
+// pusherrexist appends new error by token with
+// label of previous declaration at prev.
+//
+// Special case is;
+//
+//	pusherrexist(tok, prev, key, args) -> label is not added if prev has not file.
+func (p *Parser) pusherrexist(tok, prev lex.Token, key string, args ...any) {
+	log := p.errtok(tok, key, args...)
+	if prev.File != nil {
+		log.Labels = append(log.Labels, p.labeltok(prev, "previous_declaration"))
+	}
+	p.Errors = append(p.Errors, log)
 }
 
 // pusherrs appends specified errors.
@@ -100,7 +144,12 @@ func (p *Parser) pusherrs(errs ...julelog.CompilerLog) {
 
 // PushErr appends new error.
 func (p *Parser) PushErr(key string, args ...any) {
-	p.pusherrmsg(jule.GetError(key, args...))
+	p.Errors = append(p.Errors, julelog.CompilerLog{
+		Type:    julelog.FLAT_ERR,
+		Message: p.session.Env.GetError(key, args...),
+		Key:     key,
+		Args:    args,
+	})
 }
 
 // pusherrmsh appends new flat error message
@@ -114,7 +163,7 @@ func (p *Parser) pusherrmsg(msg string) {
 // CppLinks returns cpp code of cpp links.
 func (p *Parser) CppLinks() string {
 	var cpp strings.Builder
-	for _, use := range used {
+	for _, use := range p.session.used {
 		if use.cppLink {
 			cpp.WriteString("#include ")
 			if is_sys_header_path(use.Path) {
@@ -144,7 +193,7 @@ func cppTypes(dm *Defmap) string {
 // CppTypes returns cpp code of types.
 func (p *Parser) CppTypes() string {
 	var cpp strings.Builder
-	for _, use := range used {
+	for _, use := range p.session.used {
 		if !use.cppLink {
 			cpp.WriteString(cppTypes(use.defines))
 		}
@@ -153,11 +202,11 @@ func (p *Parser) CppTypes() string {
 	return cpp.String()
 }
 
-func cppTraits(dm *Defmap) string {
+func cppTraits(dm *Defmap, w *models.Writer) string {
 	var cpp strings.Builder
 	for _, t := range dm.Traits {
 		if t.Used && t.Ast.Token.Id != lex.ID_NA {
-			cpp.WriteString(t.String())
+			cpp.WriteString(t.String(w))
 			cpp.WriteString("\n\n")
 		}
 	}
@@ -167,21 +216,21 @@ func cppTraits(dm *Defmap) string {
 // CppTraits returns cpp code of traits.
 func (p *Parser) CppTraits() string {
 	var cpp strings.Builder
-	for _, use := range used {
+	for _, use := range p.session.used {
 		if !use.cppLink {
-			cpp.WriteString(cppTraits(use.defines))
+			cpp.WriteString(cppTraits(use.defines, p.session.Writer))
 		}
 	}
-	cpp.WriteString(cppTraits(p.Defines))
+	cpp.WriteString(cppTraits(p.Defines, p.session.Writer))
 	return cpp.String()
 }
 
-// CppStructs returns cpp code of structures.
-func CppStructs(structures []*structure) string {
+// CppStructs returns cpp code of structures with writer.
+func CppStructs(structures []*structure, w *models.Writer) string {
 	var cpp strings.Builder
 	for _, s := range structures {
 		if s.Used && s.Ast.Token.Id != lex.ID_NA {
-			cpp.WriteString(s.String())
+			cpp.WriteString(s.String(w))
 			cpp.WriteString("\n\n")
 		}
 	}
@@ -199,22 +248,22 @@ func cppStructPlainPrototypes(structures []*structure) string {
 	return cpp.String()
 }
 
-func cppStructPrototypes(structures []*structure) string {
+func cppStructPrototypes(structures []*structure, w *models.Writer) string {
 	var cpp strings.Builder
 	for _, s := range structures {
 		if s.Used && s.Ast.Token.Id != lex.ID_NA {
-			cpp.WriteString(s.prototype())
+			cpp.WriteString(s.prototype(w))
 			cpp.WriteByte('\n')
 		}
 	}
 	return cpp.String()
 }
 
-func cppFuncPrototypes(dm *Defmap) string {
+func cppFuncPrototypes(dm *Defmap, w *models.Writer) string {
 	var cpp strings.Builder
 	for _, f := range dm.Funcs {
 		if f.used && f.Ast.Token.Id != lex.ID_NA {
-			cpp.WriteString(f.Prototype(""))
+			cpp.WriteString(f.Prototype("", w))
 			cpp.WriteByte('\n')
 		}
 	}
@@ -225,20 +274,21 @@ func cppFuncPrototypes(dm *Defmap) string {
 func (p *Parser) CppPrototypes(structures []*structure) string {
 	var cpp strings.Builder
 	cpp.WriteString(cppStructPlainPrototypes(structures))
-	cpp.WriteString(cppStructPrototypes(structures))
-	for _, use := range used {
+	cpp.WriteString(cppStructPrototypes(structures, p.session.Writer))
+	for _, use := range p.session.used {
 		if !use.cppLink {
-			cpp.WriteString(cppFuncPrototypes(use.defines))
+			cpp.WriteString(cppFuncPrototypes(use.defines, p.session.Writer))
 		}
 	}
-	cpp.WriteString(cppFuncPrototypes(p.Defines))
+	cpp.WriteString(cppFuncPrototypes(p.Defines, p.session.Writer))
 	return cpp.String()
 }
 
-func cppGlobals(dm *Defmap) string {
+func cppGlobals(dm *Defmap, w *models.Writer) string {
 	var cpp strings.Builder
 	for _, g := range dm.Globals {
 		if !g.Const && g.Used && g.Token.Id != lex.ID_NA {
+			cpp.WriteString(w.LineDirective(g.Token))
 			cpp.WriteString(g.String())
 			cpp.WriteByte('\n')
 		}
@@ -249,20 +299,20 @@ func cppGlobals(dm *Defmap) string {
 // CppGlobals returns cpp code of global variables.
 func (p *Parser) CppGlobals() string {
 	var cpp strings.Builder
-	for _, use := range used {
+	for _, use := range p.session.used {
 		if !use.cppLink {
-			cpp.WriteString(cppGlobals(use.defines))
+			cpp.WriteString(cppGlobals(use.defines, p.session.Writer))
 		}
 	}
-	cpp.WriteString(cppGlobals(p.Defines))
+	cpp.WriteString(cppGlobals(p.Defines, p.session.Writer))
 	return cpp.String()
 }
 
-func cppFuncs(dm *Defmap) string {
+func cppFuncs(dm *Defmap, w *models.Writer) string {
 	var cpp strings.Builder
 	for _, f := range dm.Funcs {
 		if f.used && f.Ast.Token.Id != lex.ID_NA {
-			cpp.WriteString(f.String())
+			cpp.WriteString(f.String(w))
 			cpp.WriteString("\n\n")
 		}
 	}
@@ -272,24 +322,25 @@ func cppFuncs(dm *Defmap) string {
 // CppFuncs returns cpp code of functions.
 func (p *Parser) CppFuncs() string {
 	var cpp strings.Builder
-	for _, use := range used {
+	for _, use := range p.session.used {
 		if !use.cppLink {
-			cpp.WriteString(cppFuncs(use.defines))
+			cpp.WriteString(cppFuncs(use.defines, p.session.Writer))
 		}
 	}
-	cpp.WriteString(cppFuncs(p.Defines))
+	cpp.WriteString(cppFuncs(p.Defines, p.session.Writer))
 	return cpp.String()
 }
 
 // CppInitializerCaller returns cpp code of initializer caller.
 func (p *Parser) CppInitializerCaller() string {
 	var cpp strings.Builder
+	cpp.WriteString(p.session.Writer.LineReset())
 	cpp.WriteString("void ")
 	cpp.WriteString(juleapi.INIT_CALLER)
 	cpp.WriteString("(void) {")
-	models.AddIndent()
-	indent := models.IndentString()
-	models.DoneIndent()
+	p.session.Writer.AddIndent()
+	indent := p.session.Writer.IndentString()
+	p.session.Writer.DoneIndent()
 	pushInit := func(defs *Defmap) {
 		f, dm, _ := defs.fn_by_id(jule.INIT_FN, nil)
 		if f == nil || dm != defs {
@@ -300,7 +351,7 @@ func (p *Parser) CppInitializerCaller() string {
 		cpp.WriteString(f.outId())
 		cpp.WriteString("();")
 	}
-	for _, use := range used {
+	for _, use := range p.session.used {
 		if !use.cppLink {
 			pushInit(use.defines)
 		}
@@ -313,7 +364,7 @@ func (p *Parser) CppInitializerCaller() string {
 func (p *Parser) get_all_structures() []*structure {
 	order := make([]*structure, 0, len(p.Defines.Structs))
 	order = append(order, p.Defines.Structs...)
-	for _, use := range used {
+	for _, use := range p.session.used {
 		if !use.cppLink {
 			order = append(order, use.defines.Structs...)
 		}
@@ -322,6 +373,7 @@ func (p *Parser) get_all_structures() []*structure {
 }
 
 // Cpp returns full cpp code of parsed objects.
+// Code is generated with writer of session.
 func (p *Parser) Cpp() string {
 	structures := p.get_all_structures()
 	order_structures(structures)
@@ -334,15 +386,16 @@ func (p *Parser) Cpp() string {
 	cpp.WriteString(p.CppPrototypes(structures))
 	cpp.WriteString("\n\n")
 	cpp.WriteString(p.CppGlobals())
-	cpp.WriteString(CppStructs(structures))
+	cpp.WriteString(CppStructs(structures, p.session.Writer))
 	cpp.WriteString("\n\n")
 	cpp.WriteString(p.CppFuncs())
 	cpp.WriteString(p.CppInitializerCaller())
 	return cpp.String()
 }
 
-func getTree(toks []lex.Token) ([]models.Object, []julelog.CompilerLog) {
-	b := ast.NewBuilder(toks)
+func (p *Parser) getTree(toks []lex.Token) ([]models.Object, []julelog.CompilerLog) {
+	b := ast.NewBuilder(p.session.Env, toks)
+	b.LexErrors = p.lex_errors
 	b.Build()
 	return b.Tree, b.Errors
 }
@@ -360,25 +413,26 @@ func (p *Parser) checkCppUsePath(use *models.UseDecl) bool {
 		p.pusherrtok(use.Token, "invalid_header_ext", ext)
 		return false
 	}
-	err := os.Chdir(use.Token.File.Dir)
-	if err != nil {
-		p.pusherrtok(use.Token, "use_not_found", use.Path)
-		return false
+	// Relative to directory of file, not changes working directory
+	// because it is shared by concurrent sessions.
+	// Headers are included by cpp compiler, so OS file system is used.
+	path := use.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(use.Token.File.Dir, path)
 	}
-	info, err := os.Stat(use.Path)
+	info, err := os.Stat(path)
 	// Exist?
 	if err != nil || info.IsDir() {
 		p.pusherrtok(use.Token, "use_not_found", use.Path)
 		return false
 	}
 	// Set to absolute path for correct include path
-	use.Path, _ = filepath.Abs(use.Path)
-	_ = os.Chdir(jule.WORKING_PATH)
+	use.Path, _ = filepath.Abs(path)
 	return true
 }
 
 func (p *Parser) checkPureUsePath(use *models.UseDecl) bool {
-	info, err := os.Stat(use.Path)
+	info, err := p.session.FS.Stat(use.Path)
 	// Exist?
 	if err != nil || !info.IsDir() {
 		p.pusherrtok(use.Token, "use_not_found", use.Path)
@@ -400,7 +454,7 @@ func (p *Parser) checkUsePath(use *models.UseDecl) bool {
 	return true
 }
 
-func (p *Parser) pushSelects(use *use, selectors []lex.Token) (addNs bool) {
+func (p *Parser) pushSelects(use *use, selectors []lex.Token, decl *use_decl) (addNs bool) {
 	if len(selectors) > 0 && p.Defines.side == nil {
 		p.Defines.side = new(Defmap)
 	}
@@ -409,7 +463,7 @@ func (p *Parser) pushSelects(use *use, selectors []lex.Token) (addNs bool) {
 			if j >= i {
 				break
 			} else if jid.Kind == id.Kind {
-				p.pusherrtok(id, "exist_id", id.Kind)
+				p.pusherrexist(id, jid, "exist_id", id.Kind)
 				i = -1
 				break
 			}
@@ -419,33 +473,45 @@ func (p *Parser) pushSelects(use *use, selectors []lex.Token) (addNs bool) {
 		}
 		if id.Id == lex.ID_SELF {
 			addNs = true
+			decl.selectors = append(decl.selectors, &use_selector{token: id})
 			continue
 		}
 		i, m, def_t := use.defines.find_by_id(id.Kind, p.File)
 		if i == -1 {
-			p.pusherrtok(id, "id_not_exist", id.Kind)
+			p.pusherrsuggest(id, id.Kind, use.defines.ids(p.File), "id_not_exist", id.Kind)
 			continue
 		}
+		var def any
 		switch def_t {
 		case 'i':
+			def = m.Traits[i]
 			p.Defines.side.Traits = append(p.Defines.side.Traits, m.Traits[i])
 		case 'f':
+			def = m.Funcs[i]
 			p.Defines.side.Funcs = append(p.Defines.side.Funcs, m.Funcs[i])
 		case 'e':
+			def = m.Enums[i]
 			p.Defines.side.Enums = append(p.Defines.side.Enums, m.Enums[i])
 		case 'g':
+			def = m.Globals[i]
 			p.Defines.side.Globals = append(p.Defines.side.Globals, m.Globals[i])
 		case 't':
+			def = m.Types[i]
 			p.Defines.side.Types = append(p.Defines.side.Types, m.Types[i])
 		case 's':
+			def = m.Structs[i]
 			p.Defines.side.Structs = append(p.Defines.side.Structs, m.Structs[i])
 		}
+		decl.selectors = append(decl.selectors, &use_selector{token: id, def: def})
 	}
 	return
 }
 
-func (p *Parser) pushUse(use *use, selectors []lex.Token) {
-	dm, ok := std_builtin_defines[use.LinkString]
+func (p *Parser) pushUse(use *use, ast *models.UseDecl) {
+	selectors := ast.Selectors
+	decl := &use_decl{token: ast.Token, link: ast.LinkString}
+	p.use_decls = append(p.use_decls, decl)
+	dm, ok := p.session.builtin.std[use.LinkString]
 	if ok {
 		push_defines(use.defines, dm)
 	}
@@ -454,8 +520,9 @@ func (p *Parser) pushUse(use *use, selectors []lex.Token) {
 			p.Defines.side = new(Defmap)
 		}
 		push_defines(p.Defines.side, use.defines)
+		decl.full = use.defines
 	} else if len(selectors) > 0 {
-		if !p.pushSelects(use, selectors) {
+		if !p.pushSelects(use, selectors, decl) {
 			return
 		}
 	} else if selectors != nil {
@@ -465,6 +532,7 @@ func (p *Parser) pushUse(use *use, selectors []lex.Token) {
 	ns.Identifiers = strings.SplitN(use.LinkString, lex.KND_DBLCOLON, -1)
 	src := p.pushNs(ns)
 	src.defines = use.defines
+	decl.ns = src
 }
 
 func (p *Parser) compileCppLinkUse(useAST *models.UseDecl) (*use, bool) {
@@ -496,7 +564,7 @@ func (p *Parser) wrap_package() {
 }
 
 func (p *Parser) compilePureUse(useAST *models.UseDecl) (_ *use, hassErr bool) {
-	infos, err := os.ReadDir(useAST.Path)
+	infos, err := p.session.FS.ReadDir(useAST.Path)
 	if err != nil {
 		p.pusherrmsg(err.Error())
 		return nil, true
@@ -506,24 +574,30 @@ func (p *Parser) compilePureUse(useAST *models.UseDecl) (_ *use, hassErr bool) {
 		// Skip directories.
 		if info.IsDir() ||
 			!strings.HasSuffix(name, jule.SRC_EXT) ||
-			!juleio.IsPassFileAnnotation(name) {
+			!juleio.IsPassFileAnnotation(p.session.Env, name) {
 			continue
 		}
 		path := filepath.Join(useAST.Path, name)
-		f, err := juleio.Jopen(path)
+		f, err := juleio.Jopen(p.session.FS, path)
 		if err != nil {
 			p.pusherrmsg(err.Error())
 			continue
 		}
-		psub := New(f)
+		psub := New(p.session, f)
 		psub.SetupPackage()
 		psub.Parsef(false, false)
+		if psub.Excluded {
+			continue
+		}
 		psub.wrap_package()
 		use := make_use_from_ast(useAST)
 		push_defines(use.defines, psub.Defines)
 		p.pusherrs(psub.Errors...)
-		p.Warnings = append(p.Warnings, psub.Warnings...)
-		p.pushUse(use, useAST.Selectors)
+		// Warnings of standard library are not shown.
+		if !strings.HasPrefix(useAST.Path, p.session.Env.StdlibPath) {
+			p.Warnings = append(p.Warnings, psub.Warnings...)
+		}
+		p.pushUse(use, useAST)
 		if psub.Errors != nil {
 			p.pusherrtok(useAST.Token, "use_has_errors")
 			return use, true
@@ -545,12 +619,19 @@ func (p *Parser) use(ast *models.UseDecl, err *bool) {
 		*err = true
 		return
 	}
+	// Already uses?
+	for _, u := range p.Uses {
+		if ast.Path == u.Path {
+			p.pusherrtok(ast.Token, "already_uses")
+			return
+		}
+	}
 	// Already parsed?
-	for _, u := range used {
+	for _, u := range p.session.used {
 		if ast.Path == u.Path {
 			old := u.FullUse
 			u.FullUse = ast.FullUse
-			p.pushUse(u, ast.Selectors)
+			p.pushUse(u, ast)
 			p.Uses = append(p.Uses, u)
 			u.FullUse = old
 			return
@@ -561,36 +642,28 @@ func (p *Parser) use(ast *models.UseDecl, err *bool) {
 	if u == nil {
 		return
 	}
-	// Already uses?
-	for _, pu := range p.Uses {
-		if u.Path == pu.Path {
-			p.pusherrtok(ast.Token, "already_uses")
-			return
-		}
-	}
-	used = append(used, u)
+	p.session.used = append(p.session.used, u)
 	p.Uses = append(p.Uses, u)
 }
 
-func (p *Parser) parseUses(tree *[]models.Object) bool {
-	err := false
+func (p *Parser) parseUses(tree *[]models.Object) {
 	for i := range *tree {
 		obj := &(*tree)[i]
 		switch obj_t := obj.Data.(type) {
 		case models.UseDecl:
-			if !err {
-				p.use(&obj_t, &err)
+			err := false
+			p.use(&obj_t, &err)
+			if err {
+				p.poison_use(&obj_t)
 			}
 			obj.Data = nil
 		case models.Comment:
 			// Ignore beginning comments.
 		default:
-			goto end
+			return
 		}
 	}
 	*tree = nil
-end:
-	return err
 }
 
 func objectIsIgnored(obj *models.Object) bool {
@@ -601,6 +674,10 @@ func (p *Parser) parseSrcTreeObj(obj models.Object) {
 	if objectIsIgnored(&obj) {
 		return
 	}
+	if obj.Poisoned {
+		p.poison_st(obj.Data)
+		return
+	}
 	switch obj_t := obj.Data.(type) {
 	case models.Statement:
 		p.St(obj_t)
@@ -640,16 +717,14 @@ func (p *Parser) parseSrcTree(tree []models.Object) {
 		p.parseSrcTreeObj(obj)
 		p.checkDoc(obj)
 		p.checkAttribute(obj)
+		p.checkAllows(obj)
 		p.checkGenerics(obj)
 	}
 }
 
-func (p *Parser) parseTree(tree []models.Object) (ok bool) {
-	if p.parseUses(&tree) {
-		return false
-	}
+func (p *Parser) parseTree(tree []models.Object) {
+	p.parseUses(&tree)
 	p.parseSrcTree(tree)
-	return true
 }
 
 func (p *Parser) checkParse() {
@@ -665,7 +740,7 @@ func (p *Parser) useLocalPackage(tree *[]models.Object) (hasErr bool) {
 	if p.File == nil {
 		return
 	}
-	infos, err := os.ReadDir(p.File.Dir)
+	infos, err := p.session.FS.ReadDir(p.File.Dir)
 	if err != nil {
 		p.pusherrmsg(err.Error())
 		return true
@@ -675,16 +750,16 @@ func (p *Parser) useLocalPackage(tree *[]models.Object) (hasErr bool) {
 		// Skip directories.
 		if info.IsDir() ||
 			!strings.HasSuffix(name, jule.SRC_EXT) ||
-			!juleio.IsPassFileAnnotation(name) ||
+			!juleio.IsPassFileAnnotation(p.session.Env, name) ||
 			name == p.File.Name {
 			continue
 		}
-		f, err := juleio.Jopen(filepath.Join(p.File.Dir, name))
+		f, err := juleio.Jopen(p.session.FS, filepath.Join(p.File.Dir, name))
 		if err != nil {
 			p.pusherrmsg(err.Error())
 			return true
 		}
-		fp := New(f)
+		fp := New(p.session, f)
 		fp.package_files = p.package_files
 		*p.package_files = append(*p.package_files, fp)
 		fp.NoLocalPkg = true
@@ -696,6 +771,10 @@ func (p *Parser) useLocalPackage(tree *[]models.Object) (hasErr bool) {
 			p.pusherrs(fp.Errors...)
 			return true
 		}
+		if fp.Excluded {
+			// Remove excluded file from package.
+			*p.package_files = (*p.package_files)[:len(*p.package_files)-1]
+		}
 	}
 	return
 }
@@ -705,14 +784,34 @@ func (p *Parser) SetupPackage() {
 	*p.package_files = append(*p.package_files, p)
 }
 
+// PackageFiles returns parsers of all files of package.
+// Parser of file is included.
+//
+// Special case is;
+//
+//	PackageFiles() -> returns nil if package is not set up
+func (p *Parser) PackageFiles() []*Parser {
+	if p.package_files == nil {
+		return nil
+	}
+	return *p.package_files
+}
+
 // Parses Jule code from object tree.
 func (p *Parser) Parset(tree []models.Object, main, justDefines bool) {
 	p.IsMain = main
 	p.JustDefines = justDefines
-	preprocessor.Process(&tree, !main)
-	if !p.parseTree(tree) {
+	buildable, errs := preprocessor.IsBuildable(p.session.Env, tree)
+	if len(errs) > 0 {
+		p.pusherrs(errs...)
 		return
 	}
+	if !buildable {
+		p.Excluded = true
+		return
+	}
+	preprocessor.Process(&tree, !main)
+	p.parseTree(tree)
 	if !p.NoLocalPkg {
 		if p.useLocalPackage(&tree) {
 			return
@@ -724,21 +823,21 @@ func (p *Parser) Parset(tree []models.Object, main, justDefines bool) {
 
 // Parses Jule code from tokens.
 func (p *Parser) Parse(toks []lex.Token, main, justDefines bool) {
-	tree, errors := getTree(toks)
+	tree, errors := p.getTree(toks)
 	if len(errors) > 0 {
+		// Continue with recovered tree for reporting other errors.
 		p.pusherrs(errors...)
-		return
 	}
 	p.Parset(tree, main, justDefines)
 }
 
 // Parses Jule code from file.
 func (p *Parser) Parsef(main, justDefines bool) {
-	lexer := lex.NewLex(p.File)
+	lexer := lex.NewLex(p.session.Env, p.File)
 	toks := lexer.Lex()
-	if lexer.Logs != nil {
+	if len(lexer.Logs) > 0 {
 		p.pusherrs(lexer.Logs...)
-		return
+		p.lex_errors = lexer.ErrOffsets
 	}
 	p.Parse(toks, main, justDefines)
 }
@@ -788,7 +887,7 @@ func (p *Parser) Generics(generics []GenericType) {
 			if j >= i {
 				break
 			} else if generic.Id == cgeneric.Id {
-				p.pusherrtok(generic.Token, "exist_id", generic.Id)
+				p.pusherrexist(generic.Token, cgeneric.Token, "exist_id", generic.Id)
 				break
 			}
 		}
@@ -814,7 +913,7 @@ func (p *Parser) Type(alias TypeAlias) {
 	}
 	_, tok, canshadow := p.defined_by_id(alias.Id)
 	if tok.Id != lex.ID_NA && !canshadow {
-		p.pusherrtok(alias.Token, "exist_id", alias.Id)
+		p.pusherrexist(alias.Token, tok, "exist_id", alias.Id)
 		return
 	}
 	p.Defines.Types = append(p.Defines.Types, p.make_type_alias(alias))
@@ -830,7 +929,7 @@ func (p *Parser) parse_enum_items_str(e *Enum) {
 					break
 				}
 				if item.Id == checkItem.Id {
-					p.pusherrtok(item.Token, "exist_id", item.Id)
+					p.pusherrexist(item.Token, checkItem.Token, "exist_id", item.Id)
 					break
 				}
 			}
@@ -865,7 +964,7 @@ func (p *Parser) parse_enum_items_str(e *Enum) {
 }
 
 func (p *Parser) parse_enum_items_integer(e *Enum) {
-	max := juletype.MaxOfType(e.Type.Id)
+	max := juletype.MaxOfType(e.Type.Id, p.session.Env.BitSize())
 	for i, item := range e.Items {
 		if max == 0 {
 			p.pusherrtok(item.Token, "overflow_limits")
@@ -880,7 +979,7 @@ func (p *Parser) parse_enum_items_integer(e *Enum) {
 					break
 				}
 				if item.Id == checkItem.Id {
-					p.pusherrtok(item.Token, "exist_id", item.Id)
+					p.pusherrexist(item.Token, checkItem.Token, "exist_id", item.Id)
 					break
 				}
 			}
@@ -922,7 +1021,7 @@ func (p *Parser) Enum(e Enum) {
 	}
 	_, tok, _ := p.defined_by_id(e.Id)
 	if tok.Id != lex.ID_NA {
-		p.pusherrtok(e.Token, "exist_id", e.Id)
+		p.pusherrexist(e.Token, tok, "exist_id", e.Id)
 		return
 	}
 	e.Desc = p.docText.String()
@@ -956,7 +1055,7 @@ func (p *Parser) pushField(s *structure, f **Var, i int) {
 			break
 		}
 		if (*f).Id == cf.Id {
-			p.pusherrtok((*f).Token, "exist_id", (*f).Id)
+			p.pusherrexist((*f).Token, cf.Token, "exist_id", (*f).Id)
 			break
 		}
 	}
@@ -1020,8 +1119,8 @@ func (p *Parser) Struct(model Struct) {
 	if juleapi.IsIgnoreId(model.Id) {
 		p.pusherrtok(model.Token, "ignore_id")
 		return
-	} else if def, _, _ := p.defined_by_id(model.Id); def != nil {
-		p.pusherrtok(model.Token, "exist_id", model.Id)
+	} else if def, tok, _ := p.defined_by_id(model.Id); def != nil {
+		p.pusherrexist(model.Token, tok, "exist_id", model.Id)
 		return
 	}
 	s := p.make_struct(model)
@@ -1098,8 +1197,8 @@ func (p *Parser) Trait(model models.Trait) {
 	if juleapi.IsIgnoreId(model.Id) {
 		p.pusherrtok(model.Token, "ignore_id")
 		return
-	} else if def, _, _ := p.defined_by_id(model.Id); def != nil {
-		p.pusherrtok(model.Token, "exist_id", model.Id)
+	} else if def, tok, _ := p.defined_by_id(model.Id); def != nil {
+		p.pusherrexist(model.Token, tok, "exist_id", model.Id)
 		return
 	}
 	trait := new(trait)
@@ -1117,7 +1216,7 @@ func (p *Parser) Trait(model models.Trait) {
 			if j >= i {
 				break
 			} else if f.Id == jf.Id {
-				p.pusherrtok(f.Token, "exist_id", f.Id)
+				p.pusherrexist(f.Token, jf.Token, "exist_id", f.Id)
 			}
 		}
 		_ = p.check_param_dup(f.Params)
@@ -1132,7 +1231,7 @@ func (p *Parser) Trait(model models.Trait) {
 func (p *Parser) implTrait(model *models.Impl) {
 	trait_def, _, _ := p.trait_by_id(model.Base.Kind)
 	if trait_def == nil {
-		p.pusherrtok(model.Base, "id_not_exist", model.Base.Kind)
+		p.pusherrsuggest(model.Base, model.Base.Kind, p.scope_ids(), "id_not_exist", model.Base.Kind)
 		return
 	}
 	trait_def.Used = true
@@ -1142,7 +1241,7 @@ func (p *Parser) implTrait(model *models.Impl) {
 	s, _, _ := p.struct_by_id(model.Target.Kind)
 	p.Defines.side = side
 	if s == nil {
-		p.pusherrtok(model.Target.Token, "id_not_exist", sid)
+		p.pusherrsuggest(model.Target.Token, sid, p.scope_ids(), "id_not_exist", sid)
 		return
 	}
 	model.Target.Tag = s
@@ -1153,7 +1252,7 @@ func (p *Parser) implTrait(model *models.Impl) {
 			p.Comment(obj_t)
 		case *Func:
 			if trait_def.FindFunc(obj_t.Id) == nil {
-				p.pusherrtok(model.Target.Token, "trait_hasnt_id", trait_def.Ast.Id, obj_t.Id)
+				p.pusherrsuggest(model.Target.Token, obj_t.Id, trait_def.Defines.ids(nil), "trait_hasnt_id", trait_def.Ast.Id, obj_t.Id)
 				break
 			}
 			i, _, _ := s.Defines.find_by_id(obj_t.Id, nil)
@@ -1166,8 +1265,10 @@ func (p *Parser) implTrait(model *models.Impl) {
 			sf.Ast.Receiver.Token = s.Ast.Token
 			sf.Ast.Receiver.Tag = s
 			sf.Ast.Attributes = p.attributes
+			sf.Ast.Allows = p.allows
 			sf.Ast.Owner = p
 			p.attributes = nil
+			p.allows = nil
 			sf.Desc = p.docText.String()
 			p.docText.Reset()
 			_ = p.check_param_dup(sf.Ast.Params)
@@ -1198,7 +1299,7 @@ func (p *Parser) implStruct(model *models.Impl) {
 	s, _, _ := p.struct_by_id(model.Base.Kind)
 	p.Defines.side = side
 	if s == nil {
-		p.pusherrtok(model.Base, "id_not_exist", model.Base.Kind)
+		p.pusherrsuggest(model.Base, model.Base.Kind, p.scope_ids(), "id_not_exist", model.Base.Kind)
 		return
 	}
 	for _, obj := range model.Tree {
@@ -1218,17 +1319,19 @@ func (p *Parser) implStruct(model *models.Impl) {
 			sf.Ast.Receiver.Token = s.Ast.Token
 			sf.Ast.Receiver.Tag = s
 			sf.Ast.Attributes = p.attributes
+			sf.Ast.Allows = p.allows
 			sf.Desc = p.docText.String()
 			sf.Ast.Owner = p
 			p.docText.Reset()
 			p.attributes = nil
+			p.allows = nil
 			setGenerics(sf.Ast, p.generics)
 			p.generics = nil
 			_ = p.check_param_dup(sf.Ast.Params)
 			p.check_ret_variables(sf.Ast)
 			for _, generic := range obj_t.Generics {
-				if find_generic(generic.Id, s.Ast.Generics) != nil {
-					p.pusherrtok(generic.Token, "exist_id", generic.Id)
+				if g := find_generic(generic.Id, s.Ast.Generics); g != nil {
+					p.pusherrexist(generic.Token, g.Token, "exist_id", generic.Id)
 				}
 			}
 			if len(s.Ast.Generics) == 0 {
@@ -1271,6 +1374,9 @@ func (p *Parser) Comment(c models.Comment) {
 	switch {
 	case preprocessor.IsPreprocessorPragma(c.Content):
 		return
+	case is_allow_pragma(c):
+		p.push_allows(c)
+		return
 	case strings.HasPrefix(c.Content, jule.PRAGMA_COMMENT_PREFIX):
 		p.PushAttribute(c)
 		return
@@ -1285,6 +1391,7 @@ func (p *Parser) PushAttribute(c models.Comment) {
 	// Skip attribute prefix
 	attr.Tag = c.Content[len(jule.PRAGMA_COMMENT_PREFIX):]
 	attr.Token = c.Token
+	attr.Span = c.Span
 	ok := false
 	for _, kind := range jule.ATTRS {
 		if attr.Tag == kind {
@@ -1456,7 +1563,7 @@ func setGenerics(f *Func, generics []*models.GenericType) {
 func (p *Parser) Func(ast Func) {
 	_, tok, canshadow := p.defined_by_id(ast.Id)
 	if tok.Id != lex.ID_NA && !canshadow {
-		p.pusherrtok(ast.Token, "exist_id", ast.Id)
+		p.pusherrexist(ast.Token, tok, "exist_id", ast.Id)
 	} else if juleapi.IsIgnoreId(ast.Id) {
 		p.pusherrtok(ast.Token, "ignore_id")
 	}
@@ -1464,7 +1571,9 @@ func (p *Parser) Func(ast Func) {
 	f.Ast = new(Func)
 	*f.Ast = ast
 	f.Ast.Attributes = p.attributes
+	f.Ast.Allows = p.allows
 	p.attributes = nil
+	p.allows = nil
 	f.Ast.Owner = p
 	f.Desc = p.docText.String()
 	p.docText.Reset()
@@ -1478,14 +1587,14 @@ func (p *Parser) Func(ast Func) {
 
 // ParseVariable parse Jule global variable.
 func (p *Parser) Global(vast Var) {
-	def, _, _ := p.defined_by_id(vast.Id)
+	def, tok, _ := p.defined_by_id(vast.Id)
 	if def != nil {
-		p.pusherrtok(vast.Token, "exist_id", vast.Id)
+		p.pusherrexist(vast.Token, tok, "exist_id", vast.Id)
 		return
 	} else {
 		for _, g := range p.Defines.Globals {
 			if vast.Id == g.Id {
-				p.pusherrtok(vast.Token, "exist_id", vast.Id)
+				p.pusherrexist(vast.Token, g.Token, "exist_id", vast.Id)
 				return
 			}
 		}
@@ -1669,7 +1778,7 @@ func (p *Parser) linkById(id string) (any, byte) {
 //	fn_by_id(id) -> nil: if function is not exist.
 func (p *Parser) fn_by_id(id string) (*Fn, *Defmap, bool) {
 	if p.allowBuiltin {
-		f, _, _ := Builtin.fn_by_id(id, nil)
+		f, _, _ := p.session.builtin.defines.fn_by_id(id, nil)
 		if f != nil {
 			return f, nil, false
 		}
@@ -1677,6 +1786,7 @@ func (p *Parser) fn_by_id(id string) (*Fn, *Defmap, bool) {
 	for _, fp := range *p.package_files {
 		f, dm, can_shadow := fp.Defines.fn_by_id(id, fp.File)
 		if f != nil && p.is_accessible_define(fp, dm) {
+			p.mark_use(fp, dm, f)
 			return f, dm, can_shadow
 		}
 	}
@@ -1687,6 +1797,7 @@ func (p *Parser) global_by_id(id string) (*Var, *Defmap, bool) {
 	for _, fp := range *p.package_files {
 		g, dm, _ := fp.Defines.global_by_id(id, fp.File)
 		if g != nil && p.is_accessible_define(fp, dm) {
+			p.mark_use(fp, dm, g)
 			return g, dm, true
 		}
 	}
@@ -1735,7 +1846,7 @@ func (p *Parser) type_by_id(id string) (*TypeAlias, *Defmap, bool) {
 		return alias, nil, canshadow
 	}
 	if p.allowBuiltin {
-		alias, _, _ = Builtin.type_by_id(id, nil)
+		alias, _, _ = p.session.builtin.defines.type_by_id(id, nil)
 		if alias != nil {
 			return alias, nil, false
 		}
@@ -1743,6 +1854,7 @@ func (p *Parser) type_by_id(id string) (*TypeAlias, *Defmap, bool) {
 	for _, fp := range *p.package_files {
 		a, dm, can_shadow := fp.Defines.type_by_id(id, fp.File)
 		if a != nil && p.is_accessible_define(fp, dm) {
+			p.mark_use(fp, dm, a)
 			return a, dm, can_shadow
 		}
 	}
@@ -1751,7 +1863,7 @@ func (p *Parser) type_by_id(id string) (*TypeAlias, *Defmap, bool) {
 
 func (p *Parser) enum_by_id(id string) (*Enum, *Defmap, bool) {
 	if p.allowBuiltin {
-		e, _, _ := Builtin.enum_by_id(id, nil)
+		e, _, _ := p.session.builtin.defines.enum_by_id(id, nil)
 		if e != nil {
 			return e, nil, false
 		}
@@ -1759,6 +1871,7 @@ func (p *Parser) enum_by_id(id string) (*Enum, *Defmap, bool) {
 	for _, fp := range *p.package_files {
 		e, dm, can_shadow := fp.Defines.enum_by_id(id, fp.File)
 		if e != nil && p.is_accessible_define(fp, dm) {
+			p.mark_use(fp, dm, e)
 			return e, dm, can_shadow
 		}
 	}
@@ -1767,7 +1880,7 @@ func (p *Parser) enum_by_id(id string) (*Enum, *Defmap, bool) {
 
 func (p *Parser) struct_by_id(id string) (*structure, *Defmap, bool) {
 	if p.allowBuiltin {
-		s, _, _ := Builtin.struct_by_Id(id, nil)
+		s, _, _ := p.session.builtin.defines.struct_by_Id(id, nil)
 		if s != nil {
 			return s, nil, false
 		}
@@ -1775,6 +1888,7 @@ func (p *Parser) struct_by_id(id string) (*structure, *Defmap, bool) {
 	for _, fp := range *p.package_files {
 		s, dm, can_shadow := fp.Defines.struct_by_Id(id, fp.File)
 		if s != nil && p.is_accessible_define(fp, dm) {
+			p.mark_use(fp, dm, s)
 			return s, dm, can_shadow
 		}
 	}
@@ -1783,7 +1897,7 @@ func (p *Parser) struct_by_id(id string) (*structure, *Defmap, bool) {
 
 func (p *Parser) trait_by_id(id string) (*trait, *Defmap, bool) {
 	if p.allowBuiltin {
-		trait_def, _, _ := Builtin.trait_by_id(id, nil)
+		trait_def, _, _ := p.session.builtin.defines.trait_by_id(id, nil)
 		if trait_def != nil {
 			return trait_def, nil, false
 		}
@@ -1791,6 +1905,7 @@ func (p *Parser) trait_by_id(id string) (*trait, *Defmap, bool) {
 	for _, fp := range *p.package_files {
 		t, dm, can_shadow := fp.Defines.trait_by_id(id, fp.File)
 		if t != nil && p.is_accessible_define(fp, dm) {
+			p.mark_use(fp, dm, t)
 			return t, dm, can_shadow
 		}
 	}
@@ -1895,7 +2010,10 @@ func (p *Parser) check_package() {
 	if p.IsMain && !p.JustDefines {
 		f, _, _ := p.Defines.fn_by_id(jule.ENTRY_POINT, nil)
 		if f == nil {
-			p.PushErr("no_entry_point")
+			// Entry point is not checked if it has syntax errors.
+			if !p.poisoned_ids[jule.ENTRY_POINT] {
+				p.PushErr("no_entry_point")
+			}
 		} else {
 			f.isEntryPoint = true
 			f.used = true
@@ -1904,6 +2022,14 @@ func (p *Parser) check_package() {
 	p.precheck_package()
 	if !p.JustDefines {
 		p.parse_package_defines()
+		p.check_package_usage()
+		p.check_package_flow()
+	}
+	for _, pf := range *p.package_files {
+		if p != pf {
+			p.Warnings = append(p.Warnings, pf.Warnings...)
+			pf.Warnings = nil
+		}
 	}
 }
 
@@ -2109,7 +2235,7 @@ func (p *Parser) check_param_dup(params []models.Param) (err bool) {
 				break
 			} else if param.Id == jparam.Id {
 				err = true
-				p.pusherrtok(param.Token, "exist_id", param.Id)
+				p.pusherrexist(param.Token, jparam.Token, "exist_id", param.Id)
 			}
 		}
 	}
@@ -2150,7 +2276,17 @@ func (p *Parser) parse_pure_fn(f *Func) (err bool) {
 		return
 	}
 	owner.blockVars = owner.block_variables_of_fn(f)
+	// Allowed lints of caller are not inherited.
+	allowed := owner.allowed
+	owner.allowed = nil
+	n := len(owner.Errors)
 	owner.check_fn(f)
+	if len(owner.Errors) == n {
+		owner.allowed = f.Allows
+		owner.check_flow(f)
+		owner.check_races(f)
+	}
+	owner.allowed = allowed
 	if owner != p {
 		owner.wg.Wait()
 		p.pusherrs(owner.Errors...)
@@ -2255,6 +2391,10 @@ func (p *Parser) callStructConstructor(s *structure, argsToks []lex.Token, m *ex
 	argsToks[len(argsToks)-1].Kind = lex.KND_RPARENT
 
 	args := p.get_args(argsToks, true)
+	if args == nil {
+		p.eval.has_error = true
+		return
+	}
 	if s.CppLinked() {
 		m.append_sub(exprNode{lex.KND_LPAREN})
 		m.append_sub(exprNode{f.RetType.String()})
@@ -2341,7 +2481,7 @@ func (p *Parser) get_args(toks []lex.Token, targeting bool) *models.Args {
 	if toks == nil {
 		toks = make([]lex.Token, 0)
 	}
-	b := new(ast.Builder)
+	b := ast.NewBuilder(p.session.Env, nil)
 	args := b.Args(toks, targeting)
 	if len(b.Errors) > 0 {
 		p.pusherrs(b.Errors...)
@@ -2357,14 +2497,14 @@ func (p *Parser) get_generics(toks []lex.Token) (_ []Type, err bool) {
 	}
 	// Remove braces
 	toks = toks[1 : len(toks)-1]
-	parts, errs := ast.Parts(toks, lex.ID_COMMA, true)
+	parts, errs := ast.Parts(p.session.Env, toks, lex.ID_COMMA, true)
 	generics := make([]Type, len(parts))
 	p.pusherrs(errs...)
 	for i, part := range parts {
 		if len(part) == 0 {
 			continue
 		}
-		b := ast.NewBuilder(nil)
+		b := ast.NewBuilder(p.session.Env, nil)
 		j := 0
 		generic, _ := b.DataType(part, &j, true)
 		b.Wait()
@@ -2498,17 +2638,27 @@ ok:
 	return true
 }
 
+// generic_fn_copy returns copy of generic function for call.
+// Parameters and return type are copied too.
+func generic_fn_copy(f *Func) *Func {
+	fc := new(Func)
+	*fc = *f
+	fc.Params = make([]Param, len(f.Params))
+	for i, param := range f.Params {
+		fc.Params[i] = param
+		fc.Params[i].Type = param.Type.Copy()
+	}
+	fc.RetType.Type = f.RetType.Type.Copy()
+	return fc
+}
+
 func (p *Parser) parse_fn_call(f *Func, args *models.Args, m *exprModel, errTok lex.Token) (v value) {
 	args.NeedsPureType = p.rootBlock == nil || len(p.rootBlock.Func.Generics) == 0
 	if len(f.Generics) > 0 {
-		params := make([]Param, len(f.Params))
-		for i := range params {
-			param := &params[i]
-			fparam := &f.Params[i]
-			*param = *fparam
-			param.Type = fparam.Type.Copy()
-		}
-		retType := f.RetType.Type.Copy()
+		// Types of function are reloaded for generics of call,
+		// so call is parsed with copy and types of declaration are not changed.
+		decl := f
+		f = generic_fn_copy(f)
 		owner := f.Owner.(*Parser)
 		rootBlock := owner.rootBlock
 		nodeBlock := owner.nodeBlock
@@ -2520,14 +2670,11 @@ func (p *Parser) parse_fn_call(f *Func, args *models.Args, m *exprModel, errTok
 			owner.blockVars = blockVars
 			owner.blockTypes = blockTypes
 
-			// Remember generics
-			for i := range params {
-				params[i].Type.Generic = f.Params[i].Type.Generic
+			// Remember generics, they are same for all calls.
+			for i := range decl.Params {
+				decl.Params[i].Type.Generic = f.Params[i].Type.Generic
 			}
-			retType.Generic = f.RetType.Type.Generic
-
-			f.Params = params
-			f.RetType.Type = retType
+			decl.RetType.Type.Generic = f.RetType.Type.Generic
 		}()
 		if !p.parseGenerics(f, args, errTok) {
 			return
@@ -2581,6 +2728,10 @@ func (p *Parser) parse_fn_call_toks(f *Func, genericsToks, argsToks []lex.Token,
 		return
 	}
 	args = p.get_args(argsToks, false)
+	if args == nil {
+		p.eval.has_error = true
+		return
+	}
 	args.Generics = generics
 	return p.parse_fn_call(f, args, m, argsToks[0])
 }
@@ -2806,10 +2957,16 @@ func (p *Parser) checkNewBlockCustom(b *models.Block, oldBlockVars []*Var) {
 		}()
 	}
 	blockTypes := p.blockTypes
+	poisoned_n := p.poisoned_n
 	p.checkBlock(b)
 
 	vars := p.blockVars[len(oldBlockVars):]
 	aliases := p.blockTypes[len(blockTypes):]
+	// Usage of definitions is unknown if block has poisoned statements.
+	if p.poisoned_n > poisoned_n {
+		vars = nil
+		aliases = nil
+	}
 	for _, v := range vars {
 		if !v.Used {
 			p.pusherrtok(v.Token, "declared_but_not_used", v.Id)
@@ -2848,9 +3005,9 @@ func (p *Parser) st(s *models.Statement, recover bool) bool {
 	case *models.Match:
 		p.matchcase(data)
 	case TypeAlias:
-		def, _, canshadow := p.block_define_by_id(data.Id)
+		def, tok, canshadow := p.block_define_by_id(data.Id)
 		if def != nil && !canshadow {
-			p.pusherrtok(data.Token, "exist_id", data.Id)
+			p.pusherrexist(data.Token, tok, "exist_id", data.Id)
 			break
 		} else if juleapi.IsIgnoreId(data.Id) {
 			p.pusherrtok(data.Token, "ignore_id")
@@ -2859,12 +3016,16 @@ func (p *Parser) st(s *models.Statement, recover bool) bool {
 		data.Type, _ = p.realType(data.Type, true)
 		p.blockTypes = append(p.blockTypes, &data)
 	case *models.Block:
+		p.check_empty_block(data, s.Token)
 		p.checkNewBlock(data)
 		s.Data = data
 	case models.ConcurrentCall:
 		p.concurrentCall(&data)
 		s.Data = data
 	case models.Comment:
+		if is_allow_pragma(data) {
+			p.push_allows(data)
+		}
 	default:
 		return false
 	}
@@ -2885,6 +3046,17 @@ func (p *Parser) fallthrough_st(f *models.Fallthrough, b *models.Block, i *int)
 
 func (p *Parser) check_st(b *models.Block, i *int) {
 	s := &b.Tree[*i]
+	if _, ok := s.Data.(models.Comment); !ok && p.allows != nil {
+		allowed := p.allowed
+		p.allowed = append(p.allowed[:len(p.allowed):len(p.allowed)], p.allows...)
+		p.allows = nil
+		defer func() { p.allowed = allowed }()
+	}
+	if s.Poisoned {
+		p.poisoned_n++
+		p.poison_st(s.Data)
+		return
+	}
 	if p.st(s, true) {
 		return
 	}
@@ -2911,8 +3083,8 @@ func (p *Parser) check_st(b *models.Block, i *int) {
 		obj.Block = b
 		*b.Gotos = append(*b.Gotos, obj)
 	case models.Label:
-		if find_label_parent(data.Label, b) != nil {
-			p.pusherrtok(data.Token, "label_exist", data.Label)
+		if label := find_label_parent(data.Label, b); label != nil {
+			p.pusherrexist(data.Token, label.Token, "label_exist", data.Label)
 			break
 		}
 		obj := new(models.Label)
@@ -2926,17 +3098,26 @@ func (p *Parser) check_st(b *models.Block, i *int) {
 }
 
 func (p *Parser) checkBlock(b *models.Block) {
+	unreachable := false
 	for i := 0; i < len(b.Tree); i++ {
 		p.check_st(b, &i)
+		if !unreachable && p.is_terminator(&b.Tree[i]) {
+			// Warn once for block.
+			unreachable = true
+			p.check_unreachable(b, i)
+		}
 	}
+	p.allows = nil
 }
 
 func (p *Parser) recoverFuncExprSt(s *models.ExprStatement) {
 	errtok := s.Expr.Tokens[0]
 	callToks := s.Expr.Tokens[1:]
 	args := p.get_args(callToks, false)
-	handleParam := recoverFunc.Ast.Params[0]
-	if len(args.Src) == 0 {
+	handleParam := p.session.builtin.recover_fn.Ast.Params[0]
+	if args == nil {
+		return
+	} else if len(args.Src) == 0 {
 		p.pusherrtok(errtok, "missing_expr_for", handleParam.Id)
 		return
 	} else if len(args.Src) > 1 {
@@ -2976,13 +3157,13 @@ func (p *Parser) expr_st(s *models.ExprStatement, recover bool) {
 	if s.Expr.IsNotBinop() {
 		expr := s.Expr.Op.(models.BinopExpr)
 		tok := expr.Tokens[0]
-		if tok.Id == lex.ID_IDENT && tok.Kind == recoverFunc.Ast.Id {
+		if tok.Id == lex.ID_IDENT && tok.Kind == p.session.builtin.recover_fn.Ast.Id {
 			if ast.IsFnCall(s.Expr.Tokens) != nil {
 				if !recover {
 					p.pusherrtok(tok, "invalid_syntax")
 				}
 				def, _, _ := p.defined_by_id(tok.Kind)
-				if def == recoverFunc {
+				if def == p.session.builtin.recover_fn {
 					p.recoverFuncExprSt(s)
 					return
 				}
@@ -2994,11 +3175,17 @@ func (p *Parser) expr_st(s *models.ExprStatement, recover bool) {
 	}
 }
 
-func (p *Parser) parseCase(c *models.Case, expr_t Type) {
+// parseCase parses case and returns values of case expressions.
+// Values are nil for expressions with error.
+func (p *Parser) parseCase(c *models.Case, expr_t Type) []*value {
+	values := make([]*value, len(c.Exprs))
 	for i := range c.Exprs {
 		expr := &c.Exprs[i]
 		value, model := p.evalExpr(*expr, nil)
 		expr.Model = model
+		if !p.eval.has_error {
+			values[i] = &value
+		}
 		assign_checker{
 			p:      p,
 			expr_t: expr_t,
@@ -3010,12 +3197,15 @@ func (p *Parser) parseCase(c *models.Case, expr_t Type) {
 	p.currentCase = c
 	p.checkNewBlock(c.Block)
 	p.currentCase = oldCase
+	return values
 }
 
-func (p *Parser) cases(m *models.Match, expr_t Type) {
+func (p *Parser) cases(m *models.Match, expr_t Type) [][]*value {
+	values := make([][]*value, len(m.Cases))
 	for i := range m.Cases {
-		p.parseCase(&m.Cases[i], expr_t)
+		values[i] = p.parseCase(&m.Cases[i], expr_t)
 	}
+	return values
 }
 
 func (p *Parser) matchcase(m *models.Match) {
@@ -3027,10 +3217,11 @@ func (p *Parser) matchcase(m *models.Match) {
 		m.ExprType.Id = juletype.BOOL
 		m.ExprType.Kind = juletype.TYPE_MAP[m.ExprType.Id]
 	}
-	p.cases(m, m.ExprType)
+	values := p.cases(m, m.ExprType)
 	if m.Default != nil {
 		p.parseCase(m.Default, m.ExprType)
 	}
+	p.check_match(m, values)
 }
 
 func find_label(id string, b *models.Block) *models.Label {
@@ -3233,28 +3424,38 @@ func (p *Parser) checkRets(f *Func) {
 }
 
 func (p *Parser) check_fn(f *Func) {
+	poisoned_n := p.poisoned_n
 	if f.Block == nil || f.Block.Tree == nil {
 		goto always
 	} else {
 		rootBlock := p.rootBlock
 		nodeBlock := p.nodeBlock
+		allowed := p.allowed
 		p.rootBlock = nil
 		p.nodeBlock = nil
+		p.allowed = append(p.allowed[:len(p.allowed):len(p.allowed)], f.Allows...)
 		f.Block.Func = f
 		p.checkNewBlock(f.Block)
 		p.rootBlock = rootBlock
 		p.nodeBlock = nodeBlock
+		p.allowed = allowed
 	}
 always:
-	p.checkRets(f)
+	// Returns are unknown if block has poisoned statements.
+	if p.poisoned_n == poisoned_n {
+		p.checkRets(f)
+	}
 }
 
 func (p *Parser) var_st(v *Var, noParse bool) {
-	def, _, canshadow := p.block_define_by_id(v.Id)
+	def, tok, canshadow := p.block_define_by_id(v.Id)
 	if !canshadow && def != nil {
-		p.pusherrtok(v.Token, "exist_id", v.Id)
+		p.pusherrexist(v.Token, tok, "exist_id", v.Id)
 		return
 	}
+	if _, ok := def.(*Var); ok && !juleapi.IsIgnoreId(v.Id) {
+		p.pushwarnexist(v.Token, tok, julelint.SHADOW, "shadowed_var", v.Id)
+	}
 	if !noParse {
 		*v = *p.Var(*v)
 	}
@@ -3318,7 +3519,10 @@ func (p *Parser) singleAssign(assign *models.Assign, l, r []value) {
 	}.check()
 }
 
-func (p *Parser) assignExprs(vsAST *models.Assign) (l []value, r []value) {
+// assignExprs returns values of left and right expressions.
+// ok is false if any left expression has errors.
+func (p *Parser) assignExprs(vsAST *models.Assign) (l []value, r []value, ok bool) {
+	ok = true
 	l = make([]value, len(vsAST.Left))
 	r = make([]value, len(vsAST.Right))
 	n := len(l)
@@ -3335,6 +3539,7 @@ func (p *Parser) assignExprs(vsAST *models.Assign) (l []value, r []value) {
 				left.Expr.Model = model
 				l[i] = v
 				r_type = &v.data.Type
+				ok = ok && !p.eval.has_error
 			} else {
 				l[i].data.Value = juleapi.IGNORE
 			}
@@ -3439,7 +3644,12 @@ func (p *Parser) postfix(assign *models.Assign, l, r []value) {
 func (p *Parser) assign(assign *models.Assign) {
 	ln := len(assign.Left)
 	rn := len(assign.Right)
-	l, r := p.assignExprs(assign)
+	l, r, ok := p.assignExprs(assign)
+	if !ok {
+		// Errors of left expressions are reported,
+		// assignment is not checked to avoid cascaded errors.
+		return
+	}
 	switch {
 	case rn == 0 && ast.IsPostfixOp(assign.Setter.Kind):
 		p.postfix(assign, l, r)
@@ -3510,6 +3720,7 @@ func (p *Parser) iter(iter *models.Iter) {
 	oldIter := p.currentIter
 	p.currentCase = nil
 	p.currentIter = iter
+	p.check_empty_block(iter.Block, iter.Token)
 	switch iter.Profile.(type) {
 	case models.IterWhile:
 		p.whileProfile(iter)
@@ -3528,6 +3739,7 @@ func (p *Parser) conditional_node(node *models.If) {
 	if !p.eval.has_error && val.data.Value != "" && !isBoolExpr(val) {
 		p.pusherrtok(node.Token, "if_require_bool_expr")
 	}
+	p.check_empty_block(node.Block, node.Token)
 	p.checkNewBlock(node.Block)
 }
 
@@ -3537,6 +3749,7 @@ func (p *Parser) conditional(model *models.Conditional) {
 		p.conditional_node(elif)
 	}
 	if model.Default != nil {
+		p.check_empty_block(model.Default.Block, model.Default.Token)
 		p.checkNewBlock(model.Default.Block)
 	}
 }
@@ -3951,6 +4164,19 @@ func (p *Parser) typeSource(dt Type, err bool) (ret Type, ok bool) {
 		case *trait:
 			def.Used = true
 			return p.typeSourceIsTrait(def, dt.Tag, dt.Token)
+		case nil:
+			if !err {
+				return dt, false
+			}
+			switch {
+			case dt.CppLinked:
+				p.pusherrsuggest(dt.Token, id, p.linked_ids(), "invalid_type_source")
+			case strings.Contains(id, lex.KND_DBLCOLON):
+				p.pusherrtok(dt.Token, "invalid_type_source")
+			default:
+				p.pusherrsuggest(dt.Token, id, p.scope_ids(), "invalid_type_source")
+			}
+			return dt, false
 		default:
 			if err {
 				p.pusherrtok(dt.Token, "invalid_type_source")
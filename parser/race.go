@@ -0,0 +1,532 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/julelint"
+)
+
+// Concurrent calls are evaluated in detached threads that captures
+// the frame of function by reference. So locals and references of locals
+// may outlive the frame, and variables written in concurrent calls
+// are racy if they are written after the call without synchronization.
+//
+// Synchronization is known by the wait calls of std::sync::WaitGroup,
+// and writes with functions of std::sync::atomic are not racy.
+
+const wait_group_id = "WaitGroup"
+const wait_fn_id    = "wait"
+
+// race_var is the variable of race analysis.
+type race_var struct {
+	id      string
+	mutable bool
+	global  bool
+	t       Type
+}
+
+// race_event is the position of statement in function.
+type race_event struct {
+	token lex.Token
+	loops []lex.Token // Tokens of enclosing iterations.
+}
+
+// in_loop reports event is in iteration of token.
+func (e race_event) in_loop(loop lex.Token) bool {
+	for _, l := range e.loops {
+		if l.Row == loop.Row && l.Column == loop.Column {
+			return true
+		}
+	}
+	return false
+}
+
+type race_access struct {
+	token lex.Token
+	v     *race_var
+}
+
+type race_write struct {
+	race_event
+	v *race_var
+}
+
+type race_co struct {
+	race_event
+	locals []race_access // Captured locals.
+	refs   []race_access // References to variables.
+	writes []race_access // Variables written in call.
+}
+
+type race_checker struct {
+	p       *Parser
+	scopes  []map[string]*race_var
+	globals map[*Var]*race_var
+	loops   []lex.Token
+	writes  []race_write
+	cos     []*race_co
+	waits   []race_event
+}
+
+func (r *race_checker) event(tok lex.Token) race_event {
+	return race_event{
+		token: tok,
+		loops: append([]lex.Token(nil), r.loops...),
+	}
+}
+
+func (r *race_checker) push_scope() { r.scopes = append(r.scopes, map[string]*race_var{}) }
+func (r *race_checker) pop_scope()  { r.scopes = r.scopes[:len(r.scopes)-1] }
+
+func (r *race_checker) declare(id string, mutable bool, t Type) {
+	r.scopes[len(r.scopes)-1][id] = &race_var{id: id, mutable: mutable, t: t}
+}
+
+func (r *race_checker) package_files() []*Parser {
+	if r.p.package_files == nil {
+		return []*Parser{r.p}
+	}
+	return *r.p.package_files
+}
+
+func (r *race_checker) global(id string) *race_var {
+	for _, fp := range r.package_files() {
+		g, dm, _ := fp.Defines.global_by_id(id, fp.File)
+		if g == nil || dm != fp.Defines {
+			continue
+		}
+		v, ok := r.globals[g]
+		if !ok {
+			v = &race_var{id: g.Id, mutable: g.Mutable, global: true, t: g.Type}
+			r.globals[g] = v
+		}
+		return v
+	}
+	return nil
+}
+
+func (r *race_checker) resolve(id string) *race_var {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if v, ok := r.scopes[i][id]; ok {
+			return v
+		}
+	}
+	return r.global(id)
+}
+
+// fn returns function of package or use declarations by identifier.
+//
+// Special case is;
+//
+//	fn(id) -> returns false for local if function is not defined by package.
+func (r *race_checker) fn(id string) (f *Fn, local bool) {
+	for _, fp := range r.package_files() {
+		f, dm, _ := fp.Defines.fn_by_id(id, fp.File)
+		if f != nil {
+			return f, dm == fp.Defines
+		}
+	}
+	return nil, false
+}
+
+func is_std_package(env *jule.Env, f *File, path ...string) bool {
+	return f != nil &&
+		filepath.Clean(f.Dir) == filepath.Join(append([]string{env.StdlibPath}, path...)...)
+}
+
+// is_wait_group reports type is std::sync::WaitGroup,
+// or pointer or reference of it.
+func is_wait_group(env *jule.Env, t Type) bool {
+	s, ok := t.Tag.(*structure)
+	return ok && s.Ast.Id == wait_group_id && is_std_package(env, s.Ast.Token.File, "sync")
+}
+
+// is_atomic_call reports expression is call of std::sync::atomic function.
+func (r *race_checker) is_atomic_call(toks []lex.Token) bool {
+	i := 0
+	var path []string
+	for ; i+1 < len(toks) && toks[i].Id == lex.ID_IDENT && toks[i+1].Id == lex.ID_DBLCOLON; i += 2 {
+		path = append(path, toks[i].Kind)
+	}
+	if len(path) > 0 {
+		return strings.Join(path, lex.KND_DBLCOLON) == "std::sync::atomic"
+	}
+	if toks[0].Id != lex.ID_IDENT || r.resolve(toks[0].Kind) != nil {
+		return false
+	}
+	f, _ := r.fn(toks[0].Kind)
+	return f != nil && is_std_package(r.p.session.Env, f.Ast.Token.File, "sync", "atomic")
+}
+
+// scan_waits appends wait calls of WaitGroups of expression tokens.
+func (r *race_checker) scan_waits(toks []lex.Token) {
+	for i := 0; i+3 < len(toks); i++ {
+		tok := toks[i]
+		switch {
+		case tok.Id == lex.ID_FN:
+			i = skip_anon_fn(toks, i)
+			continue
+		case tok.Id != lex.ID_IDENT,
+			i > 0 && toks[i-1].Id == lex.ID_DOT,
+			toks[i+1].Id != lex.ID_DOT,
+			toks[i+2].Kind != wait_fn_id,
+			toks[i+3].Kind != lex.KND_LPAREN:
+			continue
+		}
+		v := r.resolve(tok.Kind)
+		if v != nil && is_wait_group(r.p.session.Env, v.t) {
+			r.waits = append(r.waits, r.event(tok))
+		}
+	}
+}
+
+// closure_accesses returns captured locals and assigned globals of
+// anonymous function tokens. Anonymous functions copies locals when
+// they are evaluated in thread, so writes of locals are not shared
+// but locals are read from the frame.
+func (r *race_checker) closure_accesses(toks []lex.Token) (captured, writes []race_access) {
+	locals := map[string]bool{}
+	for i, tok := range toks {
+		if tok.Id != lex.ID_IDENT || (i > 0 && toks[i-1].Id == lex.ID_DOT) {
+			continue
+		}
+		if i+1 < len(toks) && toks[i+1].Id == lex.ID_COLON {
+			// Parameter or variable declaration with type.
+			locals[tok.Kind] = true
+			continue
+		}
+		if i > 0 && (toks[i-1].Id == lex.ID_LET || toks[i-1].Id == lex.ID_MUT) {
+			locals[tok.Kind] = true
+			continue
+		}
+		if locals[tok.Kind] {
+			continue
+		}
+		v := r.resolve(tok.Kind)
+		switch {
+		case v == nil:
+		case !v.global:
+			captured = append(captured, race_access{tok, v})
+		case i+1 < len(toks) && ast.IsAssignOp(toks[i+1].Kind):
+			writes = append(writes, race_access{tok, v})
+		}
+	}
+	return captured, writes
+}
+
+// fn_writes returns assigned globals of function of package.
+func (r *race_checker) fn_writes(f *Fn, tok lex.Token) []race_access {
+	if f.Ast.Block == nil {
+		return nil
+	}
+	locals := map[string]bool{}
+	for _, param := range f.Ast.Params {
+		locals[param.Id] = true
+	}
+	walk_block(f.Ast.Block, func(s *models.Statement) {
+		if v, ok := s.Data.(Var); ok {
+			locals[v.Id] = true
+		}
+	})
+	var writes []race_access
+	walk_block(f.Ast.Block, func(s *models.Statement) {
+		a, ok := s.Data.(models.Assign)
+		if !ok {
+			return
+		}
+		for _, l := range a.Left {
+			toks := l.Expr.Tokens
+			if len(toks) != 1 || toks[0].Id != lex.ID_IDENT || locals[toks[0].Kind] {
+				continue
+			}
+			if v := r.global(toks[0].Kind); v != nil {
+				writes = append(writes, race_access{tok, v})
+			}
+		}
+	})
+	return writes
+}
+
+// ref_args returns local arguments of reference parameters of function.
+// Arguments are just checked if they are single identifiers.
+func (r *race_checker) ref_args(f *Fn, toks []lex.Token) []race_access {
+	var refs []race_access
+	brace_n := 0
+	arg := 0
+	start := 2
+	for i := start; i < len(toks); i++ {
+		tok := toks[i]
+		if tok.Id == lex.ID_BRACE {
+			switch tok.Kind {
+			case lex.KND_LPAREN, lex.KND_LBRACKET, lex.KND_LBRACE:
+				brace_n++
+			default:
+				brace_n--
+			}
+		}
+		end := brace_n < 0 || (brace_n == 0 && tok.Id == lex.ID_COMMA)
+		if !end {
+			continue
+		}
+		if i-start == 1 && toks[start].Id == lex.ID_IDENT &&
+			arg < len(f.Ast.Params) && type_is_ref(f.Ast.Params[arg].Type) {
+			if v := r.resolve(toks[start].Kind); v != nil && !v.global {
+				refs = append(refs, race_access{toks[start], v})
+			}
+		}
+		if brace_n < 0 {
+			break
+		}
+		arg++
+		start = i + 1
+	}
+	return refs
+}
+
+func (r *race_checker) co(cc models.ConcurrentCall) {
+	toks := cc.Expr.Tokens
+	r.scan_waits(toks)
+	if len(toks) == 0 {
+		return
+	}
+	co := &race_co{race_event: r.event(cc.Token)}
+	atomic := r.is_atomic_call(toks)
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		switch {
+		case tok.Id == lex.ID_FN:
+			end := skip_anon_fn(toks, i)
+			captured, writes := r.closure_accesses(toks[i:end])
+			co.locals = append(co.locals, captured...)
+			co.writes = append(co.writes, writes...)
+			i = end
+		case tok.Id == lex.ID_OP && tok.Kind == lex.KND_AMPER && is_unary(toks, i) &&
+			i+1 < len(toks) && toks[i+1].Id == lex.ID_IDENT:
+			i++
+			v := r.resolve(toks[i].Kind)
+			if v == nil {
+				break
+			}
+			co.refs = append(co.refs, race_access{tok, v})
+			if !atomic {
+				// Callee may write to variable by reference.
+				co.writes = append(co.writes, race_access{toks[i], v})
+			}
+		case tok.Id == lex.ID_IDENT && (i == 0 || toks[i-1].Id != lex.ID_DOT) &&
+			(i+1 == len(toks) || toks[i+1].Id != lex.ID_COLON):
+			if v := r.resolve(tok.Kind); v != nil && !v.global {
+				co.locals = append(co.locals, race_access{tok, v})
+			}
+		}
+	}
+	if len(toks) > 1 && toks[0].Id == lex.ID_IDENT && toks[1].Kind == lex.KND_LPAREN &&
+		r.resolve(toks[0].Kind) == nil {
+		if f, local := r.fn(toks[0].Kind); f != nil {
+			co.refs = append(co.refs, r.ref_args(f, toks)...)
+			if local {
+				co.writes = append(co.writes, r.fn_writes(f, toks[0])...)
+			}
+		}
+	}
+	r.cos = append(r.cos, co)
+}
+
+func (r *race_checker) assign(a models.Assign) {
+	for _, l := range a.Left {
+		r.scan_waits(l.Expr.Tokens)
+	}
+	for _, expr := range a.Right {
+		r.scan_waits(expr.Tokens)
+	}
+	for _, l := range a.Left {
+		if l.Ignore {
+			continue
+		}
+		if l.Var.New {
+			r.declare(l.Var.Id, l.Var.Mutable, l.Var.Type)
+			continue
+		}
+		toks := l.Expr.Tokens
+		if len(toks) != 1 || toks[0].Id != lex.ID_IDENT {
+			continue
+		}
+		if v := r.resolve(toks[0].Kind); v != nil {
+			r.writes = append(r.writes, race_write{r.event(toks[0]), v})
+		}
+	}
+}
+
+func (r *race_checker) iter(it models.Iter) {
+	r.loops = append(r.loops, it.Token)
+	r.push_scope()
+	switch t := it.Profile.(type) {
+	case models.IterWhile:
+		r.scan_waits(t.Expr.Tokens)
+		if t.Next.Data != nil {
+			r.st(&t.Next)
+		}
+	case models.IterForeach:
+		r.scan_waits(t.Expr.Tokens)
+		r.declare(t.KeyA.Id, t.KeyA.Mutable, t.KeyA.Type)
+		r.declare(t.KeyB.Id, t.KeyB.Mutable, t.KeyB.Type)
+	}
+	r.block(it.Block)
+	r.pop_scope()
+	r.loops = r.loops[:len(r.loops)-1]
+}
+
+func (r *race_checker) block(b *models.Block) {
+	if b == nil {
+		return
+	}
+	r.push_scope()
+	for i := range b.Tree {
+		r.st(&b.Tree[i])
+	}
+	r.pop_scope()
+}
+
+func (r *race_checker) st(s *models.Statement) {
+	if s.Poisoned {
+		return
+	}
+	switch t := s.Data.(type) {
+	case Var:
+		r.scan_waits(t.Expr.Tokens)
+		r.declare(t.Id, t.Mutable, t.Type)
+	case models.Assign:
+		r.assign(t)
+	case models.ConcurrentCall:
+		r.co(t)
+	case models.Conditional:
+		r.scan_waits(t.If.Expr.Tokens)
+		r.block(t.If.Block)
+		for _, elif := range t.Elifs {
+			r.scan_waits(elif.Expr.Tokens)
+			r.block(elif.Block)
+		}
+		if t.Default != nil {
+			r.block(t.Default.Block)
+		}
+	case *models.Match:
+		r.scan_waits(t.Expr.Tokens)
+		for _, c := range t.Cases {
+			r.block(c.Block)
+		}
+		if t.Default != nil {
+			r.block(t.Default.Block)
+		}
+	case models.Iter:
+		r.iter(t)
+	case *models.Block:
+		r.block(t)
+	default:
+		for _, expr := range st_exprs(s) {
+			r.scan_waits(expr.Tokens)
+		}
+	}
+}
+
+// waited reports there is a wait call after co, and before tok
+// if tok is not zero token.
+func (r *race_checker) waited(co *race_co, tok lex.Token) bool {
+	for _, w := range r.waits {
+		if is_after(w.token, co.token) && (tok.File == nil || is_after(tok, w.token)) {
+			return true
+		}
+	}
+	return false
+}
+
+// waited_in_loop reports there is a wait call after co,
+// in the innermost iteration of co.
+func (r *race_checker) waited_in_loop(co *race_co) bool {
+	loop := co.loops[len(co.loops)-1]
+	for _, w := range r.waits {
+		if is_after(w.token, co.token) && w.in_loop(loop) {
+			return true
+		}
+	}
+	return false
+}
+
+// is_racy reports write is racy with co.
+// Writes after co are racy until a wait call, and writes before co
+// are racy if they are in the same iteration.
+func (r *race_checker) is_racy(co *race_co, w race_write) bool {
+	if is_after(w.token, co.token) {
+		return !r.waited(co, w.token)
+	}
+	return len(co.loops) > 0 && w.in_loop(co.loops[len(co.loops)-1]) && !r.waited_in_loop(co)
+}
+
+// check_refs checks references and captured locals of co.
+// Each variable is reported once.
+func (r *race_checker) check_refs(co *race_co) {
+	if r.waited(co, lex.Token{}) {
+		return
+	}
+	checked := map[*race_var]bool{}
+	report := func(accesses []race_access, key string) {
+		for _, a := range accesses {
+			if a.v.global || checked[a.v] {
+				continue
+			}
+			checked[a.v] = true
+			log := r.p.errtok(a.token, key, a.v.id)
+			log.Notes = append(log.Notes, r.p.session.Env.GetError("co_wait_note"))
+			r.p.pusherrs(log)
+		}
+	}
+	report(co.refs, "co_ref_local")
+	report(co.locals, "co_captured_local")
+}
+
+func (r *race_checker) check_writes(co *race_co) {
+	checked := map[*race_var]bool{}
+	for _, in := range co.writes {
+		if checked[in.v] || !in.v.mutable {
+			continue
+		}
+		checked[in.v] = true
+		racy := false
+		for _, w := range r.writes {
+			if w.v != in.v || !r.is_racy(co, w) {
+				continue
+			}
+			log := r.p.warntok(w.token, julelint.DATA_RACE, "data_race", in.v.id)
+			log.Labels = append(log.Labels, r.p.labeltok(in.token, "co_written_here"))
+			log.Notes = append(log.Notes, r.p.session.Env.GetError("data_race_note"))
+			r.p.pushwarn(log)
+			racy = true
+			break
+		}
+		if !racy && len(co.loops) > 0 && !r.waited_in_loop(co) {
+			log := r.p.warntok(in.token, julelint.DATA_RACE, "data_race_iter", in.v.id)
+			log.Notes = append(log.Notes, r.p.session.Env.GetError("data_race_note"))
+			r.p.pushwarn(log)
+		}
+	}
+}
+
+// check_races checks concurrent calls of function.
+func (p *Parser) check_races(f *Func) {
+	if f.Block == nil {
+		return
+	}
+	r := &race_checker{p: p, globals: map[*Var]*race_var{}}
+	r.push_scope()
+	for _, v := range p.block_variables_of_fn(f) {
+		r.declare(v.Id, v.Mutable, v.Type)
+	}
+	r.block(f.Block)
+	for _, co := range r.cos {
+		r.check_refs(co)
+		r.check_writes(co)
+	}
+}
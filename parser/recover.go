@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/lex"
+)
+
+// poison_id marks identifier as a broken definition.
+// Errors of unresolved poisoned identifiers are suppressed
+// because they are cascaded from syntax errors.
+func (p *Parser) poison_id(id string) {
+	if p.poisoned_ids == nil {
+		p.poisoned_ids = map[string]bool{}
+	}
+	p.poisoned_ids[id] = true
+}
+
+// poison_use marks identifiers of failed use declaration
+// as broken definitions.
+func (p *Parser) poison_use(ast *models.UseDecl) {
+	for _, id := range strings.Split(ast.LinkString, lex.KND_DBLCOLON) {
+		p.poison_id(id)
+	}
+	for _, selector := range ast.Selectors {
+		p.poison_id(selector.Kind)
+	}
+}
+
+// poison_st marks definitions of poisoned node as broken definitions.
+// Poisoned nodes have syntax errors, so they are not checked.
+func (p *Parser) poison_st(data any) {
+	switch t := data.(type) {
+	case models.Statement:
+		p.poison_st(t.Data)
+	case Var:
+		p.poison_id(t.Id)
+	case Func:
+		p.poison_id(t.Id)
+	case TypeAlias:
+		p.poison_id(t.Id)
+	case Enum:
+		p.poison_id(t.Id)
+	case Struct:
+		p.poison_id(t.Id)
+	case models.Trait:
+		p.poison_id(t.Id)
+	case models.Assign:
+		for _, l := range t.Left {
+			if l.Var.New {
+				p.poison_id(l.Var.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/juleio"
+	"github.com/julelang/jule/pkg/julelint"
+	"github.com/julelang/jule/pkg/julelog"
+)
+
+// Session is a compilation session.
+// Owns all state of compilation, parsers of session are shares
+// environment, file system, lint configuration, cpp writer,
+// built-in definitions and imported packages.
+// Sessions are not shares any state, so concurrent sessions are safe.
+type Session struct {
+	Env *jule.Env
+	// File system of sources.
+	// Entry file, files of package and used packages are loaded from.
+	FS     juleio.FS
+	Lints  *julelint.Config
+	Writer *models.Writer
+	// Maximum count of reported errors, zero for no limit.
+	ErrorLimit int
+
+	builtin *builtins
+	// Imported packages.
+	used []*use
+}
+
+// NewSession returns new session for environment
+// with OS file system, default lint configuration and writer.
+func NewSession(env *jule.Env) *Session {
+	return &Session{
+		Env:     env,
+		FS:      juleio.OS,
+		Lints:   julelint.NewConfig(),
+		Writer:  models.NewWriter(),
+		builtin: new_builtins(),
+	}
+}
+
+// LimitErrors returns errors limited by error limit of session.
+// Appends an error about count of not shown errors if limit exceeded.
+func (s *Session) LimitErrors(errors []julelog.CompilerLog) []julelog.CompilerLog {
+	if s.ErrorLimit <= 0 || len(errors) <= s.ErrorLimit {
+		return errors
+	}
+	n := len(errors) - s.ErrorLimit
+	return append(errors[:s.ErrorLimit:s.ErrorLimit], julelog.CompilerLog{
+		Type:    julelog.FLAT_ERR,
+		Message: s.Env.GetError("error_limit_exceeded", n),
+		Key:     "error_limit_exceeded",
+		Args:    []any{n},
+	})
+}
@@ -487,14 +487,14 @@ func (s *solver) floatMod() (v value, ok bool) {
 	switch {
 	case juletype.IsSignedInteger(s.l.data.Type.Id):
 		switch {
-		case int_assignable(juletype.I64, s.r):
+		case int_assignable(juletype.I64, s.r, s.p.session.Env.BitSize()):
 			return s.signed(), true
-		case int_assignable(juletype.U64, s.r):
+		case int_assignable(juletype.U64, s.r, s.p.session.Env.BitSize()):
 			return s.unsigned(), true
 		}
 	case juletype.IsUnsignedInteger(s.l.data.Type.Id):
-		if int_assignable(juletype.I64, s.r) ||
-			int_assignable(juletype.U64, s.r) {
+		if int_assignable(juletype.I64, s.r, s.p.session.Env.BitSize()) ||
+			int_assignable(juletype.U64, s.r, s.p.session.Env.BitSize()) {
 			return s.unsigned(), true
 		}
 	}
@@ -537,19 +537,19 @@ func (s *solver) float() (v value) {
 		s.lteq(&v)
 	case lex.KND_PLUS:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.add(&v)
 	case lex.KND_MINUS:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.sub(&v)
 	case lex.KND_STAR:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.mul(&v)
@@ -562,7 +562,7 @@ func (s *solver) float() (v value) {
 			s.l.data.Type = s.r.data.Type
 		}
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.div(&v)
@@ -616,49 +616,49 @@ func (s *solver) signed() (v value) {
 		s.lteq(&v)
 	case lex.KND_PLUS:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.add(&v)
 	case lex.KND_MINUS:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.sub(&v)
 	case lex.KND_STAR:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.mul(&v)
 	case lex.KND_SOLIDUS:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.div(&v)
 	case lex.KND_PERCENT:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.mod(&v)
 	case lex.KND_AMPER:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.bitwiseAnd(&v)
 	case lex.KND_VLINE:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.bitwiseOr(&v)
 	case lex.KND_CARET:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.bitwiseXor(&v)
@@ -719,49 +719,49 @@ func (s *solver) unsigned() (v value) {
 		s.lteq(&v)
 	case lex.KND_PLUS:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.add(&v)
 	case lex.KND_MINUS:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.sub(&v)
 	case lex.KND_STAR:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.mul(&v)
 	case lex.KND_SOLIDUS:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.div(&v)
 	case lex.KND_PERCENT:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.mod(&v)
 	case lex.KND_AMPER:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.bitwiseAnd(&v)
 	case lex.KND_VLINE:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.bitwiseOr(&v)
 	case lex.KND_CARET:
 		v.data.Type = s.l.data.Type
-		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id) {
+		if juletype.TypeGreaterThan(s.r.data.Type.Id, v.data.Type.Id, s.p.session.Env.BitSize()) {
 			v.data.Type = s.r.data.Type
 		}
 		s.bitwiseXor(&v)
@@ -83,28 +83,28 @@ func (s *structure) OutId() string {
 // for CompiledStruct interface of ast package.
 func (s *structure) CppLinked() bool { return s.cpp_linked }
 
-func (s *structure) operators() string {
+func (s *structure) operators(w *models.Writer) string {
 	outid := s.OutId()
 	genericsDef, genericsSerie := s.cppGenerics()
 	var cpp strings.Builder
-	cpp.WriteString(models.IndentString())
+	cpp.WriteString(w.IndentString())
 	if l, _ := cpp.WriteString(genericsDef); l > 0 {
-		cpp.WriteString(models.IndentString())
+		cpp.WriteString(w.IndentString())
 	}
 	cpp.WriteString("inline bool operator==(const ")
 	cpp.WriteString(outid)
 	cpp.WriteString(genericsSerie)
 	cpp.WriteString(" &_Src) {")
 	if len(s.Defines.Globals) > 0 {
-		models.AddIndent()
+		w.AddIndent()
 		cpp.WriteByte('\n')
-		cpp.WriteString(models.IndentString())
+		cpp.WriteString(w.IndentString())
 		var expr strings.Builder
 		expr.WriteString("return ")
-		models.AddIndent()
+		w.AddIndent()
 		for _, g := range s.Defines.Globals {
 			expr.WriteByte('\n')
-			expr.WriteString(models.IndentString())
+			expr.WriteString(w.IndentString())
 			expr.WriteString("this->")
 			gid := g.OutId()
 			expr.WriteString(gid)
@@ -112,19 +112,19 @@ func (s *structure) operators() string {
 			expr.WriteString(gid)
 			expr.WriteString(" &&")
 		}
-		models.DoneIndent()
+		w.DoneIndent()
 		cpp.WriteString(expr.String()[:expr.Len()-3])
 		cpp.WriteString(";\n")
-		models.DoneIndent()
-		cpp.WriteString(models.IndentString())
+		w.DoneIndent()
+		cpp.WriteString(w.IndentString())
 		cpp.WriteByte('}')
 	} else {
 		cpp.WriteString(" return true; }")
 	}
 	cpp.WriteString("\n\n")
-	cpp.WriteString(models.IndentString())
+	cpp.WriteString(w.IndentString())
 	if l, _ := cpp.WriteString(genericsDef); l > 0 {
-		cpp.WriteString(models.IndentString())
+		cpp.WriteString(w.IndentString())
 	}
 	cpp.WriteString("inline bool operator!=(const ")
 	cpp.WriteString(outid)
@@ -141,20 +141,20 @@ func (s *structure) self_var_init_statement_str() string {
 	return cpp.String()
 }
 
-func (s *structure) cppConstructor() string {
+func (s *structure) cppConstructor(w *models.Writer) string {
 	var cpp strings.Builder
-	cpp.WriteString(models.IndentString())
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString(s.OutId())
 	cpp.WriteString(paramsToCpp(s.constructor.Params))
 	cpp.WriteString(" noexcept {\n")
-	models.AddIndent()
-	cpp.WriteString(models.IndentString())
+	w.AddIndent()
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString(s.self_var_init_statement_str())
 	cpp.WriteByte('\n')
 	if len(s.Defines.Globals) > 0 {
 		for i, g := range s.Defines.Globals {
 			cpp.WriteByte('\n')
-			cpp.WriteString(models.IndentString())
+			cpp.WriteString(w.IndentString())
 			cpp.WriteString("this->")
 			cpp.WriteString(g.OutId())
 			cpp.WriteString(" = ")
@@ -162,9 +162,9 @@ func (s *structure) cppConstructor() string {
 			cpp.WriteByte(';')
 		}
 	}
-	models.DoneIndent()
+	w.DoneIndent()
 	cpp.WriteByte('\n')
-	cpp.WriteString(models.IndentString())
+	cpp.WriteString(w.IndentString())
 	cpp.WriteByte('}')
 	return cpp.String()
 }
@@ -217,83 +217,90 @@ func (s *structure) self_ref_var_str() string {
 	return cpp.String()
 }
 
-func (s *structure) prototype() string {
+func (s *structure) prototype(w *models.Writer) string {
 	var cpp strings.Builder
 	cpp.WriteString(genericsToCpp(s.Ast.Generics))
 	cpp.WriteByte('\n')
+	cpp.WriteString(w.LineDirective(s.Ast.Token))
 	cpp.WriteString("struct ")
 	outid := s.OutId()
 	cpp.WriteString(outid)
 	cpp.WriteString(s.cppTraits())
 	cpp.WriteString(" {\n")
-	models.AddIndent()
-	cpp.WriteString(models.IndentString())
+	w.AddIndent()
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString(s.self_ref_var_str())
 	cpp.WriteString("\n\n")
 	if len(s.Defines.Globals) > 0 {
 		for _, g := range s.Defines.Globals {
-			cpp.WriteString(models.IndentString())
+			cpp.WriteString(w.IndentString())
+			cpp.WriteString(w.LineDirective(g.Token))
 			cpp.WriteString(g.FieldString())
 			cpp.WriteByte('\n')
 		}
 		cpp.WriteString("\n\n")
-		cpp.WriteString(models.IndentString())
-		cpp.WriteString(s.cppConstructor())
+	}
+	// Constructors, destructor and operators are not in source.
+	cpp.WriteString(w.IndentString())
+	cpp.WriteString(w.LineReset())
+	if len(s.Defines.Globals) > 0 {
+		cpp.WriteString(s.cppConstructor(w))
 		cpp.WriteString("\n\n")
+		cpp.WriteString(w.IndentString())
 	}
-	cpp.WriteString(models.IndentString())
 	cpp.WriteString(s.cpp_destructor())
 	cpp.WriteString("\n\n")
-	cpp.WriteString(models.IndentString())
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString(outid)
 	cpp.WriteString("(void) noexcept { ")
 	cpp.WriteString(s.self_var_init_statement_str())
 	cpp.WriteString(" }\n\n")
 	for _, f := range s.Defines.Funcs {
 		if f.used {
-			cpp.WriteString(models.IndentString())
-			cpp.WriteString(f.Prototype(""))
+			cpp.WriteString(w.IndentString())
+			cpp.WriteString(f.Prototype("", w))
 			cpp.WriteString("\n\n")
 		}
 	}
-	cpp.WriteString(s.operators())
+	cpp.WriteString(s.operators(w))
 	cpp.WriteByte('\n')
-	models.DoneIndent()
-	cpp.WriteString(models.IndentString())
+	w.DoneIndent()
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString("};")
 	return cpp.String()
 }
 
-func (s *structure) decldefString() string {
+func (s *structure) decldefString(w *models.Writer) string {
 	var cpp strings.Builder
 	for _, f := range s.Defines.Funcs {
 		if f.used {
-			cpp.WriteString(models.IndentString())
-			cpp.WriteString(f.stringOwner(s.OutId()))
+			cpp.WriteString(w.IndentString())
+			cpp.WriteString(f.stringOwner(s.OutId(), w))
 			cpp.WriteString("\n\n")
 		}
 	}
 	return cpp.String()
 }
 
-func (s *structure) ostream() string {
+func (s *structure) ostream(w *models.Writer) string {
 	var cpp strings.Builder
 	genericsDef, genericsSerie := s.cppGenerics()
-	cpp.WriteString(models.IndentString())
+	cpp.WriteString(w.IndentString())
+	cpp.WriteString(w.LineReset())
 	if l, _ := cpp.WriteString(genericsDef); l > 0 {
-		cpp.WriteString(models.IndentString())
+		cpp.WriteString(w.IndentString())
 	}
 	cpp.WriteString("std::ostream &operator<<(std::ostream &_Stream, const ")
 	cpp.WriteString(s.OutId())
 	cpp.WriteString(genericsSerie)
 	cpp.WriteString(" &_Src) {\n")
-	models.AddIndent()
-	cpp.WriteString(models.IndentString())
+	w.AddIndent()
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString(`_Stream << "`)
 	cpp.WriteString(s.Ast.Id)
 	cpp.WriteString("{\";\n")
 	for i, field := range s.Ast.Fields {
-		cpp.WriteString(models.IndentString())
+		cpp.WriteString(w.IndentString())
 		cpp.WriteString(`_Stream << "`)
 		cpp.WriteString(field.Id)
 		cpp.WriteString(`:" << _Src.`)
@@ -303,21 +310,21 @@ func (s *structure) ostream() string {
 		}
 		cpp.WriteString(";\n")
 	}
-	cpp.WriteString(models.IndentString())
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString("_Stream << \"}\";\n")
-	cpp.WriteString(models.IndentString())
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString("return _Stream;\n")
-	models.DoneIndent()
-	cpp.WriteString(models.IndentString())
+	w.DoneIndent()
+	cpp.WriteString(w.IndentString())
 	cpp.WriteString("}")
 	return cpp.String()
 }
 
-func (s structure) String() string {
+func (s structure) String(w *models.Writer) string {
 	var cpp strings.Builder
-	cpp.WriteString(s.decldefString())
+	cpp.WriteString(s.decldefString(w))
 	cpp.WriteString("\n\n")
-	cpp.WriteString(s.ostream())
+	cpp.WriteString(s.ostream(w))
 	return cpp.String()
 }
 
@@ -56,7 +56,11 @@ func (sap *structArgParser) pushArg() {
 	}
 	pair, ok := (*sap.fmap)[sap.arg.TargetId]
 	if !ok {
-		sap.p.pusherrtok(sap.arg.Token, "id_not_exist", sap.arg.TargetId)
+		ids := make([]string, 0, len(*sap.fmap))
+		for id := range *sap.fmap {
+			ids = append(ids, id)
+		}
+		sap.p.pusherrsuggest(sap.arg.Token, sap.arg.TargetId, ids, "id_not_exist", sap.arg.TargetId)
 		return
 	} else if pair.arg != nil {
 		sap.p.pusherrtok(sap.arg.Token, "already_has_expr", sap.arg.TargetId)
@@ -0,0 +1,212 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/pkg/juleapi"
+)
+
+// Maximum count of suggestions in a note.
+const max_suggestions = 3
+
+// edit_distance returns Levenshtein distance between a and b.
+func edit_distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min_int(min_int(prev[j]+1, current[j-1]+1), prev[j-1]+cost)
+		}
+		prev, current = current, prev
+	}
+	return prev[len(rb)]
+}
+
+func min_int(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// suggest returns closest candidates to id by edit distance.
+//
+// Special case is;
+//
+//	suggest(id, candidates) -> returns nil if there is no close candidate.
+func suggest(id string, candidates []string) []string {
+	max := len([]rune(id)) / 3
+	if max < 1 {
+		max = 1
+	}
+	best := max + 1
+	var matches []string
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		if c == id || seen[c] || juleapi.IsIgnoreId(c) {
+			continue
+		}
+		seen[c] = true
+		d := edit_distance(id, c)
+		if d > 1 && strings.EqualFold(id, c) {
+			d = 1
+		}
+		switch {
+		case d < best:
+			best = d
+			matches = append(matches[:0], c)
+		case d == best:
+			matches = append(matches, c)
+		}
+	}
+	sort.Strings(matches)
+	if len(matches) > max_suggestions {
+		matches = matches[:max_suggestions]
+	}
+	return matches
+}
+
+// suggestion_note returns note of suggestions for id.
+//
+// Special case is;
+//
+//	suggestion_note(id, candidates) -> returns empty string if there is no suggestion.
+func (p *Parser) suggestion_note(id string, candidates []string) string {
+	matches := suggest(id, candidates)
+	if len(matches) == 0 {
+		return ""
+	}
+	for i, m := range matches {
+		matches[i] = "`" + m + "`"
+	}
+	return p.session.Env.GetError("did_you_mean", strings.Join(matches, ", "))
+}
+
+// push_ids appends identifiers of accessible defines of dm, excluding side defines.
+func (dm *Defmap) push_ids(ids *[]string, f *File) {
+	for _, ns := range dm.Namespaces {
+		*ids = append(*ids, ns.Id)
+	}
+	for _, e := range dm.Enums {
+		if is_accessable(f, e.Token.File, e.Pub) {
+			*ids = append(*ids, e.Id)
+		}
+	}
+	for _, s := range dm.Structs {
+		if is_accessable(f, s.Ast.Token.File, s.Ast.Pub) {
+			*ids = append(*ids, s.Ast.Id)
+		}
+	}
+	for _, t := range dm.Traits {
+		if is_accessable(f, t.Ast.Token.File, t.Ast.Pub) {
+			*ids = append(*ids, t.Ast.Id)
+		}
+	}
+	for _, t := range dm.Types {
+		if is_accessable(f, t.Token.File, t.Pub) {
+			*ids = append(*ids, t.Id)
+		}
+	}
+	for _, fn := range dm.Funcs {
+		if is_accessable(f, fn.Ast.Token.File, fn.Ast.Pub) {
+			*ids = append(*ids, fn.Ast.Id)
+		}
+	}
+	for _, g := range dm.Globals {
+		if is_accessable(f, g.Token.File, g.Pub) {
+			*ids = append(*ids, g.Id)
+		}
+	}
+}
+
+// ids returns identifiers of accessible defines, includes side defines.
+func (dm *Defmap) ids(f *File) []string {
+	var ids []string
+	dm.push_ids(&ids, f)
+	if dm.side != nil {
+		dm.side.push_ids(&ids, f)
+	}
+	return ids
+}
+
+// scope_ids returns identifiers which is accessible from current scope.
+func (p *Parser) scope_ids() []string {
+	var ids []string
+	for _, v := range p.blockVars {
+		if v != nil {
+			ids = append(ids, v.Id)
+		}
+	}
+	for _, t := range p.blockTypes {
+		if t != nil {
+			ids = append(ids, t.Id)
+		}
+	}
+	for _, g := range p.generics {
+		ids = append(ids, g.Id)
+	}
+	if p.allowBuiltin {
+		p.session.builtin.defines.push_ids(&ids, nil)
+	}
+	ids = append(ids, p.Defines.ids(p.File)...)
+	if p.package_files != nil {
+		for _, fp := range *p.package_files {
+			if fp != p {
+				fp.Defines.push_ids(&ids, p.File)
+			}
+		}
+	}
+	return ids
+}
+
+// linked_ids returns identifiers of cpp links.
+func (p *Parser) linked_ids() []string {
+	var ids []string
+	for _, f := range p.linked_functions {
+		ids = append(ids, f.Id)
+	}
+	for _, v := range p.linked_variables {
+		ids = append(ids, v.Id)
+	}
+	for _, t := range p.linked_aliases {
+		ids = append(ids, t.Id)
+	}
+	for _, s := range p.linked_structs {
+		ids = append(ids, s.Ast.Id)
+	}
+	return ids
+}
+
+// pusherrsuggest appends new error by token with suggestions
+// of closest candidates to id as note.
+func (p *Parser) pusherrsuggest(tok lex.Token, id string, candidates []string, key string, args ...any) {
+	if p.poisoned_ids[id] {
+		return
+	}
+	log := p.errtok(tok, key, args...)
+	note := p.suggestion_note(id, candidates)
+	if note != "" {
+		log.Notes = append(log.Notes, note)
+	}
+	p.Errors = append(p.Errors, log)
+}
+
+// ns_ids returns identifiers of namespaces.
+func (p *Parser) ns_ids() []string {
+	ids := make([]string, len(p.Defines.Namespaces))
+	for i, ns := range p.Defines.Namespaces {
+		ids[i] = ns.Id
+	}
+	return ids
+}
@@ -39,14 +39,14 @@ func (t *trait) OutId() string {
 	return juleapi.OutId(t.Ast.Id, t.Ast.Token.File)
 }
 
-func (t *trait) String() string {
+func (t *trait) String(w *models.Writer) string {
 	var cpp strings.Builder
 	cpp.WriteString("struct ")
 	outid := t.OutId()
 	cpp.WriteString(outid)
 	cpp.WriteString(" {\n")
-	models.AddIndent()
-	is := models.IndentString()
+	w.AddIndent()
+	is := w.IndentString()
 	cpp.WriteString(is)
 	cpp.WriteString("virtual ~")
 	cpp.WriteString(outid)
@@ -64,7 +64,7 @@ func (t *trait) String() string {
 		}
 		cpp.WriteString("}\n")
 	}
-	models.DoneIndent()
+	w.DoneIndent()
 	cpp.WriteString("};")
 	return cpp.String()
 }
@@ -164,5 +164,5 @@ func (tc *type_checker) check() bool {
 		}
 		return tc.check_struct()
 	}
-	return juletype.TypesAreCompatible(tc.l.Id, tc.r.Id, tc.ignore_any)
+	return juletype.TypesAreCompatible(tc.l.Id, tc.r.Id, tc.ignore_any, tc.p.session.Env.BitSize())
 }
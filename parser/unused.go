@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/juleapi"
+	"github.com/julelang/jule/pkg/julelint"
+)
+
+// mark_use marks use declarations of definition as used.
+// Definitions of use declarations are found in side defines of file.
+func (p *Parser) mark_use(fp *Parser, dm *Defmap, def any) {
+	if fp != p || dm == nil || dm != p.Defines.side {
+		return
+	}
+	for _, decl := range p.use_decls {
+		decl.mark(def)
+	}
+}
+
+// mark_use_ns marks use declarations of namespace as used.
+// Namespaces may shared by package files, so declarations
+// of all package files are marked.
+func (p *Parser) mark_use_ns(ns *namespace) {
+	files := []*Parser{p}
+	if p.package_files != nil {
+		files = *p.package_files
+	}
+	for _, fp := range files {
+		for _, decl := range fp.use_decls {
+			decl.mark_ns(ns)
+		}
+	}
+}
+
+// check_package_usage checks usage of use declarations and
+// private definitions of all package files.
+// Usage is unknown if package has errors.
+func (p *Parser) check_package_usage() {
+	if len(p.Errors) > 0 {
+		return
+	}
+	for _, pf := range *p.package_files {
+		pf.check_uses()
+		pf.check_unused_defines()
+	}
+}
+
+func (p *Parser) check_uses() {
+	for _, decl := range p.use_decls {
+		if !decl.used {
+			p.pushwarntok(decl.token, julelint.UNUSED_USE, "unused_use", decl.link)
+			continue
+		}
+		for _, s := range decl.selectors {
+			if !s.used {
+				p.pushwarntok(s.token, julelint.UNUSED_USE, "unused_use_selector", s.token.Kind)
+			}
+		}
+	}
+}
+
+type unused_define struct {
+	token  lex.Token
+	id     string
+	allows []string
+}
+
+func (p *Parser) push_unused(defs *[]unused_define, def unused_define, pub, used bool) {
+	if used || pub || def.token.File != p.File || juleapi.IsIgnoreId(def.id) {
+		return
+	}
+	*defs = append(*defs, def)
+}
+
+// check_unused_defines checks private definitions of file
+// which is not used by package.
+func (p *Parser) check_unused_defines() {
+	var defs []unused_define
+	for _, f := range p.Defines.Funcs {
+		switch f.Ast.Id {
+		case jule.ENTRY_POINT, jule.INIT_FN:
+			continue
+		}
+		p.push_unused(&defs, unused_define{f.Ast.IdTok, f.Ast.Id, f.Ast.Allows}, f.Ast.Pub, f.used)
+	}
+	for _, s := range p.Defines.Structs {
+		p.push_unused(&defs, unused_define{s.Ast.Token, s.Ast.Id, nil}, s.Ast.Pub, s.Used)
+	}
+	for _, t := range p.Defines.Traits {
+		p.push_unused(&defs, unused_define{t.Ast.Token, t.Ast.Id, nil}, t.Ast.Pub, t.Used)
+	}
+	for _, e := range p.Defines.Enums {
+		p.push_unused(&defs, unused_define{e.Token, e.Id, nil}, e.Pub, e.Used)
+	}
+	for _, t := range p.Defines.Types {
+		p.push_unused(&defs, unused_define{t.Token, t.Id, nil}, t.Pub, t.Used)
+	}
+	for _, g := range p.Defines.Globals {
+		p.push_unused(&defs, unused_define{g.Token, g.Id, nil}, g.Pub, g.Used)
+	}
+	// Report in order of source file.
+	sort.SliceStable(defs, func(i, j int) bool {
+		if defs[i].token.Row != defs[j].token.Row {
+			return defs[i].token.Row < defs[j].token.Row
+		}
+		return defs[i].token.Column < defs[j].token.Column
+	})
+	allowed := p.allowed
+	for _, def := range defs {
+		p.allowed = def.allows
+		p.pushwarntok(def.token, julelint.UNUSED, "unused_define", def.id)
+	}
+	p.allowed = allowed
+}
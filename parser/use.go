@@ -12,3 +12,45 @@ type use struct {
 	LinkString string
 	Selectors  []lex.Token
 }
+
+// use_decl is use declaration of file for tracking of usage.
+type use_decl struct {
+	token     lex.Token
+	link      string
+	ns        *namespace // Nil if namespace is not imported.
+	full      *Defmap    // Defines of full use, nil if not full use.
+	selectors []*use_selector
+	used      bool
+}
+
+type use_selector struct {
+	token lex.Token
+	def   any // Nil for the self selector.
+	used  bool
+}
+
+// mark marks declaration as used if imports definition.
+func (decl *use_decl) mark(def any) {
+	for _, s := range decl.selectors {
+		if s.def == def {
+			s.used = true
+			decl.used = true
+		}
+	}
+	if decl.full != nil && decl.full.has(def) {
+		decl.used = true
+	}
+}
+
+// mark_ns marks declaration as used if imports namespace.
+func (decl *use_decl) mark_ns(ns *namespace) {
+	if decl.ns != ns {
+		return
+	}
+	decl.used = true
+	for _, s := range decl.selectors {
+		if s.def == nil {
+			s.used = true
+		}
+	}
+}
@@ -135,16 +135,18 @@ func (ve *valueEvaluator) nil() value {
 }
 
 func normalize(v *value) (normalized bool) {
+	// Types of normalization are not platform-dependent.
+	const bits = 64
 	switch {
 	case !v.constExpr:
 		return
-	case int_assignable(juletype.U64, *v):
+	case int_assignable(juletype.U64, *v, bits):
 		v.data.Type.Id = juletype.U64
 		v.data.Type.Kind = juletype.TYPE_MAP[v.data.Type.Id]
 		v.expr = tonumu(v.expr)
 		bitize(v)
 		return true
-	case int_assignable(juletype.I64, *v):
+	case int_assignable(juletype.I64, *v, bits):
 		v.data.Type.Id = juletype.I64
 		v.data.Type.Kind = juletype.TYPE_MAP[v.data.Type.Id]
 		v.expr = tonums(v.expr)
@@ -330,6 +332,6 @@ func (ve *valueEvaluator) id() (_ value, ok bool) {
 		return ve.typeId(id, t)
 	}
 
-	ve.p.eval.pusherrtok(ve.token, "id_not_exist", id)
+	ve.p.eval.pusherrsuggest(ve.token, id, ve.p.scope_ids(), "id_not_exist", id)
 	return
 }
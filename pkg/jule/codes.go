@@ -0,0 +1,183 @@
+package jule
+
+import "strings"
+
+// CODES is the stable public codes of error messages.
+// Codes should never be changed or reused,
+// new error messages take the next code.
+//
+// Just diagnostics have codes, labels and notes have not.
+var CODES = map[string]string{
+	`stdlib_not_exist`:                         `J0001`,
+	`file_not_useable`:                         `J0002`,
+	`file_not_jule`:                            `J0003`,
+	`no_entry_point`:                           `J0004`,
+	`exist_id`:                                 `J0005`,
+	`extra_closed_parentheses`:                 `J0006`,
+	`extra_closed_braces`:                      `J0007`,
+	`extra_closed_brackets`:                    `J0008`,
+	`wait_close_parentheses`:                   `J0009`,
+	`wait_close_brace`:                         `J0010`,
+	`wait_close_bracket`:                       `J0011`,
+	`expected_parentheses_close`:               `J0012`,
+	`expected_brace_close`:                     `J0013`,
+	`expected_bracket_close`:                   `J0014`,
+	`body_not_exist`:                           `J0015`,
+	`incompatible_types`:                       `J0017`,
+	`operator_not_for_juletype`:                `J0018`,
+	`operator_not_for_float`:                   `J0019`,
+	`operator_not_for_int`:                     `J0020`,
+	`operator_not_for_uint`:                    `J0021`,
+	`id_not_exist`:                             `J0022`,
+	`argument_overflow`:                        `J0024`,
+	`fn_have_ret`:                              `J0025`,
+	`fn_have_parameters`:                       `J0026`,
+	`fn_is_unsafe`:                             `J0027`,
+	`require_return_value`:                     `J0028`,
+	`void_function_return_value`:               `J0029`,
+	`bitshift_must_unsigned`:                   `J0030`,
+	`logical_not_bool`:                         `J0031`,
+	`assign_const`:                             `J0032`,
+	`assign_require_lvalue`:                    `J0033`,
+	`assign_type_not_support_value`:            `J0034`,
+	`invalid_token`:                            `J0035`,
+	`invalid_syntax`:                           `J0036`,
+	`invalid_type`:                             `J0037`,
+	`invalid_expr_unary_operator`:              `J0040`,
+	`invalid_escape_sequence`:                  `J0041`,
+	`invalid_type_source`:                      `J0042`,
+	`invalid_type_for_const`:                   `J0045`,
+	`invalid_value_for_key`:                    `J0046`,
+	`invalid_expr`:                             `J0047`,
+	`invalid_header_ext`:                       `J0048`,
+	`invalid_label`:                            `J0049`,
+	`missing_autotype_value`:                   `J0050`,
+	`missing_type`:                             `J0051`,
+	`missing_expr`:                             `J0052`,
+	`missing_block_comment`:                    `J0053`,
+	`missing_rune_end`:                         `J0054`,
+	`missing_ret`:                              `J0055`,
+	`missing_string_end`:                       `J0056`,
+	`missing_multi_return`:                     `J0057`,
+	`missing_multi_assign_identifiers`:         `J0058`,
+	`missing_use_path`:                         `J0059`,
+	`missing_goto_label`:                       `J0061`,
+	`missing_expr_for`:                         `J0062`,
+	`missing_generics`:                         `J0063`,
+	`missing_receiver`:                         `J0064`,
+	`missing_function_parentheses`:             `J0065`,
+	`expr_not_const`:                           `J0066`,
+	`nil_for_autotype`:                         `J0067`,
+	`void_for_autotype`:                        `J0068`,
+	`rune_empty`:                               `J0069`,
+	`rune_overflow`:                            `J0070`,
+	`not_supports_indexing`:                    `J0071`,
+	`not_supports_slicing`:                     `J0072`,
+	`already_const`:                            `J0073`,
+	`already_variadic`:                         `J0074`,
+	`already_uses`:                             `J0076`,
+	`ignore_id`:                                `J0077`,
+	`overflow_multi_assign_identifiers`:        `J0078`,
+	`overflow_return`:                          `J0079`,
+	`break_at_out_of_valid_scope`:              `J0080`,
+	`continue_at_out_of_valid_scope`:           `J0081`,
+	`iter_while_require_bool_expr`:             `J0082`,
+	`iter_foreach_require_enumerable_expr`:     `J0083`,
+	`much_foreach_vars`:                        `J0084`,
+	`if_require_bool_expr`:                     `J0085`,
+	`else_have_expr`:                           `J0086`,
+	`variadic_parameter_not_last`:              `J0087`,
+	`variadic_with_non_variadicable`:           `J0088`,
+	`more_args_with_variadiced`:                `J0089`,
+	`type_not_supports_casting`:                `J0090`,
+	`type_not_supports_casting_to`:             `J0091`,
+	`generics_not_supports`:                    `J0092`,
+	`use_at_content`:                           `J0093`,
+	`use_not_found`:                            `J0094`,
+	`use_has_errors`:                           `J0095`,
+	`def_not_support_pub`:                      `J0096`,
+	`obj_not_support_sub_fields`:               `J0097`,
+	`obj_have_not_id`:                          `J0098`,
+	`doc_couldnt_generated`:                    `J0099`,
+	`declared_but_not_used`:                    `J0100`,
+	`expr_not_func_call`:                       `J0101`,
+	`label_exist`:                              `J0102`,
+	`label_not_exist`:                          `J0103`,
+	`goto_jumps_declarations`:                  `J0104`,
+	`already_has_expr`:                         `J0106`,
+	`argument_must_target_to_parameter`:        `J0107`,
+	`namespace_not_exist`:                      `J0108`,
+	`overflow_limits`:                          `J0109`,
+	`generics_overflow`:                        `J0110`,
+	`has_generics`:                             `J0111`,
+	`not_has_generics`:                         `J0112`,
+	`divide_by_zero`:                           `J0113`,
+	`trait_hasnt_id`:                           `J0114`,
+	`not_impl_trait_def`:                       `J0115`,
+	`dynamic_type_annotation_failed`:           `J0116`,
+	`fallthrough_wrong_use`:                    `J0117`,
+	`fallthrough_into_final_case`:              `J0118`,
+	`unsafe_behavior_at_out_of_unsafe_scope`:   `J0119`,
+	`variable_not_initialized`:                 `J0120`,
+	`reference_not_initialized`:                `J0121`,
+	`ref_method_used_with_not_ref_instance`:    `J0122`,
+	`method_as_anonymous_fn`:                   `J0123`,
+	`genericed_fn_as_anonymous_fn`:             `J0124`,
+	`ref_used_struct_used_at_new_fn`:           `J0125`,
+	`reference_field_not_initialized`:          `J0126`,
+	`illegal_cycle_in_declaration`:             `J0127`,
+	`assignment_to_non_mut`:                    `J0128`,
+	`assignment_non_mut_to_mut`:                `J0129`,
+	`ret_with_mut_typed_non_mut`:               `J0130`,
+	`mutable_operation_on_immutable`:           `J0131`,
+	`trait_has_reference_parametered_function`: `J0132`,
+	`settings_invalid`:                         `J0133`,
+	`settings_invalid_key`:                     `J0134`,
+	`no_src_in_dir`:                            `J0135`,
+	`invalid_build_expr`:                       `J0136`,
+	`error_limit_exceeded`:                     `J0139`,
+	`unknown_lint`:                             `J0140`,
+	`shadowed_var`:                             `J0141`,
+	`unreachable_code`:                         `J0142`,
+	`empty_block`:                              `J0143`,
+	`redundant_cast`:                           `J0144`,
+	`comparison_always`:                        `J0145`,
+	`unused_use`:                               `J0146`,
+	`unused_use_selector`:                      `J0147`,
+	`unused_define`:                            `J0148`,
+	`duplicate_case`:                           `J0149`,
+	`case_after_default`:                       `J0151`,
+	`non_exhaustive_match`:                     `J0152`,
+	`nil_dereference`:                          `J0153`,
+	`nil_call`:                                 `J0154`,
+	`nil_before_init`:                          `J0155`,
+	`co_ref_local`:                             `J0158`,
+	`co_captured_local`:                        `J0166`,
+	`data_race`:                                `J0160`,
+	`data_race_iter`:                           `J0161`,
+	`unknown_sanitizer`:                        `J0164`,
+	`incompatible_sanitizers`:                  `J0165`,
+}
+
+// GetCode returns public code of error message.
+//
+// Special case is;
+//
+//	GetCode(key) -> returns empty string if error message is not exist.
+func GetCode(key string) string { return CODES[key] }
+
+// GetKeyByCode returns key of error message by public code.
+// Code is case-insensitive.
+//
+// Special case is;
+//
+//	GetKeyByCode(code) -> returns empty string if code is not exist.
+func GetKeyByCode(code string) string {
+	code = strings.ToUpper(code)
+	for key, c := range CODES {
+		if c == code {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package jule
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCodes(t *testing.T) {
+	keys := map[string]string{}
+	for key, code := range CODES {
+		if prev, ok := keys[code]; ok {
+			t.Errorf("code %s of %s is used by %s", code, key, prev)
+		}
+		keys[code] = key
+		if _, ok := ERRORS[key]; !ok {
+			t.Errorf("code %s has no error message: %s", code, key)
+		}
+		if GetKeyByCode(code) != key {
+			t.Errorf("GetKeyByCode(%q) = %q, want %q", code, GetKeyByCode(code), key)
+		}
+	}
+}
+
+func TestExplanations(t *testing.T) {
+	for key, code := range CODES {
+		if GetExplanation(key) == "" {
+			t.Errorf("code %s has no explanation: %s", code, key)
+		}
+	}
+	for key := range EXPLANATIONS {
+		if GetCode(key) == "" {
+			t.Errorf("explanation has no code: %s", key)
+		}
+	}
+}
+
+func TestExplanationsPack(t *testing.T) {
+	path := filepath.Join("..", "..", LOCALIZATIONS, EXPLANATIONS_DIR, "english.json")
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pack map[string]string
+	err = json.Unmarshal(bytes, &pack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pack) != len(EXPLANATIONS) {
+		t.Errorf("pack has %d explanations, want %d", len(pack), len(EXPLANATIONS))
+	}
+	for key, explanation := range EXPLANATIONS {
+		if pack[key] != explanation {
+			t.Errorf("explanation of pack is different: %s", key)
+		}
+	}
+}
+
+func TestGetErrorPattern(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"id_not_exist", "identifier is not exist: ..."},
+		{"operator_not_for_int", "... operator is not defined for integer type(s)"},
+		{"invalid_token", "undefined code content: ..."},
+		{"error_limit_exceeded", "too many errors, ... more errors are not shown"},
+		{"no_entry_point", "entry point (main) function is not defined"},
+	}
+	for _, test := range tests {
+		if got := GetErrorPattern(test.key); got != test.want {
+			t.Errorf("GetErrorPattern(%q) = %q, want %q", test.key, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package jule
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Env is the environment of a compilation.
+// Compilations with different environments are not share any state,
+// so they can run concurrently.
+type Env struct {
+	// Error messages by keys.
+	Errors     map[string]string
+	StdlibPath string
+	TargetOS   string
+	TargetArch string
+	// User defined build tags.
+	Tags []string
+}
+
+// NewEnv returns new environment from process environment.
+// Error messages are copied, so changes not affects ERRORS.
+func NewEnv() *Env {
+	env := &Env{
+		Errors:     make(map[string]string, len(ERRORS)),
+		StdlibPath: STDLIB_PATH,
+		TargetOS:   TARGET_OS,
+		TargetArch: TARGET_ARCH,
+	}
+	for key, msg := range ERRORS {
+		env.Errors[key] = msg
+	}
+	return env
+}
+
+// GetError returns error of environment.
+func (env *Env) GetError(key string, args ...any) string {
+	return fmt.Sprintf(env.Errors[key], args...)
+}
+
+// BitSize returns bit size of target architecture.
+// Returns bit size of host for unknown architectures.
+func (env *Env) BitSize() int {
+	bits := BitSizeOfArch(env.TargetArch)
+	if bits == 0 {
+		return strconv.IntSize
+	}
+	return bits
+}
@@ -1,6 +1,9 @@
 package jule
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+)
 
 // Error messages.
 var ERRORS = map[string]string{
@@ -19,14 +22,12 @@ var ERRORS = map[string]string{
 	`expected_brace_close`:                     `was expected brace close`,
 	`expected_bracket_close`:                   `was expected bracket close`,
 	`body_not_exist`:                           `body is not exist`,
-	`operator_overflow`:                        `operator overflow`,
 	`incompatible_types`:                       `%s and %s data-types are not compatible`,
 	`operator_not_for_juletype`:                `%s operator is not defined for %s type`,
 	`operator_not_for_float`:                   `%s operator is not defined for float type(s)`,
 	`operator_not_for_int`:                     `%s operator is not defined for integer type(s)`,
 	`operator_not_for_uint`:                    `%s operator is not defined for unsigned integer type(s)`,
 	`id_not_exist`:                             `identifier is not exist: %s`,
-	`not_function_call`:                        `value is not function`,
 	`argument_overflow`:                        `argument overflow`,
 	`fn_have_ret`:                              `%s function cannot have return type`,
 	`fn_have_parameters`:                       `%s function cannot have parameter(s)`,
@@ -41,13 +42,9 @@ var ERRORS = map[string]string{
 	`invalid_token`:                            `undefined code content: %c`,
 	`invalid_syntax`:                           `invalid syntax`,
 	`invalid_type`:                             `invalid data-type`,
-	`invalid_numeric_range`:                    `arithmetic value overflow`,
-	"invalid_operator":                         `invalid operator`,
 	"invalid_expr_unary_operator":              `invalid expression for unary %s operator`,
 	`invalid_escape_sequence`:                  `invalid escape sequence`,
 	`invalid_type_source`:                      `invalid data-type source`,
-	`invalid_preprocessor`:                     `invalid preprocessor directive`,
-	`invalid_pragma_directive`:                 `invalid pragma directive`,
 	`invalid_type_for_const`:                   `%s is invalid data-type for constant`,
 	`invalid_value_for_key`:                    `"%s" is invalid value for the "%s" key`,
 	`invalid_expr`:                             `invalid expression`,
@@ -63,7 +60,6 @@ var ERRORS = map[string]string{
 	`missing_multi_return`:                     `missing return values for multi return`,
 	`missing_multi_assign_identifiers`:         `missing identifier(s) for multiple assignment`,
 	`missing_use_path`:                         `missing path of use statement`,
-	`missing_pragma_directive`:                 `missing pragma directive`,
 	`missing_goto_label`:                       `missing label identifier for goto statement`,
 	`missing_expr_for`:                         `missing expression for %s`,
 	`missing_generics`:                         `missing generics`,
@@ -78,7 +74,6 @@ var ERRORS = map[string]string{
 	`not_supports_slicing`:                     `%s data type is not support slicing`,
 	`already_const`:                            `define is already constant`,
 	`already_variadic`:                         `define is already variadic`,
-	`already_reference`:                        `define is already reference`,
 	`already_uses`:                             `path is already uses`,
 	`ignore_id`:                                `ignore operator cannot use as identifier`,
 	`overflow_multi_assign_identifiers`:        `overflow multi assignment identifers`,
@@ -108,7 +103,6 @@ var ERRORS = map[string]string{
 	`label_exist`:                              `label is already exist in this identifier: %s`,
 	`label_not_exist`:                          `not exist any label in this identifier: %s`,
 	`goto_jumps_declarations`:                  `goto %s jumps over declaration(s)`,
-	`fn_not_has_parameter`:                     `function is not has parameter in this identifier: %s`,
 	`already_has_expr`:                         `%s already has expression`,
 	`argument_must_target_to_parameter`:        `argument must target to parameter`,
 	`namespace_not_exist`:                      `namespace is not exist in this identifier: %s`,
@@ -136,9 +130,51 @@ var ERRORS = map[string]string{
 	`ret_with_mut_typed_non_mut`:               `mutable typed return expressions should be mutable`,
 	`mutable_operation_on_immutable`:           `mutable operation cannot used with immutable define`,
 	`trait_has_reference_parametered_function`: `trait has reference receiver parameter used method, cannot assign non-reference instance`,
+	`settings_invalid`:                         `settings file is invalid: %s`,
+	`settings_invalid_key`:                     `"%s" is not a valid settings key`,
+	`no_src_in_dir`:                            `directory has not any Jule source file: %s`,
+	`invalid_build_expr`:                       `invalid build constraint expression: %s`,
+	`previous_declaration`:                     `previous declaration here`,
+	`did_you_mean`:                             `did you mean %s?`,
+	`error_limit_exceeded`:                     `too many errors, %d more errors are not shown`,
+	`unknown_lint`:                             `unknown lint: %s`,
+	`shadowed_var`:                             `%s shadows a variable of outer scope`,
+	`unreachable_code`:                         `unreachable code`,
+	`empty_block`:                              `empty block`,
+	`redundant_cast`:                           `redundant cast to %s`,
+	`comparison_always`:                        `comparison is always %s`,
+	`unused_use`:                               `use declaration is not used: %s`,
+	`unused_use_selector`:                      `imported but not used: %s`,
+	`unused_define`:                            `%s is declared but never used`,
+	`duplicate_case`:                           `duplicate case in match`,
+	`previous_case`:                            `previous case here`,
+	`case_after_default`:                       `case after default, default is always checked last`,
+	`non_exhaustive_match`:                     `match is not exhaustive, missing cases: %s`,
+	`nil_dereference`:                          `%s is always nil here, dereference panics at runtime`,
+	`nil_call`:                                 `%s is always nil here, call panics at runtime`,
+	`nil_before_init`:                          `%s is nil before init, global initializers are evaluated before init`,
+	`nil_assigned`:                             `assigned nil here`,
+	`nil_checked`:                              `checked for nil here`,
+	`co_ref_local`:                             `reference to local variable %s may outlive the function in concurrent call`,
+	`co_captured_local`:                        `local variable %s is captured by reference and may outlive the function in concurrent call`,
+	`co_wait_note`:                             `wait for concurrent calls with std::sync::WaitGroup before the function returns`,
+	`data_race`:                                `%s is written in concurrent call and here without synchronization`,
+	`data_race_iter`:                           `%s is written by concurrent calls of iteration without synchronization`,
+	`co_written_here`:                          `written in concurrent call here`,
+	`data_race_note`:                           `use functions of std::sync::atomic or wait with std::sync::WaitGroup`,
+	`unknown_sanitizer`:                        `unknown sanitizer: %s`,
+	`incompatible_sanitizers`:                  `%s and %s sanitizers cannot be used together`,
 }
 
 // GetError returns error.
 func GetError(key string, args ...any) string {
 	return fmt.Sprintf(ERRORS[key], args...)
 }
+
+var format_verb = regexp.MustCompile(`%[a-zA-Z]`)
+
+// GetErrorPattern returns error without arguments.
+// Format verbs of error are replaced with "..." placeholders.
+func GetErrorPattern(key string) string {
+	return format_verb.ReplaceAllString(ERRORS[key], "...")
+}
@@ -0,0 +1,2939 @@
+package jule
+
+// EXPLANATIONS is the long-form explanations of error messages.
+// Explanations are addressed by keys of error messages.
+var EXPLANATIONS = map[string]string{
+	`stdlib_not_exist`: `Standard library directory is not found.
+The compiler looks for the standard library in the std directory next to the compiler executable.
+Every compilation needs the standard library, even if the program does not use any package of it.
+Make sure the std directory is installed with the compiler and has not been moved or renamed.`,
+	`file_not_useable`: `Source file is not useable for the target.
+The file is excluded by its name or by its build constraints.
+Files with an operating system or architecture suffix, like main_windows.jule or main_arm64.jule,
+are only compiled for that target, and //jule:build directives at the top of the file
+must be satisfied by the target and the build tags.
+Compile the file for a suitable target with the --target option, give the required tags
+with the --tags option, or change the constraints of the file.`,
+	`file_not_jule`: `File is not a Jule source file.
+Jule source files must have the .jule extension.
+Rename the file, or give the path of a Jule source file or a package directory.`,
+	`no_entry_point`: `Program has not entry point.
+Every executable Jule program must define a main function at the package scope.
+Execution of program starts from the main function.
+
+Wrong:
+
+	fn hello() {
+		outln("Hello")
+	}
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+	}`,
+	`exist_id`: `Identifier is already defined in the same scope.
+Definitions of the same scope must have different identifiers.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		let x = 20
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		let y = 20
+		outln(x + y)
+	}`,
+	`extra_closed_parentheses`: `Closing parenthesis has no opening parenthesis.
+Every closing parenthesis must close a parenthesis opened before it.
+Closing tokens inside of other ranges are reported as wrong order of closing.
+
+Wrong:
+
+	fn main() {
+		outln("Hello")
+	})
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+	}`,
+	`extra_closed_braces`: `Closing brace has no opening brace.
+Every closing brace must close a brace opened before it.
+This is usually caused by a block that is closed twice.
+
+Wrong:
+
+	fn main() {
+		outln("Hello")
+	}
+	}
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+	}`,
+	`extra_closed_brackets`: `Closing bracket has no opening bracket.
+Every closing bracket must close a bracket opened before it.
+Closing tokens inside of other ranges are reported as wrong order of closing.
+
+Wrong:
+
+	fn main() {
+		outln("Hello")
+	}]
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+	}`,
+	`wait_close_parentheses`: `Parenthesis is never closed.
+Every opening parenthesis must be closed before the end of the file.
+
+Wrong:
+
+	fn main() {
+		outln((1 + 2)
+	}
+
+Correct:
+
+	fn main() {
+		outln((1 + 2))
+	}`,
+	`wait_close_brace`: `Brace is never closed.
+Every opening brace must be closed before the end of the file.
+This is usually caused by a block that is not closed.
+
+Wrong:
+
+	fn main() {
+		if true {
+			outln("Hello")
+	}
+
+Correct:
+
+	fn main() {
+		if true {
+			outln("Hello")
+		}
+	}`,
+	`wait_close_bracket`: `Bracket is never closed.
+Every opening bracket must be closed before the end of the file.
+
+Wrong:
+
+	fn main() {
+		let s = [1, 2, 3
+	}
+
+Correct:
+
+	fn main() {
+		let s = [1, 2, 3]
+		outln(s)
+	}`,
+	`expected_parentheses_close`: `Parenthesis is not closed before another closing token.
+Parentheses, braces and brackets must be closed in the reverse order they are opened.
+
+Wrong:
+
+	fn main() {
+		let s = [(1 + 2]
+		outln(s)
+	}
+
+Correct:
+
+	fn main() {
+		let s = [(1 + 2)]
+		outln(s)
+	}`,
+	`expected_brace_close`: `Brace is not closed before another closing token.
+Parentheses, braces and brackets must be closed in the reverse order they are opened.
+
+Wrong:
+
+	fn main() {
+		outln(fn(): int { ret 1 )
+	}
+
+Correct:
+
+	fn main() {
+		outln(fn(): int { ret 1 }())
+	}`,
+	`expected_bracket_close`: `Bracket is not closed before another closing token.
+Parentheses, braces and brackets must be closed in the reverse order they are opened.
+
+Wrong:
+
+	fn main() {
+		outln([1, 2, 3)
+	}
+
+Correct:
+
+	fn main() {
+		outln([1, 2, 3])
+	}`,
+	`body_not_exist`: `Definition has not body.
+Definitions like structures, enums and traits must have a body in braces,
+even if the body is empty.
+
+Wrong:
+
+	enum Color: u8
+
+	fn main() {}
+
+Correct:
+
+	enum Color: u8 {
+		Red,
+		Green,
+		Blue,
+	}
+
+	fn main() {}`,
+	`incompatible_types`: `Data-types of expressions are not compatible.
+Jule does not make implicit conversions between unrelated types.
+Use casting if the conversion is really intended.
+
+Wrong:
+
+	fn main() {
+		let x: int = "10"
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x: int = 10
+		outln(x)
+	}`,
+	`operator_not_for_juletype`: `Operator is not defined for the data-type.
+Strings only support the +, == and != operators,
+pointers only support the +, - and comparison operators,
+and values of the any type only support the == and != operators.
+Compound assignments like += are only defined for numeric types.
+
+Wrong:
+
+	fn main() {
+		let s = "Hello" - "H"
+		outln(s)
+	}
+
+Correct:
+
+	fn main() {
+		let s = "Hello" + " World"
+		outln(s)
+	}`,
+	`operator_not_for_float`: `Operator is not defined for floating-point types.
+Bitwise and shift operators are only defined for integer types.
+
+Wrong:
+
+	fn main() {
+		let x = 1.5 & 2.5
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 1.5 + 2.5
+		outln(x)
+	}`,
+	`operator_not_for_int`: `Operator is not defined for signed integer types.
+Signed integer types support arithmetic, bitwise, shift and comparison operators,
+logical operators are only defined for the bool type.
+Use an operator that is defined for integer types,
+or convert the operands to a type that supports the operator.`,
+	`operator_not_for_uint`: `Operator is not defined for unsigned integer types.
+Unsigned integer types support arithmetic, bitwise, shift and comparison operators,
+logical operators are only defined for the bool type.
+Use an operator that is defined for integer types,
+or convert the operands to a type that supports the operator.`,
+	`id_not_exist`: `Identifier is not defined.
+An identifier is used but there is no visible definition with this identifier.
+Check spelling of the identifier and the use declarations of the file.
+
+Wrong:
+
+	fn main() {
+		outln(count)
+	}
+
+Correct:
+
+	fn main() {
+		let count = 10
+		outln(count)
+	}`,
+	`argument_overflow`: `Call has more arguments than parameters of the function.
+Every argument must match a parameter of the function.
+Variadic parameters accept any count of arguments at the end of the call.
+
+Wrong:
+
+	fn add(a: int, b: int): int {
+		ret a + b
+	}
+
+	fn main() {
+		outln(add(1, 2, 3))
+	}
+
+Correct:
+
+	fn add(a: int, b: int): int {
+		ret a + b
+	}
+
+	fn main() {
+		outln(add(1, 2))
+	}`,
+	`fn_have_ret`: `Special function has return type.
+The main and init functions are called by the runtime and cannot return a value.
+
+Wrong:
+
+	fn main(): int {
+		ret 0
+	}
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+	}`,
+	`fn_have_parameters`: `Special function has parameters.
+The main and init functions are called by the runtime without arguments,
+so they cannot have any parameter.
+
+Wrong:
+
+	fn main(name: str) {
+		outln(name)
+	}
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+	}`,
+	`fn_is_unsafe`: `Special function is unsafe.
+The main and init functions cannot be unsafe functions.
+Use an unsafe block for unsafe operations in the function.
+
+Wrong:
+
+	unsafe fn main() {
+		outln("Hello")
+	}
+
+Correct:
+
+	fn main() {
+		unsafe {
+			outln("Hello")
+		}
+	}`,
+	`require_return_value`: `Return statement has not return value.
+Functions that have a return type must return a value with every return statement.
+
+Wrong:
+
+	fn double(x: int): int {
+		ret
+	}
+
+	fn main() {
+		outln(double(2))
+	}
+
+Correct:
+
+	fn double(x: int): int {
+		ret x * 2
+	}
+
+	fn main() {
+		outln(double(2))
+	}`,
+	`void_function_return_value`: `Void function returns a value.
+Functions without a return type cannot return any value.
+Declare a return type if function should return a value.
+
+Wrong:
+
+	fn answer() {
+		ret 42
+	}
+
+	fn main() {
+		answer()
+	}
+
+Correct:
+
+	fn answer(): int {
+		ret 42
+	}
+
+	fn main() {
+		outln(answer())
+	}`,
+	`bitshift_must_unsigned`: `Shift count is not valid.
+Right operand of shift operators must be an integer.
+Constant shift counts must not be negative.
+
+Wrong:
+
+	fn main() {
+		outln(1 << -1)
+	}
+
+Correct:
+
+	fn main() {
+		outln(1 << 1)
+	}`,
+	`logical_not_bool`: `Logical expression has non-boolean operand.
+Operands of logical operators must be boolean.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		outln(x && true)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		outln(x > 0 && true)
+	}`,
+	`assign_const`: `Constant is assigned.
+Constants are evaluated at compile-time and cannot be changed.
+
+Wrong:
+
+	const LIMIT = 10
+
+	fn main() {
+		LIMIT = 20
+	}
+
+Correct:
+
+	let mut LIMIT = 10
+
+	fn main() {
+		LIMIT = 20
+		outln(LIMIT)
+	}`,
+	`assign_require_lvalue`: `Left side of assignment is not assignable.
+Only variables, fields, indexed elements and dereferenced pointers can be assigned.
+Results of calls and literals cannot be assigned.
+
+Wrong:
+
+	fn get(): int {
+		ret 10
+	}
+
+	fn main() {
+		get() = 20
+	}
+
+Correct:
+
+	fn main() {
+		let mut x = 10
+		x = 20
+		outln(x)
+	}`,
+	`assign_type_not_support_value`: `Type does not support assignment.
+A function definition is not a variable, so a value cannot be assigned to it.
+Declare a variable of a function type to hold functions that change.`,
+	`invalid_token`: `Source code has a character which is not part of the Jule syntax.
+Characters outside of string and rune literals and comments
+must be valid tokens of the language.
+
+Wrong:
+
+	fn main() {
+		let x = 10 $ 20
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10 + 20
+		outln(x)
+	}`,
+	`invalid_syntax`: `Syntax is not valid.
+Tokens are not in a form of any valid declaration, statement or expression.
+Check the statement at the reported position, a missing or extra token is a common cause.
+
+Wrong:
+
+	fn main() {
+		let x = 10 20
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10 + 20
+		outln(x)
+	}`,
+	`invalid_type`: `Data-type is not valid here.
+Arrays with automatic size, like [...]int, are only allowed with an initializer,
+and functions cannot have parameters or return types of array types.
+Use a slice instead of an array for these cases.
+
+Wrong:
+
+	fn first(a: [...]int): int {
+		ret a[0]
+	}
+
+	fn main() {}
+
+Correct:
+
+	fn first(s: []int): int {
+		ret s[0]
+	}
+
+	fn main() {}`,
+	`invalid_expr_unary_operator`: `Unary operator is not defined for the expression.
+The - and + operators are only defined for numeric types, the ^ operator for integer types,
+the ! operator for booleans, the * operator for pointers,
+and the & operator for addressable expressions.
+
+Wrong:
+
+	fn main() {
+		let b = true
+		outln(-b)
+	}
+
+Correct:
+
+	fn main() {
+		let b = true
+		outln(!b)
+	}`,
+	`invalid_escape_sequence`: `Escape sequence of literal is not valid.
+String and rune literals only support the escape sequences of the language,
+like \n, \t, \\, \', \", \x hexadecimal and \u unicode escapes.
+Use a raw string literal if backslashes should be kept as is.
+
+Wrong:
+
+	fn main() {
+		outln("C:\dir")
+	}
+
+Correct:
+
+	fn main() {
+		outln("C:\\dir")
+	}`,
+	`invalid_type_source`: `Data-type cannot be used for the definition.
+Enums can only have integer or string types,
+and type definitions like aliases must refer to a valid type.
+
+Wrong:
+
+	enum Ratio: f64 {
+		Half = 0.5,
+	}
+
+	fn main() {}
+
+Correct:
+
+	enum Ratio: u8 {
+		Half = 50,
+	}
+
+	fn main() {}`,
+	`invalid_type_for_const`: `Data-type is not allowed for constants.
+Constants can only have basic types like numeric types, bool and str.
+
+Wrong:
+
+	const NAMES: []str = ["a", "b"]
+
+	fn main() {}
+
+Correct:
+
+	const NAME: str = "a"
+
+	fn main() {}`,
+	`invalid_value_for_key`: `Value of an option or settings key is not valid.
+Check the documentation of the option for the accepted values.
+For example, compiler must be gcc or clang, optimization must be one of 0, 1, 2, 3 and s,
+std must be a C++ standard like c++17 or c++20,
+and sanitize must list the address, undefined and thread sanitizers.
+
+For example, this jule.set file has an invalid compiler:
+
+	{
+		"compiler": "msvc"
+	}`,
+	`invalid_expr`: `Expression is not valid here.
+Types cannot be used as values, and indexes must be integer values.
+
+Wrong:
+
+	fn main() {
+		let s = [1, 2, 3]
+		outln(s["0"])
+	}
+
+Correct:
+
+	fn main() {
+		let s = [1, 2, 3]
+		outln(s[0])
+	}`,
+	`invalid_header_ext`: `Extension of C++ header is not valid.
+Use declarations of C++ headers must have a header extension like .h, .hpp or .hh,
+system headers are given in angle brackets.
+
+Wrong:
+
+	use cpp "lib.cpp"
+
+	fn main() {}
+
+Correct:
+
+	use cpp "lib.hpp"
+
+	fn main() {}`,
+	`invalid_label`: `Label is not a label of an iteration or match.
+Labels of break and continue statements must be defined just before an iteration.
+Break statements also accept labels of match statements.
+
+Wrong:
+
+	fn main() {
+	outer:
+		outln("Start")
+		for {
+			break outer
+		}
+	}
+
+Correct:
+
+	fn main() {
+		outln("Start")
+	outer:
+		for {
+			break outer
+		}
+	}`,
+	`missing_autotype_value`: `Auto-typed variable has not initializer.
+Type of variable is inferred from the initializer expression.
+Declare the type explicitly or give an initializer.
+
+Wrong:
+
+	fn main() {
+		let x
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		outln(x)
+	}`,
+	`missing_type`: `Data-type is missing.
+Fields of structures and parameters of functions must have a data-type.
+
+Wrong:
+
+	struct Point {
+		x
+		y
+	}
+
+	fn main() {}
+
+Correct:
+
+	struct Point {
+		x: int
+		y: int
+	}
+
+	fn main() {}`,
+	`missing_expr`: `Expression is missing.
+The statement requires an expression at the reported position.
+
+Wrong:
+
+	fn main() {
+		let x = 10 +
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10 + 20
+		outln(x)
+	}`,
+	`missing_block_comment`: `Block comment is never closed.
+Every block comment opened with /* must be closed with */.
+
+Wrong:
+
+	fn main() {
+		/* Print greeting.
+		outln("Hello")
+	}
+
+Correct:
+
+	fn main() {
+		/* Print greeting. */
+		outln("Hello")
+	}`,
+	`missing_rune_end`: `Rune literal is not finished.
+Rune literals must be closed with a single quote in the same line.
+
+Wrong:
+
+	fn main() {
+		let r = 'a
+		outln(r)
+	}
+
+Correct:
+
+	fn main() {
+		let r = 'a'
+		outln(r)
+	}`,
+	`missing_ret`: `Function has not return statement at end.
+Functions that have a return type must end with a return statement.
+
+Wrong:
+
+	fn sign(x: int): int {
+		if x < 0 {
+			ret -1
+		}
+	}
+
+	fn main() {
+		outln(sign(-5))
+	}
+
+Correct:
+
+	fn sign(x: int): int {
+		if x < 0 {
+			ret -1
+		}
+		ret 1
+	}
+
+	fn main() {
+		outln(sign(-5))
+	}`,
+	`missing_string_end`: `String literal is not finished.
+String literals must be closed with a double quote in the same line.
+Use a raw string literal for strings of multiple lines.
+
+Wrong:
+
+	fn main() {
+		outln("Hello)
+	}
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+	}`,
+	`missing_multi_return`: `Return statement has fewer values than the return type.
+Functions with multiple return types must return a value for every type.
+
+Wrong:
+
+	fn div(a: int, b: int): (int, int) {
+		ret a / b
+	}
+
+	fn main() {}
+
+Correct:
+
+	fn div(a: int, b: int): (int, int) {
+		ret a / b, a % b
+	}
+
+	fn main() {}`,
+	`missing_multi_assign_identifiers`: `Multiple values are assigned to fewer identifiers.
+Results of functions with multiple return values must be assigned
+to the same count of identifiers.
+Use the ignore identifier for values that are not needed.
+
+Wrong:
+
+	fn div(a: int, b: int): (int, int) {
+		ret a / b, a % b
+	}
+
+	fn main() {
+		let q = div(7, 2)
+		outln(q)
+	}
+
+Correct:
+
+	fn div(a: int, b: int): (int, int) {
+		ret a / b, a % b
+	}
+
+	fn main() {
+		let (q, _) = div(7, 2)
+		outln(q)
+	}`,
+	`missing_use_path`: `Use declaration has no path.
+Use declarations must give the path of a package, like std::math,
+or a C++ header with the cpp keyword.
+
+Wrong:
+
+	use
+
+	fn main() {}
+
+Correct:
+
+	use std::math::{PI}
+
+	fn main() {
+		outln(PI)
+	}`,
+	`missing_goto_label`: `Goto statement has no label.
+Goto statements must give the identifier of the label to jump.
+
+Wrong:
+
+	fn main() {
+		goto
+	end:
+		outln("End")
+	}
+
+Correct:
+
+	fn main() {
+		goto end
+	end:
+		outln("End")
+	}`,
+	`missing_expr_for`: `Argument of parameter is missing.
+Every parameter must have an argument in the call, except variadic parameters.
+
+Wrong:
+
+	fn add(a: int, b: int): int {
+		ret a + b
+	}
+
+	fn main() {
+		outln(add(1))
+	}
+
+Correct:
+
+	fn add(a: int, b: int): int {
+		ret a + b
+	}
+
+	fn main() {
+		outln(add(1, 2))
+	}`,
+	`missing_generics`: `Generic types are missing.
+Every generic type of the definition must be given.
+
+Wrong:
+
+	type[K, V]
+	fn pair(k: K, v: V) {
+		outln(k)
+		outln(v)
+	}
+
+	fn main() {
+		pair[int](1, "a")
+	}
+
+Correct:
+
+	type[K, V]
+	fn pair(k: K, v: V) {
+		outln(k)
+		outln(v)
+	}
+
+	fn main() {
+		pair[int, str](1, "a")
+	}`,
+	`missing_receiver`: `Method has no receiver parameter.
+Methods of structures must have a self parameter as the first parameter.
+Use self for methods of the instance, or &self for methods of references.
+
+Wrong:
+
+	struct Counter {
+		n: int
+	}
+
+	impl Counter {
+		fn get(): int {
+			ret 0
+		}
+	}
+
+	fn main() {}
+
+Correct:
+
+	struct Counter {
+		n: int
+	}
+
+	impl Counter {
+		fn get(self): int {
+			ret self.n
+		}
+	}
+
+	fn main() {}`,
+	`missing_function_parentheses`: `Function has no parameter list.
+Functions must have parentheses for parameters, even if they have no parameter.
+
+Wrong:
+
+	fn hello {
+		outln("Hello")
+	}
+
+	fn main() {
+		hello()
+	}
+
+Correct:
+
+	fn hello() {
+		outln("Hello")
+	}
+
+	fn main() {
+		hello()
+	}`,
+	`expr_not_const`: `Expression is not constant.
+Constants, enum items and sizes of arrays must be constant expressions,
+they cannot use variables or calls.
+
+Wrong:
+
+	fn main() {
+		let n = 3
+		let a: [n]int = [1, 2, 3]
+		outln(a)
+	}
+
+Correct:
+
+	fn main() {
+		const N = 3
+		let a: [N]int = [1, 2, 3]
+		outln(a)
+	}`,
+	`nil_for_autotype`: `Auto-typed variable is initialized with nil.
+Type of variable cannot be inferred from nil.
+Declare the type of variable explicitly.
+
+Wrong:
+
+	fn main() {
+		let x = nil
+		_ = x
+	}
+
+Correct:
+
+	fn main() {
+		let x: *int = nil
+		_ = x
+	}`,
+	`void_for_autotype`: `Void value is used for automatic type.
+The function has no return value, so the type of the variable cannot be inferred.
+
+Wrong:
+
+	fn hello() {
+		outln("Hello")
+	}
+
+	fn main() {
+		let x = hello()
+	}
+
+Correct:
+
+	fn hello(): str {
+		ret "Hello"
+	}
+
+	fn main() {
+		let x = hello()
+		outln(x)
+	}`,
+	`rune_empty`: `Rune literal is empty.
+Rune literals must have exactly one character.
+
+Wrong:
+
+	fn main() {
+		let r = ''
+		outln(r)
+	}
+
+Correct:
+
+	fn main() {
+		let r = ' '
+		outln(r)
+	}`,
+	`rune_overflow`: `Rune literal has more than one character.
+Rune literals must have exactly one character.
+Use a string literal for multiple characters.
+
+Wrong:
+
+	fn main() {
+		let r = 'ab'
+		outln(r)
+	}
+
+Correct:
+
+	fn main() {
+		let s = "ab"
+		outln(s)
+	}`,
+	`not_supports_indexing`: `Data-type does not support indexing.
+Only arrays, slices, maps, strings and pointers can be indexed.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		outln(x[0])
+	}
+
+Correct:
+
+	fn main() {
+		let x = [10]
+		outln(x[0])
+	}`,
+	`not_supports_slicing`: `Data-type does not support slicing.
+Only arrays, slices and strings can be sliced.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		outln(x[0:1])
+	}
+
+Correct:
+
+	fn main() {
+		let x = [10, 20]
+		outln(x[0:1])
+	}`,
+	`already_const`: `Definition is already constant.
+The const keyword is given more than once for the same definition.
+Remove the repeated const keyword.`,
+	`already_variadic`: `Variadic operator is repeated.
+Parameters can only have one variadic operator.
+
+Wrong:
+
+	fn sum(values: ......int) {}
+
+	fn main() {}
+
+Correct:
+
+	fn sum(values: ...int) {}
+
+	fn main() {}`,
+	`already_uses`: `Package is already used.
+A file uses the same package more than once.
+Every package can be used only once by a file, so merge the use declarations into one.
+
+Wrong:
+
+	use std::math::{PI}
+	use std::math::{PI}
+
+	fn main() {
+		outln(PI)
+	}
+
+Correct:
+
+	use std::math::{PI}
+
+	fn main() {
+		outln(PI)
+	}`,
+	`ignore_id`: `Ignore operator is used as identifier.
+The ignore operator cannot be an identifier of a definition.
+
+Wrong:
+
+	fn _() {}
+
+	fn main() {}
+
+Correct:
+
+	fn ignored() {}
+
+	fn main() {
+		ignored()
+	}`,
+	`overflow_multi_assign_identifiers`: `Identifiers are more than assigned values.
+Every identifier of a multiple assignment must have a value.
+
+Wrong:
+
+	fn main() {
+		let mut a = 0
+		let mut b = 0
+		let mut c = 0
+		a, b, c = 1, 2
+		outln(a + b + c)
+	}
+
+Correct:
+
+	fn main() {
+		let mut a = 0
+		let mut b = 0
+		let mut c = 0
+		a, b, c = 1, 2, 3
+		outln(a + b + c)
+	}`,
+	`overflow_return`: `Return statement has more values than the return type.
+Count of returned values must be the same with count of return types.
+
+Wrong:
+
+	fn div(a: int, b: int): int {
+		ret a / b, a % b
+	}
+
+	fn main() {}
+
+Correct:
+
+	fn div(a: int, b: int): (int, int) {
+		ret a / b, a % b
+	}
+
+	fn main() {}`,
+	`break_at_out_of_valid_scope`: `Break statement is used out of iteration or match case.
+The break keyword can only be used in iterations and match cases.
+
+Wrong:
+
+	fn main() {
+		if true {
+			break
+		}
+	}
+
+Correct:
+
+	fn main() {
+		for {
+			break
+		}
+	}`,
+	`continue_at_out_of_valid_scope`: `Continue statement is used out of iteration.
+The continue keyword can only be used in iterations.
+
+Wrong:
+
+	fn main() {
+		if true {
+			continue
+		}
+	}
+
+Correct:
+
+	fn main() {
+		let mut i = 0
+		for i < 10; i++ {
+			if i%2 == 0 {
+				continue
+			}
+			outln(i)
+		}
+	}`,
+	`iter_while_require_bool_expr`: `Condition of while iteration is not boolean.
+Conditions must be boolean expressions, there is no implicit conversion to boolean.
+
+Wrong:
+
+	fn main() {
+		let mut x = 10
+		for x {
+			x--
+		}
+	}
+
+Correct:
+
+	fn main() {
+		let mut x = 10
+		for x > 0 {
+			x--
+		}
+	}`,
+	`iter_foreach_require_enumerable_expr`: `Foreach iteration has an expression that is not enumerable.
+Foreach iterations can only iterate arrays, slices, maps and strings.
+
+Wrong:
+
+	fn main() {
+		for i in 10 {
+			outln(i)
+		}
+	}
+
+Correct:
+
+	fn main() {
+		for i in [1, 2, 3] {
+			outln(i)
+		}
+	}`,
+	`much_foreach_vars`: `Foreach iteration has too many variables.
+Foreach iterations can have maximum two variables, the index or key and the element.
+
+Wrong:
+
+	fn main() {
+		for i, x, y in "Jule" {
+			outln(x)
+		}
+	}
+
+Correct:
+
+	fn main() {
+		for i, x in "Jule" {
+			outln(i)
+			outln(x)
+		}
+	}`,
+	`if_require_bool_expr`: `Condition of if statement is not boolean.
+Conditions must be boolean expressions, there is no implicit conversion to boolean.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		if x {
+			outln(x)
+		}
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		if x != 0 {
+			outln(x)
+		}
+	}`,
+	`else_have_expr`: `Else has an expression.
+Else blocks are executed if no condition is true, so they cannot have a condition.
+Use else if for another condition.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		if x > 20 {
+			outln("big")
+		} else x > 5 {
+			outln("medium")
+		}
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		if x > 20 {
+			outln("big")
+		} else if x > 5 {
+			outln("medium")
+		}
+	}`,
+	`variadic_parameter_not_last`: `Variadic parameter is not the last parameter.
+Variadic parameters take all remaining arguments of the call,
+so only the last parameter can be variadic.
+
+Wrong:
+
+	fn print_all(values: ...int, sep: str) {}
+
+	fn main() {}
+
+Correct:
+
+	fn print_all(sep: str, values: ...int) {}
+
+	fn main() {}`,
+	`variadic_with_non_variadicable`: `Expression cannot be passed as variadic arguments.
+Only slices can be passed to variadic parameters with the ... operator.
+
+Wrong:
+
+	fn sum(values: ...int) {}
+
+	fn main() {
+		let x = 10
+		sum(x...)
+	}
+
+Correct:
+
+	fn sum(values: ...int) {}
+
+	fn main() {
+		sum([10, 20]...)
+	}`,
+	`more_args_with_variadiced`: `Variadic argument is passed with other arguments.
+A slice passed with the ... operator replaces all arguments of the variadic parameter,
+so it cannot be mixed with other arguments of the same parameter.
+
+Wrong:
+
+	fn sum(values: ...int) {}
+
+	fn main() {
+		sum(1, [2, 3]...)
+	}
+
+Correct:
+
+	fn sum(values: ...int) {}
+
+	fn main() {
+		sum([1, 2, 3]...)
+	}`,
+	`type_not_supports_casting`: `Data-type cannot be a target of casting.
+Casting is only supported to numeric types, str, slices, pointers,
+structures and traits.
+
+Wrong:
+
+	fn main() {
+		let x = (bool)(1)
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 1 != 0
+		outln(x)
+	}`,
+	`type_not_supports_casting_to`: `Expression cannot be cast to the data-type.
+Casting is only allowed between compatible types, for example between numeric types,
+from traits to structures that implement the trait, and from str to byte and rune slices.
+
+Wrong:
+
+	fn main() {
+		let x = (int)("10")
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = (int)(10.5)
+		outln(x)
+	}`,
+	`generics_not_supports`: `Definition does not support generics.
+Generic types can only be declared for functions and structures.
+
+Wrong:
+
+	type[T]
+	enum Color {
+		Red,
+	}
+
+	fn main() {}
+
+Correct:
+
+	enum Color {
+		Red,
+	}
+
+	fn main() {}`,
+	`use_at_content`: `Use declaration is not at the start of source code.
+All use declarations must be written before any other definition.
+
+Wrong:
+
+	fn hello() {
+		outln("Hello")
+	}
+
+	use std::math
+
+	fn main() {
+		hello()
+	}
+
+Correct:
+
+	use std::math
+
+	fn hello() {
+		outln("Hello")
+	}
+
+	fn main() {
+		hello()
+	}`,
+	`use_not_found`: `Path of use declaration is not found.
+Paths of packages are relative to the standard library, like std::math,
+and C++ headers are relative to the source file.
+
+Wrong:
+
+	use std::maths::{PI}
+
+	fn main() {
+		outln(PI)
+	}
+
+Correct:
+
+	use std::math::{PI}
+
+	fn main() {
+		outln(PI)
+	}`,
+	`use_has_errors`: `Used package has errors.
+Errors of the package are reported before this error.
+Fix the errors of the package to use it.`,
+	`def_not_support_pub`: `Definition does not support the pub modifier.
+Only definitions of package scope like functions, variables, structures,
+traits, enums and type aliases can be public.
+
+Wrong:
+
+	pub impl Point {}
+
+	struct Point {}
+
+	fn main() {}
+
+Correct:
+
+	pub struct Point {}
+
+	fn main() {}`,
+	`obj_not_support_sub_fields`: `Data-type has no fields or methods.
+Dot operator can only be used with values of structures, traits, enums, namespaces
+and types that have built-in methods.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		outln(x.len)
+	}
+
+Correct:
+
+	fn main() {
+		let s = [10]
+		outln(s.len)
+	}`,
+	`obj_have_not_id`: `Data-type has no field or method with the identifier.
+Check spelling of the identifier and definition of the data-type.
+
+Wrong:
+
+	struct Point {
+		x: int
+		y: int
+	}
+
+	fn main() {
+		let p = Point{1, 2}
+		outln(p.z)
+	}
+
+Correct:
+
+	struct Point {
+		x: int
+		y: int
+	}
+
+	fn main() {
+		let p = Point{1, 2}
+		outln(p.y)
+	}`,
+	`doc_couldnt_generated`: `Documentation is not generated.
+Documentation is only generated for source code that has no errors.
+Errors of the source code are reported before this error.`,
+	`declared_but_not_used`: `Variable is declared but never used.
+Unused variables are not allowed in Jule.
+Use the variable or assign it to the ignore operator.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		_ = x
+	}`,
+	`expr_not_func_call`: `Concurrent call statement has not function call expression.
+The co keyword runs a function call concurrently, so expression must be a function call.
+
+Wrong:
+
+	fn work() {
+		outln("working")
+	}
+
+	fn main() {
+		co work
+	}
+
+Correct:
+
+	fn work() {
+		outln("working")
+	}
+
+	fn main() {
+		co work()
+	}`,
+	`label_exist`: `Label is already defined in the same function.
+Labels of a function must have different identifiers.
+
+Wrong:
+
+	fn main() {
+	loop:
+		outln("loop")
+	loop:
+		goto loop
+	}
+
+Correct:
+
+	fn main() {
+		goto second
+	first:
+		outln("first")
+		ret
+	second:
+		goto first
+	}`,
+	`label_not_exist`: `Goto statement targets a label that is not defined.
+Labels must be defined in the same function with the goto statement.
+
+Wrong:
+
+	fn main() {
+		goto end
+	}
+
+Correct:
+
+	fn main() {
+		goto end
+	end:
+	}`,
+	`goto_jumps_declarations`: `Goto statement jumps over declarations.
+Jumping forward over a variable declaration can cause use of an uninitialized variable.
+
+Wrong:
+
+	fn main() {
+		goto end
+		let x = 10
+		outln(x)
+	end:
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		goto end
+		outln(x)
+	end:
+	}`,
+	`already_has_expr`: `Field is given more than once.
+Every field of a structure literal can only be given once.
+
+Wrong:
+
+	struct Point {
+		x: int
+		y: int
+	}
+
+	fn main() {
+		let p = Point{x: 1, x: 2}
+		outln(p.x)
+	}
+
+Correct:
+
+	struct Point {
+		x: int
+		y: int
+	}
+
+	fn main() {
+		let p = Point{x: 1, y: 2}
+		outln(p.x)
+	}`,
+	`argument_must_target_to_parameter`: `Argument has no field identifier.
+Arguments of structure literals must be all named or all positional.
+
+Wrong:
+
+	struct Point {
+		x: int
+		y: int
+	}
+
+	fn main() {
+		let p = Point{x: 1, 2}
+		outln(p.x)
+	}
+
+Correct:
+
+	struct Point {
+		x: int
+		y: int
+	}
+
+	fn main() {
+		let p = Point{x: 1, y: 2}
+		outln(p.x)
+	}`,
+	`namespace_not_exist`: `Namespace is not found.
+Namespaces are paths of used packages and enums, like std::math::bits.
+Check the spelling and the use declarations of the file.
+
+Wrong:
+
+	use std::math::bits
+
+	fn main() {
+		outln(bits::leading_zeros64(1))
+	}
+
+Correct:
+
+	use std::math::bits
+
+	fn main() {
+		outln(std::math::bits::leading_zeros64(1))
+	}`,
+	`overflow_limits`: `Value overflows the limits of the data-type.
+Constant values must fit in the data-type they are assigned to,
+and constant indexes cannot be negative.
+
+Wrong:
+
+	fn main() {
+		let x: u8 = 256
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x: u16 = 256
+		outln(x)
+	}`,
+	`generics_overflow`: `Too many generic types are given.
+Count of given generic types must be the same with the definition.
+
+Wrong:
+
+	type[T]
+	fn show(x: T) {
+		outln(x)
+	}
+
+	fn main() {
+		show[int, str](1)
+	}
+
+Correct:
+
+	type[T]
+	fn show(x: T) {
+		outln(x)
+	}
+
+	fn main() {
+		show[int](1)
+	}`,
+	`has_generics`: `Generic types are not given.
+Generic types of the definition must be given if they cannot be inferred from arguments.
+
+Wrong:
+
+	type[T]
+	fn empty(): []T {
+		ret nil
+	}
+
+	fn main() {
+		outln(empty())
+	}
+
+Correct:
+
+	type[T]
+	fn empty(): []T {
+		ret nil
+	}
+
+	fn main() {
+		outln(empty[int]())
+	}`,
+	`not_has_generics`: `Generic types are given to a definition which has no generics.
+
+Wrong:
+
+	fn show(x: int) {
+		outln(x)
+	}
+
+	fn main() {
+		show[int](1)
+	}
+
+Correct:
+
+	fn show(x: int) {
+		outln(x)
+	}
+
+	fn main() {
+		show(1)
+	}`,
+	`divide_by_zero`: `Constant division by zero.
+Division and modulo by constant zero is detected at compile-time.
+
+Wrong:
+
+	fn main() {
+		let x = 10 / 0
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10 / 2
+		outln(x)
+	}`,
+	`trait_hasnt_id`: `Implementation has a method which is not defined by the trait.
+Implementations of traits can only define the methods of the trait.
+
+Wrong:
+
+	trait Shape {
+		fn area(self): int
+	}
+
+	struct Square {
+		a: int
+	}
+
+	impl Shape for Square {
+		fn area(self): int {
+			ret self.a * self.a
+		}
+
+		fn perimeter(self): int {
+			ret self.a * 4
+		}
+	}
+
+	fn main() {}
+
+Correct:
+
+	trait Shape {
+		fn area(self): int
+	}
+
+	struct Square {
+		a: int
+	}
+
+	impl Shape for Square {
+		fn area(self): int {
+			ret self.a * self.a
+		}
+	}
+
+	fn main() {}`,
+	`not_impl_trait_def`: `Structure does not implement all methods of trait.
+Implementation of a trait must define every method of the trait.
+
+Wrong:
+
+	trait Shape {
+		fn area(self): f64
+	}
+
+	struct Square {
+		side: f64
+	}
+
+	impl Shape for Square {}
+
+	fn main() {}
+
+Correct:
+
+	trait Shape {
+		fn area(self): f64
+	}
+
+	struct Square {
+		side: f64
+	}
+
+	impl Shape for Square {
+		fn area(self): f64 { ret self.side * self.side }
+	}
+
+	fn main() {}`,
+	`dynamic_type_annotation_failed`: `Data-type cannot be inferred.
+Generic types are inferred from arguments of the call,
+and types of slice literals are inferred from their elements.
+Give the types explicitly if they cannot be inferred.
+
+Wrong:
+
+	fn main() {
+		let s = []
+		outln(s)
+	}
+
+Correct:
+
+	fn main() {
+		let s: []int = []
+		outln(s)
+	}`,
+	`fallthrough_wrong_use`: `Fallthrough is used in wrong place.
+The fallthrough keyword can only be used as last statement of a case.
+
+Wrong:
+
+	fn main() {
+		match 1 {
+		case 1:
+			fallthrough
+			outln("one")
+		case 2:
+			outln("two")
+		}
+	}
+
+Correct:
+
+	fn main() {
+		match 1 {
+		case 1:
+			outln("one")
+			fallthrough
+		case 2:
+			outln("two")
+		}
+	}`,
+	`fallthrough_into_final_case`: `Fallthrough is used in final case.
+There is no case to fall through after the final case.
+
+Wrong:
+
+	fn main() {
+		match 1 {
+		case 1:
+			outln("one")
+		case 2:
+			outln("two")
+			fallthrough
+		}
+	}
+
+Correct:
+
+	fn main() {
+		match 1 {
+		case 1:
+			outln("one")
+			fallthrough
+		case 2:
+			outln("two")
+		}
+	}`,
+	`unsafe_behavior_at_out_of_unsafe_scope`: `Unsafe behavior is used out of unsafe scope.
+Unsafe behaviors such as pointer dereferencing are only allowed in unsafe scopes.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		let p = &x
+		outln(*p)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		let p = &x
+		unsafe {
+			outln(*p)
+		}
+	}`,
+	`variable_not_initialized`: `Variable has not initializer.
+Variables must be initialized explicitly at declaration.
+
+Wrong:
+
+	fn main() {
+		let x: int
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x: int = 0
+		outln(x)
+	}`,
+	`reference_not_initialized`: `Reference is not initialized.
+References always refer to a value, so variables and array elements
+of reference types must be initialized explicitly.
+
+Wrong:
+
+	struct Point {
+		x: int
+	}
+
+	fn main() {
+		let p: &Point
+		outln(p.x)
+	}
+
+Correct:
+
+	struct Point {
+		x: int
+	}
+
+	fn main() {
+		let p = &Point{10}
+		outln(p.x)
+	}`,
+	`ref_method_used_with_not_ref_instance`: `Reference method is used with a non-reference instance.
+Methods with a &self receiver can only be called with references of the structure.
+Use a reference literal to create a reference instance.
+
+Wrong:
+
+	struct Counter {
+		n: int
+	}
+
+	impl Counter {
+		fn get(&self): int {
+			ret self.n
+		}
+	}
+
+	fn main() {
+		let c = Counter{}
+		outln(c.get())
+	}
+
+Correct:
+
+	struct Counter {
+		n: int
+	}
+
+	impl Counter {
+		fn get(&self): int {
+			ret self.n
+		}
+	}
+
+	fn main() {
+		let c = &Counter{}
+		outln(c.get())
+	}`,
+	`method_as_anonymous_fn`: `Method is used as an anonymous function.
+Methods are bound to their receivers, so they cannot be used as function values.
+Wrap the call with an anonymous function instead.
+
+Wrong:
+
+	struct Counter {
+		n: int
+	}
+
+	impl Counter {
+		fn get(self): int {
+			ret self.n
+		}
+	}
+
+	fn main() {
+		let c = Counter{}
+		let f = c.get
+		outln(f())
+	}
+
+Correct:
+
+	struct Counter {
+		n: int
+	}
+
+	impl Counter {
+		fn get(self): int {
+			ret self.n
+		}
+	}
+
+	fn main() {
+		let c = Counter{}
+		let f = fn(): int { ret c.get() }
+		outln(f())
+	}`,
+	`genericed_fn_as_anonymous_fn`: `Generic function is used as an anonymous function.
+Generic functions are instantiated for the given types at calls,
+so they cannot be used as function values.
+Wrap the call with an anonymous function instead.
+
+Wrong:
+
+	type[T]
+	fn id(x: T): T {
+		ret x
+	}
+
+	fn main() {
+		let f = id
+		outln(f(10))
+	}
+
+Correct:
+
+	type[T]
+	fn id(x: T): T {
+		ret x
+	}
+
+	fn main() {
+		let f = fn(x: int): int { ret id[int](x) }
+		outln(f(10))
+	}`,
+	`ref_used_struct_used_at_new_fn`: `Structure with reference fields is created with the new function.
+The new function initializes fields with default values,
+but reference fields must be initialized explicitly.
+Use a reference literal instead.
+
+Wrong:
+
+	struct Counter {
+		n: int
+	}
+
+	struct Holder {
+		counter: &Counter
+	}
+
+	fn main() {
+		let h = new(Holder)
+		outln(h)
+	}
+
+Correct:
+
+	struct Counter {
+		n: int
+	}
+
+	struct Holder {
+		counter: &Counter
+	}
+
+	fn main() {
+		let h = &Holder{&Counter{}}
+		outln(h.counter.n)
+	}`,
+	`reference_field_not_initialized`: `Reference field is not initialized.
+Reference fields of structures must be given in every structure literal.
+
+Wrong:
+
+	struct Counter {
+		n: int
+	}
+
+	struct Holder {
+		counter: &Counter
+	}
+
+	fn main() {
+		let h = Holder{}
+		outln(h)
+	}
+
+Correct:
+
+	struct Counter {
+		n: int
+	}
+
+	struct Holder {
+		counter: &Counter
+	}
+
+	fn main() {
+		let h = Holder{counter: &Counter{}}
+		outln(h.counter.n)
+	}`,
+	`illegal_cycle_in_declaration`: `Declaration refers to itself.
+Structures cannot contain themselves by value, because size of structure would be infinite.
+Use a reference or pointer for recursive structures.
+
+Wrong:
+
+	struct Node {
+		next: Node
+	}
+
+	fn main() {}
+
+Correct:
+
+	struct Node {
+		next: *Node
+	}
+
+	fn main() {}`,
+	`assignment_to_non_mut`: `Immutable variable is assigned.
+Variables are immutable by default.
+Use the mut keyword to declare a mutable variable.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		x = 20
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let mut x = 10
+		x = 20
+		outln(x)
+	}`,
+	`assignment_non_mut_to_mut`: `Immutable value of mutable type is assigned to a mutable variable.
+Slices, pointers and references share their data,
+so assigning them to a mutable variable would allow mutation of immutable data.
+Declare the source variable as mutable.
+
+Wrong:
+
+	fn main() {
+		let s = [1, 2, 3]
+		let mut t = s
+		t[0] = 10
+		outln(t)
+	}
+
+Correct:
+
+	fn main() {
+		let mut s = [1, 2, 3]
+		let mut t = s
+		t[0] = 10
+		outln(t)
+	}`,
+	`ret_with_mut_typed_non_mut`: `Immutable value of mutable type is returned.
+Slices, pointers and references share their data,
+so returning an immutable one would allow mutation of immutable data by the caller.
+Declare the returned variable as mutable.
+
+Wrong:
+
+	fn first(s: []int): []int {
+		ret s[:1]
+	}
+
+	fn main() {
+		outln(first([1, 2]))
+	}
+
+Correct:
+
+	fn first(mut s: []int): []int {
+		ret s[:1]
+	}
+
+	fn main() {
+		outln(first([1, 2]))
+	}`,
+	`mutable_operation_on_immutable`: `Mutable method is called with an immutable instance.
+Methods with a mut receiver modify the instance,
+so they can only be called with mutable variables.
+
+Wrong:
+
+	struct Counter {
+		n: int
+	}
+
+	impl Counter {
+		fn inc(mut &self) {
+			self.n++
+		}
+	}
+
+	fn main() {
+		let c = &Counter{}
+		c.inc()
+		outln(c.n)
+	}
+
+Correct:
+
+	struct Counter {
+		n: int
+	}
+
+	impl Counter {
+		fn inc(mut &self) {
+			self.n++
+		}
+	}
+
+	fn main() {
+		let mut c = &Counter{}
+		c.inc()
+		outln(c.n)
+	}`,
+	`trait_has_reference_parametered_function`: `Non-reference instance is assigned to a trait with reference methods.
+Methods with a &self receiver can only be called with references,
+so only references of the structure can be used as the trait.
+
+Wrong:
+
+	trait Shape {
+		fn area(&self): int
+	}
+
+	struct Square {
+		a: int
+	}
+
+	impl Shape for Square {
+		fn area(&self): int {
+			ret self.a * self.a
+		}
+	}
+
+	fn main() {
+		let s: Shape = Square{2}
+		outln(s.area())
+	}
+
+Correct:
+
+	trait Shape {
+		fn area(&self): int
+	}
+
+	struct Square {
+		a: int
+	}
+
+	impl Shape for Square {
+		fn area(&self): int {
+			ret self.a * self.a
+		}
+	}
+
+	fn main() {
+		let s: Shape = &Square{2}
+		outln(s.area())
+	}`,
+	`settings_invalid`: `Settings file is not valid.
+The jule.set file must be a JSON object with the settings keys.
+Values must have the type of their keys, like strings for compiler
+and lists of strings for tags.
+
+This jule.set file has a trailing comma:
+
+	{
+		"compiler": "clang",
+	}`,
+	`settings_invalid_key`: `Settings file has an unknown key.
+Keys of the jule.set file are out_dir, out_name, compiler, compiler_path,
+cxx_flags, ld_flags, libs, optimization, debug, line_directives, panic_trace,
+std, language, tags, sanitize, error_limit, warnings and werror.
+
+This jule.set file has a misspelled key:
+
+	{
+		"optimisation": "2"
+	}`,
+	`no_src_in_dir`: `Directory has no Jule source file.
+Packages are directories of source files with the .jule extension.
+Files excluded for the target by their names or build constraints are not counted.
+Give the path of a directory that has Jule source files, or a source file.`,
+	`invalid_build_expr`: `Build constraint expression is not valid.
+Build constraints are expressions of identifiers like linux, amd64 and tags
+with the !, && and || operators and parentheses.
+
+Wrong:
+
+	//jule:build linux &&
+
+	fn main() {}
+
+Correct:
+
+	//jule:build linux && amd64
+
+	fn main() {}`,
+	`error_limit_exceeded`: `Too many errors are reported.
+The compiler stops reporting errors after the error limit,
+and reports the count of errors that are not shown.
+Fixing the first errors often fixes the rest, since errors tend to cause more errors.
+The limit is set with the --error-limit option or error_limit key of the jule.set file,
+and a limit of 0 shows all errors.`,
+	`unknown_lint`: `Lint is not known.
+Names of lints are all, shadow, unreachable, empty_block, redundant_cast,
+const_compare, unused_use, unused, non_exhaustive, case_order, nil_deref and data_race.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		//jule:allow(shadowing)
+		if true {
+			let x = 20
+			outln(x)
+		}
+		outln(x)
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		//jule:allow(shadow)
+		if true {
+			let x = 20
+			outln(x)
+		}
+		outln(x)
+	}`,
+	`shadowed_var`: `Variable shadows a variable of an outer scope.
+Variables of inner scopes hide the variables with the same identifier,
+so uses in the inner scope refer to a different variable, which is often a mistake.
+This warning is reported by the shadow lint.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		if x > 5 {
+			let x = 20
+			outln(x)
+		}
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		if x > 5 {
+			let y = 20
+			outln(y)
+		}
+	}`,
+	`unreachable_code`: `Statement is never executed.
+Statements after return, break, continue, goto and panic calls cannot be reached.
+This warning is reported by the unreachable lint.
+
+Wrong:
+
+	fn main() {
+		ret
+		outln("Hello")
+	}
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+		ret
+	}`,
+	`empty_block`: `Block is empty.
+Empty blocks of conditions and iterations have no effect, which is often a mistake.
+This warning is reported by the empty_block lint, which is disabled by default.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		if x > 5 {}
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		if x > 5 {
+			outln(x)
+		}
+	}`,
+	`redundant_cast`: `Expression is cast to its own data-type.
+Cast has no effect, so it can be removed.
+This warning is reported by the redundant_cast lint.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		outln(int(x))
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		outln(x)
+	}`,
+	`comparison_always`: `Comparison has always the same result.
+Comparisons of an expression with itself and of constants are evaluated at compile time,
+so the condition has no effect, which is often a mistake.
+This warning is reported by the const_compare lint.
+
+Wrong:
+
+	fn main() {
+		let x = 10
+		if x == x {
+			outln(x)
+		}
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		if x == 10 {
+			outln(x)
+		}
+	}`,
+	`unused_use`: `Use declaration is not used.
+Packages that are not used by the file can be removed from use declarations.
+This warning is reported by the unused_use lint.
+
+Wrong:
+
+	use std::math
+
+	fn main() {
+		outln("Hello")
+	}
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+	}`,
+	`unused_use_selector`: `Selected definition of use declaration is not used.
+Definitions that are not used by the file can be removed from the selection.
+This warning is reported by the unused_use lint.
+
+Wrong:
+
+	use std::math::{PI, E}
+
+	fn main() {
+		outln(PI)
+	}
+
+Correct:
+
+	use std::math::{PI}
+
+	fn main() {
+		outln(PI)
+	}`,
+	`unused_define`: `Private definition is never used.
+Definitions which are not public and not used by the package can be removed.
+This warning is reported by the unused lint.
+
+Wrong:
+
+	fn helper() {}
+
+	fn main() {
+		outln("Hello")
+	}
+
+Correct:
+
+	fn main() {
+		outln("Hello")
+	}`,
+	`duplicate_case`: `Match has the same case more than once.
+Cases are checked in order, so a repeated case is never matched.
+
+Wrong:
+
+	fn main() {
+		let x = 2
+		match x {
+		case 1:
+			outln("one")
+		case 1:
+			outln("two")
+		}
+	}
+
+Correct:
+
+	fn main() {
+		let x = 2
+		match x {
+		case 1:
+			outln("one")
+		case 2:
+			outln("two")
+		}
+	}`,
+	`case_after_default`: `Case is written after the default case.
+The default case is always checked after all cases regardless of its position,
+so writing it last makes the order of checks clear.
+This warning is reported by the case_order lint.
+
+Wrong:
+
+	fn main() {
+		let x = 2
+		match x {
+		case 1:
+			outln("one")
+		default:
+			outln("other")
+		case 2:
+			outln("two")
+		}
+	}
+
+Correct:
+
+	fn main() {
+		let x = 2
+		match x {
+		case 1:
+			outln("one")
+		case 2:
+			outln("two")
+		default:
+			outln("other")
+		}
+	}`,
+	`non_exhaustive_match`: `Match of enum does not handle all items.
+Match statements of enums should have a case for every item or a default case.
+This warning is reported by the non_exhaustive lint.
+
+Wrong:
+
+	enum Color {
+		Red,
+		Green,
+	}
+
+	fn main() {
+		let c = Color.Red
+		match c {
+		case Color.Red:
+			outln("red")
+		}
+	}
+
+Correct:
+
+	enum Color {
+		Red,
+		Green,
+	}
+
+	fn main() {
+		let c = Color.Red
+		match c {
+		case Color.Red:
+			outln("red")
+		case Color.Green:
+			outln("green")
+		}
+	}`,
+	`nil_dereference`: `Pointer is always nil at the dereference.
+Dereference of a nil pointer panics at runtime.
+This warning is reported by the nil_deref lint.
+
+Wrong:
+
+	fn main() {
+		let p: *int = nil
+		unsafe {
+			outln(*p)
+		}
+	}
+
+Correct:
+
+	fn main() {
+		let x = 10
+		let p = &x
+		unsafe {
+			outln(*p)
+		}
+	}`,
+	`nil_call`: `Function value is always nil at the call.
+Call of a nil function panics at runtime.
+This warning is reported by the nil_deref lint.
+
+Wrong:
+
+	fn main() {
+		let f: fn() = nil
+		f()
+	}
+
+Correct:
+
+	fn main() {
+		let f = fn() { outln("Hello") }
+		f()
+	}`,
+	`nil_before_init`: `Global is used before it is assigned by the init function.
+Initializers of globals are evaluated before the init function,
+so globals assigned by init are still nil in initializers.
+This warning is reported by the nil_deref lint.
+
+Wrong:
+
+	let mut handler: fn(): int = nil
+	let value = handler()
+
+	fn init() {
+		handler = fn(): int { ret 1 }
+	}
+
+	fn main() {
+		outln(value)
+	}
+
+Correct:
+
+	let mut handler: fn(): int = nil
+	let mut value = 0
+
+	fn init() {
+		handler = fn(): int { ret 1 }
+		value = handler()
+	}
+
+	fn main() {
+		outln(value)
+	}`,
+	`co_ref_local`: `Reference of local variable is passed to a concurrent call.
+Concurrent calls may run after the function returns,
+so references of locals may refer to variables that no longer exist.
+Wait for the concurrent calls with std::sync::WaitGroup before the function returns.
+
+Wrong:
+
+	use std::sync::{WaitGroup}
+
+	fn inc(mut p: *int, mut wg: *WaitGroup) {
+		unsafe {
+			*p++
+			wg.done()
+		}
+	}
+
+	fn main() {
+		let mut n = 0
+		let mut wg = WaitGroup{}
+		wg.add(1)
+		co inc(&n, &wg)
+	}
+
+Correct:
+
+	use std::sync::{WaitGroup}
+
+	fn inc(mut p: *int, mut wg: *WaitGroup) {
+		unsafe {
+			*p++
+			wg.done()
+		}
+	}
+
+	fn main() {
+		let mut n = 0
+		let mut wg = WaitGroup{}
+		wg.add(1)
+		co inc(&n, &wg)
+		wg.wait()
+		outln(n)
+	}`,
+	`co_captured_local`: `Local variable is used in a concurrent call.
+Concurrent calls capture the frame of the function by reference,
+so locals may be read after the function returns and they no longer exist.
+Wait for the concurrent calls with std::sync::WaitGroup before the function returns.
+
+Wrong:
+
+	use std::sync::{WaitGroup}
+
+	let mut wg = WaitGroup{}
+
+	fn print(n: int) {
+		outln(n)
+		wg.done()
+	}
+
+	fn start() {
+		let n = 10
+		wg.add(1)
+		co print(n)
+	}
+
+	fn main() {
+		start()
+		wg.wait()
+	}
+
+Correct:
+
+	use std::sync::{WaitGroup}
+
+	let mut wg = WaitGroup{}
+
+	fn print(n: int) {
+		outln(n)
+		wg.done()
+	}
+
+	fn start() {
+		let n = 10
+		wg.add(1)
+		co print(n)
+		wg.wait()
+	}
+
+	fn main() {
+		start()
+	}`,
+	`data_race`: `Variable is written by a concurrent call and the function at the same time.
+Writes without synchronization may happen at the same time, so the result is undefined.
+Wait for the concurrent call before the write, or use functions of std::sync::atomic.
+This warning is reported by the data_race lint.
+
+Wrong:
+
+	use std::sync::{WaitGroup}
+
+	let mut total = 0
+	let mut wg = WaitGroup{}
+
+	fn work() {
+		total++
+		wg.done()
+	}
+
+	fn main() {
+		wg.add(1)
+		co work()
+		total++
+		wg.wait()
+		outln(total)
+	}
+
+Correct:
+
+	use std::sync::{WaitGroup}
+
+	let mut total = 0
+	let mut wg = WaitGroup{}
+
+	fn work() {
+		total++
+		wg.done()
+	}
+
+	fn main() {
+		wg.add(1)
+		co work()
+		wg.wait()
+		total++
+		outln(total)
+	}`,
+	`data_race_iter`: `Variable is written by concurrent calls of an iteration.
+Every iteration starts a new concurrent call, so the calls write the variable at the same time.
+Wait for every concurrent call in the iteration, or use functions of std::sync::atomic.
+This warning is reported by the data_race lint.
+
+Wrong:
+
+	use std::sync::{WaitGroup}
+
+	let mut total = 0
+	let mut wg = WaitGroup{}
+
+	fn main() {
+		for i in [1, 2, 3] {
+			wg.add(1)
+			co fn() {
+				total += i
+				wg.done()
+			}()
+		}
+		wg.wait()
+		outln(total)
+	}
+
+Correct:
+
+	use std::sync::{WaitGroup}
+
+	let mut total = 0
+	let mut wg = WaitGroup{}
+
+	fn main() {
+		for i in [1, 2, 3] {
+			wg.add(1)
+			co fn() {
+				total += i
+				wg.done()
+			}()
+			wg.wait()
+		}
+		outln(total)
+	}`,
+	`unknown_sanitizer`: `Sanitizer is not known.
+The --sanitize option and sanitize key of the jule.set file
+accept the address, undefined and thread sanitizers.
+
+This command has a misspelled sanitizer:
+
+	julec --sanitize adress main.jule`,
+	`incompatible_sanitizers`: `Sanitizers cannot be used together.
+ThreadSanitizer has its own shadow memory, so it cannot be used with AddressSanitizer.
+Compile with each sanitizer separately.
+
+This command uses both sanitizers:
+
+	julec --sanitize address,thread main.jule`,
+}
+
+// GetExplanation returns long-form explanation of error message.
+//
+// Special case is;
+//
+//	GetExplanation(key) -> returns empty string if explanation is not exist.
+func GetExplanation(key string) string { return EXPLANATIONS[key] }
@@ -1,12 +1,13 @@
 package jule
 
 // Jule constants.
-const VERSION       = `@development_channel`
-const SRC_EXT       = `.jule`
-const DOC_EXT       = SRC_EXT + "doc"
-const SETTINGS_FILE = "jule.set"
-const STDLIB        = "std"
-const LOCALIZATIONS = "localization"
+const VERSION          = `@development_channel`
+const SRC_EXT          = `.jule`
+const DOC_EXT          = SRC_EXT + "doc"
+const SETTINGS_FILE    = "jule.set"
+const STDLIB           = "std"
+const LOCALIZATIONS    = "localization"
+const EXPLANATIONS_DIR = "explain"
 
 const ENTRY_POINT = "main"
 const INIT_FN     = "init"
@@ -34,6 +35,7 @@ const ATTR_TYPEDEF = "typedef"
 
 const PREPROCESSOR_DIRECTIVE      = "pragma"
 const PREPROCESSOR_DIRECTIVE_ENOFI = "enofi"
+const PREPROCESSOR_DIRECTIVE_BUILD = "build"
 
 const MARK_ARRAY = "..."
 
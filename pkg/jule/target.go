@@ -0,0 +1,54 @@
+package jule
+
+import "runtime"
+
+// Target platform of compilation.
+// Defaults to host platform.
+var TARGET_OS string
+var TARGET_ARCH string
+
+// IsSupportedOS reports operating system is supported or not.
+func IsSupportedOS(os string) bool {
+	for _, dos := range DISTOS {
+		if os == dos {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSupportedArch reports architecture is supported or not.
+func IsSupportedArch(arch string) bool {
+	for _, darch := range DISTARCH {
+		if arch == darch {
+			return true
+		}
+	}
+	return false
+}
+
+// BitSizeOfArch returns bit size of architecture.
+//
+// Special case is;
+//
+//	BitSizeOfArch(arch) -> returns 0 if architecture is not supported.
+func BitSizeOfArch(arch string) int {
+	switch arch {
+	case ARCH_I386, ARCH_ARM:
+		return 32
+	case ARCH_AMD64, ARCH_ARM64:
+		return 64
+	default:
+		return 0
+	}
+}
+
+func init() {
+	TARGET_OS = runtime.GOOS
+	switch runtime.GOARCH {
+	case "386":
+		TARGET_ARCH = ARCH_I386
+	default:
+		TARGET_ARCH = runtime.GOARCH
+	}
+}
@@ -3,8 +3,8 @@ package julebits
 import "github.com/julelang/jule/pkg/juletype"
 
 // BitsizeType returns bit-size of
-// data type of specified type code.
-func BitsizeType(t uint8) int {
+// data type of specified type code for bit size of architecture.
+func BitsizeType(t uint8, bits int) int {
 	switch t {
 	case juletype.I8, juletype.U8:
 		return 0b1000
@@ -15,7 +15,7 @@ func BitsizeType(t uint8) int {
 	case juletype.I64, juletype.U64, juletype.F64:
 		return 0b01000000
 	case juletype.UINT, juletype.INT:
-		return juletype.BIT_SIZE
+		return bits
 	default:
 		return 0
 	}
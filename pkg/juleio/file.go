@@ -1,15 +1,104 @@
 package juleio
 
-import "path/filepath"
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
 
 // File instance of fs.
+// Data should not be changed after first use of line table.
 type File struct {
 	Dir  string
 	Name string
 	Data []rune
+
+	once  sync.Once
+	text  string
+	lines []int
+}
+
+// RuneLen returns byte length of rune in UTF-8 text.
+// Invalid runes are encoded as utf8.RuneError.
+func RuneLen(r rune) int {
+	n := utf8.RuneLen(r)
+	if n == -1 {
+		return utf8.RuneLen(utf8.RuneError)
+	}
+	return n
 }
 
 // Path returns full path of file.
 func (f *File) Path() string {
 	return filepath.Join(f.Dir, f.Name)
 }
+
+// table builds text and line table of file at first call.
+func (f *File) table() {
+	f.once.Do(func() {
+		f.text = string(f.Data)
+		f.lines = []int{0}
+		for i := 0; i < len(f.text); i++ {
+			if f.text[i] == '\n' {
+				f.lines = append(f.lines, i+1)
+			}
+		}
+	})
+}
+
+// Text returns UTF-8 text of data.
+// Byte offsets of file are offsets in text.
+func (f *File) Text() string {
+	f.table()
+	return f.text
+}
+
+// Lines returns line-offset table of file.
+// Table has byte offsets of beginning of lines,
+// offset of row is at index row-1.
+func (f *File) Lines() []int {
+	f.table()
+	return f.lines
+}
+
+// Position returns row and byte column of byte offset.
+// Rows and columns starts at 1.
+//
+// Special case is;
+//
+//	Position(offset) -> returns position of end of text if offset is out of text
+func (f *File) Position(offset int) (row, column int) {
+	f.table()
+	if offset < 0 {
+		offset = 0
+	} else if offset > len(f.text) {
+		offset = len(f.text)
+	}
+	row = sort.SearchInts(f.lines, offset+1)
+	return row, offset - f.lines[row-1] + 1
+}
+
+// Offset returns byte offset of row and byte column.
+// Rows and columns starts at 1.
+//
+// Special case is;
+//
+//	Offset(row, column) -> returns -1 if row is not exist
+func (f *File) Offset(row, column int) int {
+	f.table()
+	if row < 1 || row > len(f.lines) {
+		return -1
+	} else if column < 1 {
+		column = 1
+	}
+	offset := f.lines[row-1] + column - 1
+	if row < len(f.lines) && offset >= f.lines[row] {
+		// Columns are not exceeds line, newline is the last column.
+		return f.lines[row] - 1
+	}
+	if offset > len(f.text) {
+		return len(f.text)
+	}
+	return offset
+}
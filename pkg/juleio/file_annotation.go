@@ -2,34 +2,24 @@ package juleio
 
 import (
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/julelang/jule/pkg/jule"
 )
 
-const os_windows = "windows"
-const os_darwin = "darwin"
-const os_linux = "linux"
-
-const arch_i386 = "386"
-const arch_amd64 = "amd64"
-const arch_arm = "arm"
-const arch_arm64 = "arm64"
-
-func checkPlatform(path string) (ok bool, exist bool) {
+func checkPlatform(env *jule.Env, path string) (ok bool, exist bool) {
 	ok = false
 	exist = true
 	switch path {
 	case jule.OS_WINDOWS:
-		ok = runtime.GOOS == os_windows
+		ok = env.TargetOS == jule.OS_WINDOWS
 	case jule.OS_DARWIN:
-		ok = runtime.GOOS == os_darwin
+		ok = env.TargetOS == jule.OS_DARWIN
 	case jule.OS_LINUX:
-		ok = runtime.GOOS == os_linux
+		ok = env.TargetOS == jule.OS_LINUX
 	case jule.OS_UNIX:
-		switch runtime.GOOS {
-		case os_darwin, os_linux:
+		switch env.TargetOS {
+		case jule.OS_DARWIN, jule.OS_LINUX:
 			ok = true
 		}
 	default:
@@ -39,28 +29,22 @@ func checkPlatform(path string) (ok bool, exist bool) {
 	return
 }
 
-func checkArch(path string) (ok bool, exist bool) {
+func checkArch(env *jule.Env, path string) (ok bool, exist bool) {
 	ok = false
 	exist = true
 	switch path {
 	case jule.ARCH_I386:
-		ok = runtime.GOARCH == arch_i386
+		ok = env.TargetArch == jule.ARCH_I386
 	case jule.ARCH_AMD64:
-		ok = runtime.GOARCH == arch_amd64
+		ok = env.TargetArch == jule.ARCH_AMD64
 	case jule.ARCH_ARM:
-		ok = runtime.GOARCH == arch_arm
+		ok = env.TargetArch == jule.ARCH_ARM
 	case jule.ARCH_ARM64:
-		ok = runtime.GOARCH == arch_arm64
+		ok = env.TargetArch == jule.ARCH_ARM64
 	case jule.ARCH_64Bit:
-		switch runtime.GOARCH {
-		case arch_amd64, arch_arm64:
-			ok = true
-		}
+		ok = jule.BitSizeOfArch(env.TargetArch) == 64
 	case jule.ARCH_32Bit:
-		switch runtime.GOARCH {
-		case arch_i386, arch_arm:
-			ok = true
-		}
+		ok = jule.BitSizeOfArch(env.TargetArch) == 32
 	default:
 		ok = true
 		exist = false
@@ -71,7 +55,7 @@ func checkArch(path string) (ok bool, exist bool) {
 // IsPassFileAnnotation returns true
 // if file path is passes file annotation,
 // returns false if not.
-func IsPassFileAnnotation(p string) bool {
+func IsPassFileAnnotation(env *jule.Env, p string) bool {
 	p = filepath.Base(p)
 	n := len(p)
 	p = p[:n-len(filepath.Ext(p))]
@@ -104,25 +88,25 @@ func IsPassFileAnnotation(p string) bool {
 
 	
 	if a2 == "" {
-		ok, exist := checkPlatform(a1)
+		ok, exist := checkPlatform(env, a1)
 		if exist {
 			return ok
 		}
-		ok, exist = checkArch(a1)
+		ok, exist = checkArch(env, a1)
 		return !exist || ok
 	}
 	
-	ok, exist := checkArch(a1)
+	ok, exist := checkArch(env, a1)
 	if exist {
 		if !ok {
 			return false
 		}
-		ok, exist = checkPlatform(a2)
+		ok, exist = checkPlatform(env, a2)
 		return !exist || ok
 	}
 
 	// a1 is not architecture, for this reason bad couple pattern.
 	// Accept as one pattern, so a1 can be platform.
-	ok, exist = checkPlatform(a1)
+	ok, exist = checkPlatform(env, a1)
 	return !exist || ok
 }
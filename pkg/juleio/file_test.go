@@ -0,0 +1,101 @@
+package juleio
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Tab and ş are single rune, but ş is two bytes in UTF-8.
+const test_text = "fn main() {\n\tlet ş = 1\n}\n"
+
+func TestFileLines(t *testing.T) {
+	f := &File{Data: []rune(test_text)}
+	if f.Text() != test_text {
+		t.Errorf("got text %q, want %q", f.Text(), test_text)
+	}
+	want := []int{0, 12, 24, 26}
+	if lines := f.Lines(); !reflect.DeepEqual(lines, want) {
+		t.Errorf("got lines %v, want %v", lines, want)
+	}
+}
+
+func TestFilePosition(t *testing.T) {
+	f := &File{Data: []rune(test_text)}
+	tests := []struct {
+		offset int
+		row    int
+		column int
+	}{
+		{0, 1, 1},
+		{11, 1, 12},
+		{12, 2, 1},  // Tab.
+		{13, 2, 2},  // Just after tab.
+		{17, 2, 6},  // ş.
+		{19, 2, 8},  // Just after ş.
+		{23, 2, 12}, // Newline.
+		{24, 3, 1},
+		{26, 4, 1}, // End of text.
+		{-1, 1, 1},
+		{100, 4, 1},
+	}
+	for _, test := range tests {
+		row, column := f.Position(test.offset)
+		if row != test.row || column != test.column {
+			t.Errorf("position of %d is %d:%d, want %d:%d",
+				test.offset, row, column, test.row, test.column)
+		}
+	}
+}
+
+func TestFileOffset(t *testing.T) {
+	f := &File{Data: []rune(test_text)}
+	tests := []struct {
+		row    int
+		column int
+		offset int
+	}{
+		{1, 1, 0},
+		{2, 1, 12},
+		{2, 2, 13},
+		{2, 6, 17},
+		{2, 8, 19},
+		{2, 100, 23}, // Newline is the last column.
+		{3, 0, 24},
+		{4, 1, 26},
+		{4, 5, 26}, // Last line has not newline.
+		{0, 1, -1},
+		{5, 1, -1},
+	}
+	for _, test := range tests {
+		offset := f.Offset(test.row, test.column)
+		if offset != test.offset {
+			t.Errorf("offset of %d:%d is %d, want %d",
+				test.row, test.column, offset, test.offset)
+		}
+	}
+
+	// Offsets are same with offset of their positions.
+	for offset := 0; offset <= len(f.Text()); offset++ {
+		row, column := f.Position(offset)
+		if got := f.Offset(row, column); got != offset {
+			t.Errorf("offset of position %d:%d is %d, want %d", row, column, got, offset)
+		}
+	}
+}
+
+func TestFileInvalidUTF8(t *testing.T) {
+	// Invalid byte is encoded as utf8.RuneError, which is three bytes.
+	f := &File{Data: []rune("a\xffb\nc")}
+	if f.Text() != "a\uFFFDb\nc" {
+		t.Errorf("got text %q", f.Text())
+	}
+	if RuneLen(f.Data[1]) != 3 {
+		t.Errorf("got rune length %d, want 3", RuneLen(f.Data[1]))
+	}
+	if row, column := f.Position(4); row != 1 || column != 5 {
+		t.Errorf("position of 4 is %d:%d, want 1:5", row, column)
+	}
+	if offset := f.Offset(2, 1); offset != 6 {
+		t.Errorf("offset of 2:1 is %d, want 6", offset)
+	}
+}
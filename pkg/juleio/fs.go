@@ -0,0 +1,281 @@
+package juleio
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FS is the file system of source loading.
+// Methods are same with io/fs interfaces, but names are
+// operating system paths instead of slash separated names.
+type FS interface {
+	fs.FS
+	fs.ReadDirFS
+	fs.ReadFileFS
+	fs.StatFS
+}
+
+// OS is the file system of operating system.
+var OS FS = osFS{}
+
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error)          { return os.Open(name) }
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (osFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (osFS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+
+// MapFS is an in-memory file system of file contents by paths.
+// Directories are implied by paths of files.
+type MapFS map[string][]byte
+
+func (m MapFS) file(name string) ([]byte, bool) {
+	data, ok := m[name]
+	if !ok {
+		data, ok = m[filepath.Clean(name)]
+	}
+	return data, ok
+}
+
+// children returns entries of directory.
+// Reports false if directory is not exist.
+func (m MapFS) children(name string) ([]fs.DirEntry, bool) {
+	name = filepath.Clean(name)
+	exist := false
+	dirs := map[string]bool{}
+	var entries []fs.DirEntry
+	for path, data := range m {
+		rel, err := filepath.Rel(name, filepath.Clean(path))
+		if err != nil || rel == "." || rel == ".." ||
+			strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		exist = true
+		child, _, nested := strings.Cut(rel, string(filepath.Separator))
+		if !nested {
+			entries = append(entries, fs.FileInfoToDirEntry(fileInfo{child, int64(len(data)), false}))
+		} else if !dirs[child] {
+			dirs[child] = true
+			entries = append(entries, fs.FileInfoToDirEntry(fileInfo{child, 0, true}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, exist
+}
+
+func (m MapFS) Open(name string) (fs.File, error) {
+	if data, ok := m.file(name); ok {
+		return &memFile{
+			info:   fileInfo{filepath.Base(name), int64(len(data)), false},
+			Reader: bytes.NewReader(data),
+		}, nil
+	}
+	if entries, ok := m.children(name); ok {
+		return &memDir{fileInfo{filepath.Base(name), 0, true}, entries}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m MapFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if entries, ok := m.children(name); ok {
+		return entries, nil
+	}
+	return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m MapFS) ReadFile(name string) ([]byte, error) {
+	if data, ok := m.file(name); ok {
+		return append([]byte(nil), data...), nil
+	}
+	return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m MapFS) Stat(name string) (fs.FileInfo, error) {
+	if data, ok := m.file(name); ok {
+		return fileInfo{filepath.Base(name), int64(len(data)), false}, nil
+	}
+	if _, ok := m.children(name); ok {
+		return fileInfo{filepath.Base(name), 0, true}, nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+type fileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return fi.size }
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return fi.dir }
+func (fi fileInfo) Sys() any           { return nil }
+
+func (fi fileInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+type memFile struct {
+	info fileInfo
+	*bytes.Reader
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+type memDir struct {
+	info    fileInfo
+	entries []fs.DirEntry
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *memDir) Close() error               { return nil }
+
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+func (d *memDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+// Overlay is a file system that files of Upper shadows files of Lower.
+// Directories are merged, so entries of both file systems are listed.
+// For example, Upper can be open buffers of editor and Lower can be OS.
+type Overlay struct {
+	Upper FS
+	Lower FS
+}
+
+func (o Overlay) Open(name string) (fs.File, error) {
+	uinfo, uerr := o.Upper.Stat(name)
+	if uerr == nil && !uinfo.IsDir() {
+		return o.Upper.Open(name)
+	}
+	linfo, lerr := o.Lower.Stat(name)
+	switch {
+	case lerr != nil:
+		return o.Upper.Open(name)
+	case uerr != nil || !linfo.IsDir():
+		return o.Lower.Open(name)
+	}
+	// Directory of both file systems, entries are merged.
+	entries, err := o.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+	return &memDir{fileInfo{filepath.Base(name), 0, true}, entries}, nil
+}
+
+func (o Overlay) ReadDir(name string) ([]fs.DirEntry, error) {
+	upper, uerr := o.Upper.ReadDir(name)
+	lower, lerr := o.Lower.ReadDir(name)
+	if uerr != nil {
+		return lower, lerr
+	}
+	if lerr != nil {
+		return upper, nil
+	}
+	shadowed := make(map[string]bool, len(upper))
+	for _, e := range upper {
+		shadowed[e.Name()] = true
+	}
+	entries := upper
+	for _, e := range lower {
+		if !shadowed[e.Name()] {
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (o Overlay) ReadFile(name string) ([]byte, error) {
+	data, err := o.Upper.ReadFile(name)
+	if err == nil {
+		return data, nil
+	}
+	return o.Lower.ReadFile(name)
+}
+
+func (o Overlay) Stat(name string) (fs.FileInfo, error) {
+	info, err := o.Upper.Stat(name)
+	if err == nil {
+		return info, nil
+	}
+	return o.Lower.Stat(name)
+}
+
+// SubFS returns file system of fsys which is mounted at root path.
+// Paths under root are opened from slash separated names of fsys.
+// For example, sources embedded by embed.FS can be mounted.
+func SubFS(root string, fsys fs.FS) FS { return subFS{filepath.Clean(root), fsys} }
+
+type subFS struct {
+	root string
+	fsys fs.FS
+}
+
+// name returns name of path in file system.
+func (s subFS) name(op, path string) (string, error) {
+	rel, err := filepath.Rel(s.root, filepath.Clean(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &fs.PathError{Op: op, Path: path, Err: fs.ErrNotExist}
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func (s subFS) Open(path string) (fs.File, error) {
+	name, err := s.name("open", path)
+	if err != nil {
+		return nil, err
+	}
+	return s.fsys.Open(name)
+}
+
+func (s subFS) ReadDir(path string) ([]fs.DirEntry, error) {
+	name, err := s.name("readdir", path)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadDir(s.fsys, name)
+}
+
+func (s subFS) ReadFile(path string) ([]byte, error) {
+	name, err := s.name("read", path)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(s.fsys, name)
+}
+
+func (s subFS) Stat(path string) (fs.FileInfo, error) {
+	name, err := s.name("stat", path)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(s.fsys, name)
+}
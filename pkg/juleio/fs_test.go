@@ -0,0 +1,206 @@
+package juleio
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+// fs_test is the wanted state of path in file system.
+type fs_test struct {
+	path string
+	// Names of entries if path is directory,
+	// names of directories have slash suffix.
+	entries []string
+	// Content of file if path is file.
+	data string
+	// Path is not exist.
+	not_exist bool
+}
+
+// test_fs checks all methods of file system with paths of tests.
+func test_fs(t *testing.T, fsys FS, tests []fs_test) {
+	t.Helper()
+	for _, test := range tests {
+		path := filepath.FromSlash(test.path)
+		if test.not_exist {
+			check_not_exist(t, fsys, path)
+			continue
+		}
+		info, err := fsys.Stat(path)
+		if err != nil {
+			t.Errorf("stat %s: %v", path, err)
+			continue
+		}
+		dir := test.entries != nil
+		if info.IsDir() != dir {
+			t.Errorf("stat %s: directory is %v, want %v", path, info.IsDir(), dir)
+			continue
+		}
+		if dir {
+			check_dir(t, fsys, path, test.entries)
+		} else {
+			check_file(t, fsys, path, test.data)
+		}
+	}
+}
+
+func check_not_exist(t *testing.T, fsys FS, path string) {
+	t.Helper()
+	_, err := fsys.Stat(path)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("stat %s: got error %v, want %v", path, err, fs.ErrNotExist)
+	}
+	_, err = fsys.ReadFile(path)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("read %s: got error %v, want %v", path, err, fs.ErrNotExist)
+	}
+	_, err = fsys.ReadDir(path)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("readdir %s: got error %v, want %v", path, err, fs.ErrNotExist)
+	}
+	_, err = fsys.Open(path)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("open %s: got error %v, want %v", path, err, fs.ErrNotExist)
+	}
+}
+
+func entry_names(entries []fs.DirEntry) []string {
+	names := []string{}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() {
+			name += "/"
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func check_dir(t *testing.T, fsys FS, path string, want []string) {
+	t.Helper()
+	entries, err := fsys.ReadDir(path)
+	if err != nil {
+		t.Errorf("readdir %s: %v", path, err)
+	} else if names := entry_names(entries); !reflect.DeepEqual(names, want) {
+		t.Errorf("readdir %s: got entries %v, want %v", path, names, want)
+	}
+	f, err := fsys.Open(path)
+	if err != nil {
+		t.Errorf("open %s: %v", path, err)
+		return
+	}
+	defer f.Close()
+	dir, ok := f.(fs.ReadDirFile)
+	if !ok {
+		t.Errorf("open %s: directory is not readable", path)
+		return
+	}
+	entries, err = dir.ReadDir(-1)
+	if err != nil {
+		t.Errorf("open %s: readdir: %v", path, err)
+	} else if names := entry_names(entries); !reflect.DeepEqual(names, want) {
+		t.Errorf("open %s: got entries %v, want %v", path, names, want)
+	}
+}
+
+func check_file(t *testing.T, fsys FS, path string, want string) {
+	t.Helper()
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		t.Errorf("read %s: %v", path, err)
+	} else if string(data) != want {
+		t.Errorf("read %s: got %q, want %q", path, data, want)
+	}
+	f, err := fsys.Open(path)
+	if err != nil {
+		t.Errorf("open %s: %v", path, err)
+		return
+	}
+	defer f.Close()
+	data, err = io.ReadAll(f)
+	if err != nil {
+		t.Errorf("open %s: read: %v", path, err)
+	} else if string(data) != want {
+		t.Errorf("open %s: got %q, want %q", path, data, want)
+	}
+}
+
+func TestMapFS(t *testing.T) {
+	fsys := MapFS{
+		filepath.FromSlash("/jule/src/main.jule"):      []byte("fn main() {}"),
+		filepath.FromSlash("/jule/src/lib/lib.jule"):   []byte("fn lib() {}"),
+		filepath.FromSlash("/jule/src/lib/empty.jule"): nil,
+	}
+	test_fs(t, fsys, []fs_test{
+		{path: "/jule/src/main.jule", data: "fn main() {}"},
+		{path: "/jule/src/./lib/../main.jule", data: "fn main() {}"},
+		{path: "/jule/src/lib/empty.jule", data: ""},
+		{path: "/jule", entries: []string{"src/"}},
+		{path: "/jule/src", entries: []string{"lib/", "main.jule"}},
+		{path: "/jule/src/lib/", entries: []string{"empty.jule", "lib.jule"}},
+		{path: "/jule/src/none.jule", not_exist: true},
+		{path: "/jule/std", not_exist: true},
+		{path: "/jule/src/main.jule/x", not_exist: true},
+	})
+
+	// Contents are copied, so file system is not changed by readers.
+	path := filepath.FromSlash("/jule/src/main.jule")
+	data, _ := fsys.ReadFile(path)
+	data[0] = 'x'
+	if string(fsys[path]) != "fn main() {}" {
+		t.Errorf("file is changed by reader: %q", fsys[path])
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	fsys := Overlay{
+		Upper: MapFS{
+			filepath.FromSlash("/jule/src/main.jule"): []byte("upper"),
+			filepath.FromSlash("/jule/src/new.jule"):  []byte("new"),
+		},
+		Lower: MapFS{
+			filepath.FromSlash("/jule/src/main.jule"):    []byte("lower"),
+			filepath.FromSlash("/jule/src/old.jule"):     []byte("old"),
+			filepath.FromSlash("/jule/src/lib/lib.jule"): []byte("lib"),
+			filepath.FromSlash("/jule/std/std.jule"):     []byte("std"),
+		},
+	}
+	test_fs(t, fsys, []fs_test{
+		{path: "/jule/src/main.jule", data: "upper"},
+		{path: "/jule/src/new.jule", data: "new"},
+		{path: "/jule/src/old.jule", data: "old"},
+		{path: "/jule/src/lib/lib.jule", data: "lib"},
+		{path: "/jule", entries: []string{"src/", "std/"}},
+		{path: "/jule/src", entries: []string{"lib/", "main.jule", "new.jule", "old.jule"}},
+		{path: "/jule/src/lib", entries: []string{"lib.jule"}},
+		{path: "/jule/std", entries: []string{"std.jule"}},
+		{path: "/jule/src/none.jule", not_exist: true},
+		{path: "/jule/doc", not_exist: true},
+	})
+}
+
+func TestSubFS(t *testing.T) {
+	fsys := SubFS(filepath.FromSlash("/jule/std/"), fstest.MapFS{
+		"math/math.jule":      {Data: []byte("math")},
+		"math/bits/bits.jule": {Data: []byte("bits")},
+		"std.jule":            {Data: []byte("std")},
+	})
+	test_fs(t, fsys, []fs_test{
+		{path: "/jule/std/std.jule", data: "std"},
+		{path: "/jule/std/math/math.jule", data: "math"},
+		{path: "/jule/std/math/../math/bits/bits.jule", data: "bits"},
+		{path: "/jule/std", entries: []string{"math/", "std.jule"}},
+		{path: "/jule/std/math", entries: []string{"bits/", "math.jule"}},
+		{path: "/jule/std/none.jule", not_exist: true},
+		// Paths out of root are not exist.
+		{path: "/jule", not_exist: true},
+		{path: "/jule/src/main.jule", not_exist: true},
+		{path: "/jule/std/../src/main.jule", not_exist: true},
+		{path: "/jule/stdlib/std.jule", not_exist: true},
+	})
+}
@@ -2,19 +2,18 @@ package juleio
 
 import (
 	"errors"
-	"os"
 	"path/filepath"
 
 	"github.com/julelang/jule/pkg/jule"
 )
 
-// Jopen returns Jule source file.
-func Jopen(path string) (*File, error) {
+// Jopen returns Jule source file of file system.
+func Jopen(fsys FS, path string) (*File, error) {
 	path, _ = filepath.Abs(path)
 	if filepath.Ext(path) != jule.SRC_EXT {
 		return nil, errors.New(jule.GetError("file_not_jule", path))
 	}
-	bytes, err := os.ReadFile(path)
+	bytes, err := fsys.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,128 @@
+package julelint
+
+import "sort"
+
+// Lint names.
+const SHADOW         = "shadow"
+const UNREACHABLE    = "unreachable"
+const EMPTY_BLOCK    = "empty_block"
+const REDUNDANT_CAST = "redundant_cast"
+const CONST_COMPARE  = "const_compare"
+const UNUSED_USE     = "unused_use"
+const UNUSED         = "unused"
+const NON_EXHAUSTIVE = "non_exhaustive"
+const CASE_ORDER     = "case_order"
+const NIL_DEREF      = "nil_deref"
+const DATA_RACE      = "data_race"
+
+// ALL is the name for all lints in lint configurations.
+const ALL = "all"
+
+// ALLOW_PRAGMA is the pragma of lint suppression.
+//
+// Syntax is;
+//
+//	//jule:allow(name, ...)
+const ALLOW_PRAGMA = "allow"
+
+// LINTS is the map of lint names and default states.
+var LINTS = map[string]bool{
+	SHADOW:         true,
+	UNREACHABLE:    true,
+	EMPTY_BLOCK:    false,
+	REDUNDANT_CAST: true,
+	CONST_COMPARE:  true,
+	UNUSED_USE:     true,
+	UNUSED:         true,
+	NON_EXHAUSTIVE: true,
+	CASE_ORDER:     true,
+	NIL_DEREF:      true,
+	DATA_RACE:      true,
+}
+
+// IsLint reports name is lint name or not.
+func IsLint(name string) bool {
+	_, ok := LINTS[name]
+	return ok
+}
+
+// Names returns sorted names of all lints.
+func Names() []string {
+	names := make([]string, 0, len(LINTS))
+	for name := range LINTS {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Config is the lint configuration of a compilation.
+type Config struct {
+	// Werror reports warnings of lints are errors or not.
+	Werror bool
+
+	// States of lints, uses default state if lint is not exist.
+	states map[string]bool
+	// Lints that reported as errors.
+	errors map[string]bool
+}
+
+// NewConfig returns new configuration with default states.
+func NewConfig() *Config {
+	return &Config{
+		states: map[string]bool{},
+		errors: map[string]bool{},
+	}
+}
+
+// Set sets state of lint.
+// Sets state of all lints if name is ALL.
+//
+// Special case is;
+//
+//	Set(name, state) -> returns false if name is not lint name.
+func (c *Config) Set(name string, state bool) bool {
+	if name == ALL {
+		for name := range LINTS {
+			c.states[name] = state
+		}
+		return true
+	}
+	if !IsLint(name) {
+		return false
+	}
+	c.states[name] = state
+	return true
+}
+
+// IsEnabled reports lint is enabled or not.
+func (c *Config) IsEnabled(name string) bool {
+	state, ok := c.states[name]
+	if !ok {
+		return LINTS[name]
+	}
+	return state
+}
+
+// SetError sets lint is reported as error or not.
+// Sets all lints if name is ALL.
+//
+// Special case is;
+//
+//	SetError(name, state) -> returns false if name is not lint name.
+func (c *Config) SetError(name string, state bool) bool {
+	if name == ALL {
+		for name := range LINTS {
+			c.errors[name] = state
+		}
+		return true
+	}
+	if !IsLint(name) {
+		return false
+	}
+	c.errors[name] = state
+	return true
+}
+
+// IsError reports lint is reported as error or not.
+func (c *Config) IsError(name string) bool { return c.Werror || c.errors[name] }
@@ -0,0 +1,152 @@
+package julelint
+
+import "testing"
+
+// all_disabled returns states of all lints which are disabled
+// except enabled lints.
+func all_disabled(enabled ...string) map[string]bool {
+	states := map[string]bool{}
+	for name := range LINTS {
+		states[name] = false
+	}
+	for _, name := range enabled {
+		states[name] = true
+	}
+	return states
+}
+
+func TestConfig(t *testing.T) {
+	type set struct {
+		name  string
+		state bool
+		ok    bool
+	}
+	tests := []struct {
+		name string
+		sets []set
+		// Wanted states of lints, other lints have default states.
+		enabled map[string]bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:    "enable",
+			sets:    []set{{EMPTY_BLOCK, true, true}},
+			enabled: map[string]bool{EMPTY_BLOCK: true},
+		},
+		{
+			name:    "disable",
+			sets:    []set{{SHADOW, false, true}},
+			enabled: map[string]bool{SHADOW: false},
+		},
+		{
+			name:    "disable all",
+			sets:    []set{{ALL, false, true}},
+			enabled: all_disabled(),
+		},
+		{
+			name:    "override all",
+			sets:    []set{{ALL, false, true}, {UNUSED, true, true}},
+			enabled: all_disabled(UNUSED),
+		},
+		{
+			name:    "last wins",
+			sets:    []set{{UNUSED, false, true}, {UNUSED, true, true}},
+			enabled: map[string]bool{UNUSED: true},
+		},
+		{
+			name: "unknown lint",
+			sets: []set{{"shadowing", false, false}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewConfig()
+			for _, s := range test.sets {
+				if ok := c.Set(s.name, s.state); ok != s.ok {
+					t.Errorf("Set(%q) = %v, want %v", s.name, ok, s.ok)
+				}
+			}
+			for _, name := range Names() {
+				want, ok := test.enabled[name]
+				if !ok {
+					want = LINTS[name]
+				}
+				if got := c.IsEnabled(name); got != want {
+					t.Errorf("IsEnabled(%q) = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		werror bool
+		errors []string
+		// Lints that wanted as errors.
+		want []string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:   "werror",
+			werror: true,
+			want:   Names(),
+		},
+		{
+			name:   "lint",
+			errors: []string{DATA_RACE},
+			want:   []string{DATA_RACE},
+		},
+		{
+			name:   "all",
+			errors: []string{ALL},
+			want:   Names(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewConfig()
+			c.Werror = test.werror
+			for _, name := range test.errors {
+				if !c.SetError(name, true) {
+					t.Fatalf("SetError(%q) = false", name)
+				}
+			}
+			want := map[string]bool{}
+			for _, name := range test.want {
+				want[name] = true
+			}
+			for _, name := range Names() {
+				if got := c.IsError(name); got != want[name] {
+					t.Errorf("IsError(%q) = %v, want %v", name, got, want[name])
+				}
+			}
+		})
+	}
+	if NewConfig().SetError("shadowing", true) {
+		t.Error("SetError of unknown lint = true")
+	}
+}
+
+func TestNames(t *testing.T) {
+	names := Names()
+	if len(names) != len(LINTS) {
+		t.Fatalf("got %d names, want %d", len(names), len(LINTS))
+	}
+	for i, name := range names {
+		if !IsLint(name) {
+			t.Errorf("IsLint(%q) = false", name)
+		}
+		if i > 0 && names[i-1] >= name {
+			t.Errorf("names are not sorted: %v", names)
+		}
+	}
+	if IsLint(ALL) {
+		t.Error("IsLint(ALL) = true")
+	}
+}
@@ -0,0 +1,230 @@
+package julelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const sarif_schema = "https://json.schemastore.org/sarif-2.1.0.json"
+const sarif_version = "2.1.0"
+
+type jlabel struct {
+	Path      string `json:"path"`
+	Row       int    `json:"row"`
+	Column    int    `json:"column"`
+	EndColumn int    `json:"end_column,omitempty"`
+	Message   string `json:"message"`
+}
+
+type jlog struct {
+	Severity  string   `json:"severity"`
+	Path      string   `json:"path,omitempty"`
+	Row       int      `json:"row,omitempty"`
+	Column    int      `json:"column,omitempty"`
+	EndRow    int      `json:"end_row,omitempty"`
+	EndColumn int      `json:"end_column,omitempty"`
+	Code      string   `json:"code,omitempty"`
+	Key       string   `json:"key,omitempty"`
+	Lint      string   `json:"lint,omitempty"`
+	Message   string   `json:"message"`
+	Args      []string `json:"args"`
+	Labels    []jlabel `json:"labels,omitempty"`
+	Notes     []string `json:"notes,omitempty"`
+}
+
+// Severity returns severity of log as "error" or "warning".
+func (clog *CompilerLog) Severity() string {
+	switch clog.Type {
+	case FLAT_WARN, WARN:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+func fmt_args(args []any) []string {
+	sargs := make([]string, len(args))
+	for i, arg := range args {
+		switch t := arg.(type) {
+		case rune:
+			sargs[i] = string(t)
+		default:
+			sargs[i] = fmt.Sprint(t)
+		}
+	}
+	return sargs
+}
+
+// Json returns logs in JSON format.
+func Json(logs []CompilerLog) (string, error) {
+	jlogs := make([]jlog, len(logs))
+	for i, clog := range logs {
+		jlogs[i] = jlog{
+			Severity:  clog.Severity(),
+			Path:      clog.Path,
+			Row:       clog.Row,
+			Column:    clog.Column,
+			EndRow:    clog.EndRow,
+			EndColumn: clog.EndColumn,
+			Code:      clog.Code(),
+			Key:       clog.Key,
+			Lint:      clog.Lint,
+			Message:   clog.Message,
+			Args:      fmt_args(clog.Args),
+			Notes:     clog.Notes,
+		}
+		for _, l := range clog.Labels {
+			end_column := l.EndColumn
+			if end_column < 0 {
+				end_column = 0
+			}
+			jlogs[i].Labels = append(jlogs[i].Labels, jlabel{l.Path, l.Row, l.Column, end_column, l.Message})
+		}
+	}
+	bytes, err := json.MarshalIndent(jlogs, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+type sarif_text struct {
+	Text string `json:"text"`
+}
+
+type sarif_rule struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarif_driver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version"`
+	InformationUri string       `json:"informationUri"`
+	Rules          []sarif_rule `json:"rules"`
+}
+
+type sarif_tool struct {
+	Driver sarif_driver `json:"driver"`
+}
+
+type sarif_region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarif_artifact struct {
+	Uri string `json:"uri"`
+}
+
+type sarif_physical_location struct {
+	ArtifactLocation sarif_artifact `json:"artifactLocation"`
+	Region           sarif_region   `json:"region"`
+}
+
+type sarif_location struct {
+	PhysicalLocation sarif_physical_location `json:"physicalLocation"`
+	Message          *sarif_text             `json:"message,omitempty"`
+}
+
+type sarif_result struct {
+	RuleId           string           `json:"ruleId,omitempty"`
+	Level            string           `json:"level"`
+	Message          sarif_text       `json:"message"`
+	Locations        []sarif_location `json:"locations,omitempty"`
+	RelatedLocations []sarif_location `json:"relatedLocations,omitempty"`
+}
+
+type sarif_run struct {
+	Tool    sarif_tool     `json:"tool"`
+	Results []sarif_result `json:"results"`
+}
+
+type sarif_log struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []sarif_run `json:"runs"`
+}
+
+// sarif_uri returns uri of path relative to root if possible.
+func sarif_uri(path, root string) string {
+	rel, err := filepath.Rel(root, path)
+	if err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return "file://" + filepath.ToSlash(path)
+}
+
+// Sarif returns logs in SARIF 2.1.0 format.
+// Paths of logs are relative to root if possible.
+func Sarif(logs []CompilerLog, name, version, root string) (string, error) {
+	run := sarif_run{
+		Tool: sarif_tool{
+			Driver: sarif_driver{
+				Name:           name,
+				Version:        version,
+				InformationUri: "https://github.com/julelang/jule",
+				Rules:          []sarif_rule{},
+			},
+		},
+		Results: []sarif_result{},
+	}
+	rules := map[string]bool{}
+	for _, clog := range logs {
+		code := clog.Code()
+		if code != "" && !rules[code] {
+			rules[code] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarif_rule{code, clog.Key})
+		}
+		msg := clog.Message
+		for _, note := range clog.Notes {
+			msg += "\nnote: " + note
+		}
+		result := sarif_result{
+			RuleId:  code,
+			Level:   clog.Severity(),
+			Message: sarif_text{msg},
+		}
+		switch clog.Type {
+		case ERR, WARN:
+			result.Locations = []sarif_location{{
+				PhysicalLocation: sarif_physical_location{
+					ArtifactLocation: sarif_artifact{sarif_uri(clog.Path, root)},
+					Region:           sarif_region{clog.Row, clog.Column, clog.EndRow, clog.EndColumn},
+				},
+			}}
+		}
+		for _, l := range clog.Labels {
+			end_line := 0
+			if l.EndColumn > 0 {
+				end_line = l.Row
+			}
+			end_column := l.EndColumn
+			if end_column < 0 {
+				end_column = 0
+			}
+			result.RelatedLocations = append(result.RelatedLocations, sarif_location{
+				PhysicalLocation: sarif_physical_location{
+					ArtifactLocation: sarif_artifact{sarif_uri(l.Path, root)},
+					Region:           sarif_region{l.Row, l.Column, end_line, end_column},
+				},
+				Message: &sarif_text{l.Message},
+			})
+		}
+		run.Results = append(run.Results, result)
+	}
+	log := sarif_log{
+		Schema:  sarif_schema,
+		Version: sarif_version,
+		Runs:    []sarif_run{run},
+	}
+	bytes, err := json.MarshalIndent(log, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,222 @@
+package julelog
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/julelang/jule/pkg/jule"
+)
+
+var test_root = filepath.FromSlash("/project")
+var test_path = filepath.Join(test_root, "src", "main.jule")
+
+var test_logs = []CompilerLog{
+	{
+		Type:    FLAT_ERR,
+		Message: "entry point (main) function is not defined",
+		Key:     "no_entry_point",
+	},
+	{
+		Type:      ERR,
+		Row:       3,
+		Column:    5,
+		EndRow:    3,
+		EndColumn: 8,
+		Path:      test_path,
+		Message:   "identifier is already exist: x",
+		Key:       "exist_id",
+		Args:      []any{"x", 'r', 10},
+		Labels: []Label{{
+			Row:       1,
+			Column:    6,
+			EndColumn: -1,
+			Path:      test_path,
+			Message:   "previous declaration here",
+		}},
+		Notes: []string{"first note", "second note"},
+	},
+	{
+		Type:    WARN,
+		Row:     7,
+		Column:  2,
+		Path:    filepath.FromSlash("/other/lib.jule"),
+		Message: "comparison is always true",
+		Key:     "comparison_always",
+		Args:    []any{"true"},
+		Lint:    "const_compare",
+	},
+	{
+		Type:    FLAT_WARN,
+		Message: "flat warning",
+	},
+}
+
+func TestJson(t *testing.T) {
+	s, err := Json(test_logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]any
+	err = json.Unmarshal([]byte(s), &got)
+	if err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	want := []map[string]any{
+		{
+			"severity": "error",
+			"code":     jule.GetCode("no_entry_point"),
+			"key":      "no_entry_point",
+			"message":  "entry point (main) function is not defined",
+			"args":     []any{},
+		},
+		{
+			"severity":   "error",
+			"path":       test_path,
+			"row":        3.0,
+			"column":     5.0,
+			"end_row":    3.0,
+			"end_column": 8.0,
+			"code":       jule.GetCode("exist_id"),
+			"key":        "exist_id",
+			"message":    "identifier is already exist: x",
+			"args":       []any{"x", "r", "10"},
+			"labels": []any{map[string]any{
+				"path":    test_path,
+				"row":     1.0,
+				"column":  6.0,
+				"message": "previous declaration here",
+			}},
+			"notes": []any{"first note", "second note"},
+		},
+		{
+			"severity": "warning",
+			"path":     filepath.FromSlash("/other/lib.jule"),
+			"row":      7.0,
+			"column":   2.0,
+			"code":     jule.GetCode("comparison_always"),
+			"key":      "comparison_always",
+			"lint":     "const_compare",
+			"message":  "comparison is always true",
+			"args":     []any{"true"},
+		},
+		{
+			"severity": "warning",
+			"message":  "flat warning",
+			"args":     []any{},
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d logs, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("log %d:\ngot  %v\nwant %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestJsonEmpty(t *testing.T) {
+	s, err := Json(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "[]" {
+		t.Errorf("got %q, want %q", s, "[]")
+	}
+}
+
+func TestSarif(t *testing.T) {
+	s, err := Sarif(test_logs, "julec", "v1", test_root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got sarif_log
+	err = json.Unmarshal([]byte(s), &got)
+	if err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if got.Schema != sarif_schema || got.Version != sarif_version {
+		t.Errorf("got schema %q and version %q", got.Schema, got.Version)
+	}
+	if len(got.Runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(got.Runs))
+	}
+	run := got.Runs[0]
+	if run.Tool.Driver.Name != "julec" || run.Tool.Driver.Version != "v1" {
+		t.Errorf("got driver %+v", run.Tool.Driver)
+	}
+	want_rules := []sarif_rule{
+		{jule.GetCode("no_entry_point"), "no_entry_point"},
+		{jule.GetCode("exist_id"), "exist_id"},
+		{jule.GetCode("comparison_always"), "comparison_always"},
+	}
+	if !reflect.DeepEqual(run.Tool.Driver.Rules, want_rules) {
+		t.Errorf("got rules %v, want %v", run.Tool.Driver.Rules, want_rules)
+	}
+	location := func(uri string, region sarif_region, msg *sarif_text) sarif_location {
+		return sarif_location{
+			PhysicalLocation: sarif_physical_location{
+				ArtifactLocation: sarif_artifact{uri},
+				Region:           region,
+			},
+			Message: msg,
+		}
+	}
+	want := []sarif_result{
+		{
+			RuleId:  jule.GetCode("no_entry_point"),
+			Level:   "error",
+			Message: sarif_text{"entry point (main) function is not defined"},
+		},
+		{
+			RuleId:  jule.GetCode("exist_id"),
+			Level:   "error",
+			Message: sarif_text{"identifier is already exist: x\nnote: first note\nnote: second note"},
+			Locations: []sarif_location{
+				location("src/main.jule", sarif_region{3, 5, 3, 8}, nil),
+			},
+			RelatedLocations: []sarif_location{
+				location("src/main.jule", sarif_region{1, 6, 0, 0}, &sarif_text{"previous declaration here"}),
+			},
+		},
+		{
+			RuleId:  jule.GetCode("comparison_always"),
+			Level:   "warning",
+			Message: sarif_text{"comparison is always true"},
+			Locations: []sarif_location{
+				location("file:///other/lib.jule", sarif_region{7, 2, 0, 0}, nil),
+			},
+		},
+		{
+			Level:   "warning",
+			Message: sarif_text{"flat warning"},
+		},
+	}
+	if len(run.Results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(run.Results), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(run.Results[i], want[i]) {
+			t.Errorf("result %d:\ngot  %+v\nwant %+v", i, run.Results[i], want[i])
+		}
+	}
+}
+
+func TestSarifUri(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{test_path, "src/main.jule"},
+		{filepath.Join(test_root, "main.jule"), "main.jule"},
+		{filepath.FromSlash("/other/main.jule"), "file:///other/main.jule"},
+	}
+	for _, test := range tests {
+		got := sarif_uri(test.path, test_root)
+		if got != test.want {
+			t.Errorf("sarif_uri(%q) = %q, want %q", test.path, got, test.want)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package julelog
 import (
 	"fmt"
 	"strings"
+
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/juleio"
 )
 
 // Log types.
@@ -13,16 +16,54 @@ const WARN      uint8 = 3
 
 const warningMark = "<!>"
 
+// Label is a secondary position of log.
+type Label struct {
+	Row       int
+	Column    int
+	EndColumn int
+	Path      string
+	File      *juleio.File
+	Message   string
+}
+
 // CompilerLog is a compiler log.
 type CompilerLog struct {
-	Type    uint8
-	Row     int
-	Column  int
-	Path    string
-	Message string
+	Type      uint8
+	Row       int
+	Column    int
+	EndRow    int // Zero if not exist.
+	EndColumn int // Zero if not exist.
+	Path      string
+	File      *juleio.File // Source file of log, nil if not exist.
+	Message   string
+	Key       string   // Key of message in error list, empty if not exist.
+	Args      []any    // Arguments of message.
+	Labels    []Label  // Secondary positions.
+	Notes     []string // Additional notes.
+	Lint      string   // Name of lint, empty if log is not a lint.
+}
+
+// Code returns public code of log.
+//
+// Special case is;
+//
+//	Code() -> returns empty string if log has not key.
+func (clog *CompilerLog) Code() string { return jule.GetCode(clog.Key) }
+
+// message returns message with code and lint if exist.
+func (clog *CompilerLog) message() string {
+	msg := clog.Message
+	if clog.Lint != "" {
+		msg += " [-W" + clog.Lint + "]"
+	}
+	code := clog.Code()
+	if code == "" {
+		return msg
+	}
+	return "[" + code + "] " + msg
 }
 
-func (clog *CompilerLog) flatError() string { return clog.Message }
+func (clog *CompilerLog) flatError() string { return clog.message() }
 
 func (clog *CompilerLog) error() string {
 	var log strings.Builder
@@ -32,12 +73,12 @@ func (clog *CompilerLog) error() string {
 	log.WriteByte(':')
 	log.WriteString(fmt.Sprint(clog.Column))
 	log.WriteByte(' ')
-	log.WriteString(clog.Message)
+	log.WriteString(clog.message())
 	return log.String()
 }
 
 func (clog *CompilerLog) flatWarning() string {
-	return warningMark + " " + clog.Message
+	return warningMark + " " + clog.message()
 }
 
 func (clog *CompilerLog) warning() string {
@@ -50,7 +91,7 @@ func (clog *CompilerLog) warning() string {
 	log.WriteByte(':')
 	log.WriteString(fmt.Sprint(clog.Column))
 	log.WriteByte(' ')
-	log.WriteString(clog.Message)
+	log.WriteString(clog.message())
 	return log.String()
 }
 
@@ -0,0 +1,182 @@
+package julelog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/julelang/jule/pkg/juleio"
+)
+
+// ANSI escape sequences for colored rendering.
+const color_reset  = "\033[0m"
+const color_red    = "\033[1;31m"
+const color_yellow = "\033[1;33m"
+const color_blue   = "\033[1;34m"
+const color_cyan   = "\033[1;36m"
+
+// Width of tab character in columns, same as lexer.
+const tab_width = 4
+
+type renderer struct {
+	sb     strings.Builder
+	color  bool
+	gutter int
+}
+
+func (r *renderer) paint(style, s string) {
+	if r.color {
+		r.sb.WriteString(style)
+		r.sb.WriteString(s)
+		r.sb.WriteString(color_reset)
+		return
+	}
+	r.sb.WriteString(s)
+}
+
+// source_line returns line of file at row without line terminator.
+//
+// Special case is;
+//
+//	source_line(f, row) -> returns false if row is not exist.
+func source_line(f *juleio.File, row int) (string, bool) {
+	if f == nil || row < 1 {
+		return "", false
+	}
+	current := 1
+	start := -1
+	if row == 1 {
+		start = 0
+	}
+	for i, r := range f.Data {
+		if r != '\n' {
+			continue
+		}
+		if current == row {
+			return strings.TrimSuffix(string(f.Data[start:i]), "\r"), true
+		}
+		current++
+		if current == row {
+			start = i + 1
+		}
+	}
+	if start == -1 || start > len(f.Data) {
+		return "", false
+	}
+	return strings.TrimSuffix(string(f.Data[start:]), "\r"), true
+}
+
+// visual_column returns count of cells before lexer column in line.
+// Lexer counts tabs as tab_width and other characters by byte length.
+func visual_column(line string, column int) int {
+	cells := 0
+	c := 1
+	for _, r := range line {
+		if c >= column {
+			return cells
+		}
+		if r == '\t' {
+			c += tab_width
+			cells += tab_width
+		} else {
+			c += len(string(r))
+			cells++
+		}
+	}
+	if column > c {
+		cells += column - c
+	}
+	return cells
+}
+
+func expand_tabs(line string) string {
+	return strings.ReplaceAll(line, "\t", strings.Repeat(" ", tab_width))
+}
+
+// snippet writes source line at row with marks under columns.
+func (r *renderer) snippet(f *juleio.File, row, column, end_column int, mark, style, msg string) {
+	line, ok := source_line(f, row)
+	if !ok {
+		return
+	}
+	start := visual_column(line, column)
+	n := 1
+	if end_column > column {
+		n = visual_column(line, end_column) - start
+	} else if end_column == -1 {
+		// Mark to end of line.
+		n = len([]rune(expand_tabs(line))) - start
+	}
+	if n < 1 {
+		n = 1
+	}
+	gutter := strings.Repeat(" ", r.gutter)
+	r.paint(color_blue, fmt.Sprintf("%*d | ", r.gutter, row))
+	r.sb.WriteString(expand_tabs(line))
+	r.sb.WriteByte('\n')
+	r.paint(color_blue, gutter+" | ")
+	r.sb.WriteString(strings.Repeat(" ", start))
+	r.paint(style, strings.Repeat(mark, n))
+	if msg != "" {
+		r.sb.WriteByte(' ')
+		r.paint(style, msg)
+	}
+	r.sb.WriteByte('\n')
+}
+
+func (r *renderer) position(path string, row, column int) {
+	r.sb.WriteString(path)
+	r.sb.WriteByte(':')
+	r.sb.WriteString(fmt.Sprint(row))
+	r.sb.WriteByte(':')
+	r.sb.WriteString(fmt.Sprint(column))
+}
+
+// Render returns log with source snippets of log and labels,
+// and notes. Uses ANSI colors if color is true.
+// Log is rendered same as String if there is no source.
+func (clog *CompilerLog) Render(color bool) string {
+	r := renderer{color: color}
+	style := color_red
+	warn := clog.Type == FLAT_WARN || clog.Type == WARN
+	if warn {
+		style = color_yellow
+		r.paint(style, warningMark)
+		r.sb.WriteByte(' ')
+	}
+	if clog.Type == ERR || clog.Type == WARN {
+		r.position(clog.Path, clog.Row, clog.Column)
+		r.sb.WriteByte(' ')
+	}
+	r.paint(style, clog.message())
+	r.sb.WriteByte('\n')
+
+	max_row := clog.Row
+	for _, l := range clog.Labels {
+		if l.Row > max_row {
+			max_row = l.Row
+		}
+	}
+	r.gutter = len(fmt.Sprint(max_row))
+
+	end_column := clog.EndColumn
+	if clog.EndRow > clog.Row {
+		end_column = -1
+	}
+	r.snippet(clog.File, clog.Row, clog.Column, end_column, "^", style, "")
+	for _, l := range clog.Labels {
+		if l.Path != clog.Path {
+			r.position(l.Path, l.Row, l.Column)
+			r.sb.WriteByte('\n')
+		}
+		r.snippet(l.File, l.Row, l.Column, l.EndColumn, "-", color_blue, l.Message)
+	}
+	for _, note := range clog.Notes {
+		r.sb.WriteString(strings.Repeat(" ", r.gutter))
+		r.sb.WriteString(" = ")
+		r.paint(color_cyan, "note:")
+		r.sb.WriteByte(' ')
+		r.sb.WriteString(note)
+		r.sb.WriteByte('\n')
+	}
+	return strings.TrimSuffix(r.sb.String(), "\n")
+}
@@ -0,0 +1,174 @@
+package julelog
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/julelang/jule/pkg/juleio"
+)
+
+// test_file returns in-memory source file of data.
+func test_file(data string) *juleio.File {
+	return &juleio.File{
+		Dir:  filepath.Dir(test_path),
+		Name: filepath.Base(test_path),
+		Data: []rune(data),
+	}
+}
+
+const test_source = "fn main() {\n" +
+	"\tlet x = y\n" +
+	"\tlet s = \"çğü\" + z\n" +
+	"\t\tret a+b\n" +
+	"\tlet v = f(1,\r\n" +
+	"\t\t2)\n" +
+	"}\n"
+
+func TestRender(t *testing.T) {
+	f := test_file(test_source)
+	other := filepath.FromSlash("/project/src/lib.jule")
+	tests := []struct {
+		name string
+		log  CompilerLog
+		want string
+	}{
+		{
+			name: "caret",
+			log: CompilerLog{
+				Type: ERR, Row: 2, Column: 13, Path: test_path, File: f,
+				Message: "identifier is not exist: y", Key: "id_not_exist",
+			},
+			want: test_path + ":2:13 [J0022] identifier is not exist: y\n" +
+				"2 |     let x = y\n" +
+				"  |             ^",
+		},
+		{
+			name: "underline of tabs",
+			log: CompilerLog{
+				Type: ERR, Row: 4, Column: 13, EndRow: 4, EndColumn: 16,
+				Path: test_path, File: f, Message: "mismatched types",
+			},
+			want: test_path + ":4:13 mismatched types\n" +
+				"4 |         ret a+b\n" +
+				"  |             ^^^",
+		},
+		{
+			name: "underline of multibyte characters",
+			log: CompilerLog{
+				Type: ERR, Row: 3, Column: 13, EndRow: 3, EndColumn: 21,
+				Path: test_path, File: f, Message: "string literal",
+			},
+			want: test_path + ":3:13 string literal\n" +
+				"3 |     let s = \"çğü\" + z\n" +
+				"  |             ^^^^^",
+		},
+		{
+			name: "caret after multibyte characters",
+			log: CompilerLog{
+				Type: ERR, Row: 3, Column: 24, EndRow: 3, EndColumn: 25,
+				Path: test_path, File: f, Message: "identifier is not exist: z",
+			},
+			want: test_path + ":3:24 identifier is not exist: z\n" +
+				"3 |     let s = \"çğü\" + z\n" +
+				"  |                     ^",
+		},
+		{
+			name: "multiple rows",
+			log: CompilerLog{
+				Type: ERR, Row: 5, Column: 13, EndRow: 6, EndColumn: 4,
+				Path: test_path, File: f, Message: "call of multiple rows",
+			},
+			want: test_path + ":5:13 call of multiple rows\n" +
+				"5 |     let v = f(1,\n" +
+				"  |             ^^^^",
+		},
+		{
+			name: "labels and notes",
+			log: CompilerLog{
+				Type: ERR, Row: 6, Column: 9, Path: test_path, File: f,
+				Message: "identifier is already exist: x", Key: "exist_id",
+				Labels: []Label{
+					{Row: 2, Column: 9, EndColumn: -1, Path: test_path, File: f, Message: "previous declaration here"},
+					{Row: 1, Column: 4, EndColumn: 8, Path: other, File: test_file("fn main() {}\n"), Message: "other declaration"},
+				},
+				Notes: []string{"did you mean `y`?"},
+			},
+			want: test_path + ":6:9 [J0005] identifier is already exist: x\n" +
+				"6 |         2)\n" +
+				"  |         ^\n" +
+				"2 |     let x = y\n" +
+				"  |         ----- previous declaration here\n" +
+				other + ":1:4\n" +
+				"1 | fn main() {}\n" +
+				"  |    ---- other declaration\n" +
+				"  = note: did you mean `y`?",
+		},
+		{
+			name: "gutter of labels",
+			log: CompilerLog{
+				Type: ERR, Row: 2, Column: 5, Path: test_path, File: f, Message: "label",
+				Labels: []Label{{Row: 12, Column: 1, Path: test_path, File: test_file(strings.Repeat("\n", 11) + "label:\n")}},
+			},
+			want: test_path + ":2:5 label\n" +
+				" 2 |     let x = y\n" +
+				"   |     ^\n" +
+				"12 | label:\n" +
+				"   | -",
+		},
+		{
+			name: "warning",
+			log: CompilerLog{
+				Type: WARN, Row: 2, Column: 9, EndRow: 2, EndColumn: 10,
+				Path: test_path, File: f, Message: "variable is never used", Lint: "unused",
+			},
+			want: "<!> " + test_path + ":2:9 variable is never used [-Wunused]\n" +
+				"2 |     let x = y\n" +
+				"  |         ^",
+		},
+		{
+			name: "row is not exist",
+			log: CompilerLog{
+				Type: ERR, Row: 20, Column: 1, Path: test_path, File: f, Message: "row is not exist",
+				Notes: []string{"note"},
+			},
+			want: test_path + ":20:1 row is not exist\n" +
+				"   = note: note",
+		},
+		{
+			name: "no source",
+			log:  CompilerLog{Type: ERR, Row: 2, Column: 10, Path: test_path, Message: "no source"},
+			want: test_path + ":2:10 no source",
+		},
+		{
+			name: "flat error",
+			log:  CompilerLog{Type: FLAT_ERR, Message: "flat error"},
+			want: "flat error",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.log.Render(false)
+			if got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	log := CompilerLog{
+		Type: WARN, Row: 1, Column: 4, EndRow: 1, EndColumn: 8,
+		Path: test_path, File: test_file("fn main() {}\n"), Message: "warning",
+		Notes: []string{"note"},
+	}
+	want := color_yellow + "<!>" + color_reset + " " + test_path + ":1:4 " +
+		color_yellow + "warning" + color_reset + "\n" +
+		color_blue + "1 | " + color_reset + "fn main() {}\n" +
+		color_blue + "  | " + color_reset + "   " + color_yellow + "^^^^" + color_reset + "\n" +
+		"  = " + color_cyan + "note:" + color_reset + " note"
+	got := log.Render(true)
+	if got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
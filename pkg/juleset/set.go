@@ -0,0 +1,212 @@
+package juleset
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/julelint"
+)
+
+// Set is project settings of the jule.set file.
+type Set struct {
+	OutDir         string          `json:"out_dir"`
+	OutName        string          `json:"out_name"`
+	Compiler       string          `json:"compiler"`
+	CompilerPath   string          `json:"compiler_path"`
+	CxxFlags       []string        `json:"cxx_flags"`
+	LdFlags        []string        `json:"ld_flags"`
+	Libs           []string        `json:"libs"`
+	Optimization   string          `json:"optimization"`
+	Debug          *bool           `json:"debug"`
+	LineDirectives *bool           `json:"line_directives"`
+	PanicTrace     *bool           `json:"panic_trace"`
+	Std            string          `json:"std"`
+	Language       string          `json:"language"`
+	Tags           []string        `json:"tags"`
+	Sanitize       []string        `json:"sanitize"`
+	ErrorLimit     *int            `json:"error_limit"`
+	Warnings       map[string]bool `json:"warnings"`
+	Werror         *bool           `json:"werror"`
+}
+
+// Error is a settings error.
+// Message is formatted at every call of Error,
+// so it follows to current language pack.
+type Error struct {
+	Key  string
+	Args []any
+}
+
+func (e *Error) Error() string { return jule.GetError(e.Key, e.Args...) }
+
+func (s *Set) field(key string) any {
+	switch key {
+	case "out_dir":
+		return &s.OutDir
+	case "out_name":
+		return &s.OutName
+	case "compiler":
+		return &s.Compiler
+	case "compiler_path":
+		return &s.CompilerPath
+	case "cxx_flags":
+		return &s.CxxFlags
+	case "ld_flags":
+		return &s.LdFlags
+	case "libs":
+		return &s.Libs
+	case "optimization":
+		return &s.Optimization
+	case "debug":
+		return &s.Debug
+	case "line_directives":
+		return &s.LineDirectives
+	case "panic_trace":
+		return &s.PanicTrace
+	case "std":
+		return &s.Std
+	case "language":
+		return &s.Language
+	case "tags":
+		return &s.Tags
+	case "sanitize":
+		return &s.Sanitize
+	case "error_limit":
+		return &s.ErrorLimit
+	case "warnings":
+		return &s.Warnings
+	case "werror":
+		return &s.Werror
+	}
+	return nil
+}
+
+// IsOptimizationLevel reports level is valid optimization level or not.
+// Valid levels are "0", "1", "2", "3" and "s".
+func IsOptimizationLevel(level string) bool {
+	switch level {
+	case "0", "1", "2", "3", "s":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsCppStd reports std is supported C++ standard or not.
+// Valid standards are C++17 and newer, with GNU dialects.
+func IsCppStd(std string) bool {
+	switch std {
+	case "c++17", "c++20", "c++23", "gnu++17", "gnu++20", "gnu++23":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSanitizer reports name is known sanitizer or not.
+// Valid sanitizers are "address", "undefined" and "thread".
+func IsSanitizer(name string) bool {
+	switch name {
+	case "address", "undefined", "thread":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Set) check() (errs []error) {
+	switch s.Compiler {
+	case "", jule.COMPILER_GCC, jule.COMPILER_CLANG:
+	default:
+		errs = append(errs, &Error{"invalid_value_for_key", []any{s.Compiler, "compiler"}})
+	}
+	if s.Optimization != "" && !IsOptimizationLevel(s.Optimization) {
+		errs = append(errs, &Error{"invalid_value_for_key", []any{s.Optimization, "optimization"}})
+	}
+	if s.Std != "" && !IsCppStd(s.Std) {
+		errs = append(errs, &Error{"invalid_value_for_key", []any{s.Std, "std"}})
+		s.Std = ""
+	}
+	sanitize := s.Sanitize[:0]
+	for _, name := range s.Sanitize {
+		if IsSanitizer(name) {
+			sanitize = append(sanitize, name)
+		} else {
+			errs = append(errs, &Error{"invalid_value_for_key", []any{name, "sanitize"}})
+		}
+	}
+	s.Sanitize = sanitize
+	if s.ErrorLimit != nil && *s.ErrorLimit < 0 {
+		errs = append(errs, &Error{"invalid_value_for_key", []any{*s.ErrorLimit, "error_limit"}})
+		s.ErrorLimit = nil
+	}
+	for name := range s.Warnings {
+		if name != julelint.ALL && !julelint.IsLint(name) {
+			errs = append(errs, &Error{"unknown_lint", []any{name}})
+			delete(s.Warnings, name)
+		}
+	}
+	return
+}
+
+// Load loads Set from json bytes.
+// Returns settings with all valid keys even if has errors.
+func Load(bytes []byte) (*Set, []error) {
+	var keys map[string]json.RawMessage
+	err := json.Unmarshal(bytes, &keys)
+	if err != nil {
+		return nil, []error{&Error{"settings_invalid", []any{err.Error()}}}
+	}
+	// Sort keys for deterministic order of errors.
+	order := make([]string, 0, len(keys))
+	for key := range keys {
+		order = append(order, key)
+	}
+	sort.Strings(order)
+	s := new(Set)
+	var errs []error
+	for _, key := range order {
+		value := keys[key]
+		f := s.field(key)
+		if f == nil {
+			errs = append(errs, &Error{"settings_invalid_key", []any{key}})
+			continue
+		}
+		err = json.Unmarshal(value, f)
+		if err != nil {
+			errs = append(errs, &Error{"invalid_value_for_key", []any{strings.Trim(string(value), `"`), key}})
+			// Unmarshal may set field partially, invalid keys are not set.
+			v := reflect.ValueOf(f).Elem()
+			v.Set(reflect.Zero(v.Type()))
+		}
+	}
+	errs = append(errs, s.check()...)
+	return s, errs
+}
+
+// Find returns path of the nearest settings file.
+// Searches by walking up from dir to the root directory.
+//
+// Special case is;
+//
+//	Find(dir) -> returns empty string if settings file is not exist.
+func Find(dir string) string {
+	dir, _ = filepath.Abs(dir)
+	for {
+		path := filepath.Join(dir, jule.SETTINGS_FILE)
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
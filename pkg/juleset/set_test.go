@@ -0,0 +1,155 @@
+package juleset
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/julelang/jule/pkg/jule"
+)
+
+func error_keys(t *testing.T, errs []error) [][]any {
+	var keys [][]any
+	for _, err := range errs {
+		e, ok := err.(*Error)
+		if !ok {
+			t.Fatalf("error is not settings error: %v", err)
+		}
+		keys = append(keys, append([]any{e.Key}, e.Args...))
+	}
+	return keys
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		errs [][]any
+	}{
+		{
+			name: "valid",
+			json: `{"out_dir": "dist", "compiler": "gcc", "std": "c++20", "sanitize": ["address", "undefined"]}`,
+		},
+		{
+			name: "invalid json",
+			json: `{"out_dir": `,
+			errs: [][]any{{"settings_invalid", "unexpected end of JSON input"}},
+		},
+		{
+			name: "unknown key",
+			json: `{"foo": 1, "bar": 2}`,
+			errs: [][]any{
+				{"settings_invalid_key", "bar"},
+				{"settings_invalid_key", "foo"},
+			},
+		},
+		{
+			name: "bad types",
+			json: `{"debug": "yes", "out_dir": 1, "tags": "a"}`,
+			errs: [][]any{
+				{"invalid_value_for_key", "yes", "debug"},
+				{"invalid_value_for_key", "1", "out_dir"},
+				{"invalid_value_for_key", "a", "tags"},
+			},
+		},
+		{
+			name: "bad compiler",
+			json: `{"compiler": "msvc"}`,
+			errs: [][]any{{"invalid_value_for_key", "msvc", "compiler"}},
+		},
+		{
+			name: "bad optimization",
+			json: `{"optimization": "4"}`,
+			errs: [][]any{{"invalid_value_for_key", "4", "optimization"}},
+		},
+		{
+			name: "bad std",
+			json: `{"std": "c++11"}`,
+			errs: [][]any{{"invalid_value_for_key", "c++11", "std"}},
+		},
+		{
+			name: "bad sanitizers",
+			json: `{"sanitize": ["memory", "address", "leak"]}`,
+			errs: [][]any{
+				{"invalid_value_for_key", "memory", "sanitize"},
+				{"invalid_value_for_key", "leak", "sanitize"},
+			},
+		},
+		{
+			name: "negative error limit",
+			json: `{"error_limit": -1}`,
+			errs: [][]any{{"invalid_value_for_key", -1, "error_limit"}},
+		},
+		{
+			name: "unknown lint",
+			json: `{"warnings": {"all": false, "no_such_lint": true}}`,
+			errs: [][]any{{"unknown_lint", "no_such_lint"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, errs := Load([]byte(test.json))
+			got := error_keys(t, errs)
+			if !reflect.DeepEqual(got, test.errs) {
+				t.Errorf("got errors %v, want %v", got, test.errs)
+			}
+		})
+	}
+}
+
+func TestLoadKeepsValidKeys(t *testing.T) {
+	s, errs := Load([]byte(`{"out_dir": "dist", "std": "c++98", "sanitize": ["leak", "thread"], "debug": 1}`))
+	if len(errs) != 3 {
+		t.Fatalf("got %d errors, want 3", len(errs))
+	}
+	if s.OutDir != "dist" {
+		t.Errorf("out_dir is %q, want %q", s.OutDir, "dist")
+	}
+	if s.Std != "" {
+		t.Errorf("std is %q, want empty", s.Std)
+	}
+	if !reflect.DeepEqual(s.Sanitize, []string{"thread"}) {
+		t.Errorf("sanitize is %v, want [thread]", s.Sanitize)
+	}
+	if s.Debug != nil {
+		t.Errorf("debug is %v, want nil", *s.Debug)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "a", "b")
+	err := os.MkdirAll(sub, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set_path := filepath.Join(root, "a", jule.SETTINGS_FILE)
+	err = os.WriteFile(set_path, []byte("{}"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Directories named as settings file are not settings.
+	err = os.Mkdir(filepath.Join(sub, jule.SETTINGS_FILE), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{sub, set_path},
+		{filepath.Join(root, "a"), set_path},
+		{root, ""},
+	}
+	for _, test := range tests {
+		got := Find(test.dir)
+		// Settings files above of temporary directory are not belongs to test.
+		if test.want == "" && got != "" && filepath.Dir(got) != root {
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Find(%q) = %q, want %q", test.dir, got, test.want)
+		}
+	}
+}
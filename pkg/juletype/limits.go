@@ -2,16 +2,16 @@ package juletype
 
 import "math"
 
-// MinOfType returns minimum value of integer type.
+// MinOfType returns minimum value of integer type for bit size of architecture.
 //
 // Special case is;
-//  MinOfType(id) -> returns 0 if type id is not integer type.
-//  MinOfType(id) -> returns 0 if type id is not supported.
-func MinOfType(id uint8) int64 {
+//  MinOfType(id, bits) -> returns 0 if type id is not integer type.
+//  MinOfType(id, bits) -> returns 0 if type id is not supported.
+func MinOfType(id uint8, bits int) int64 {
 	if !IsInteger(id) {
 		return 0
 	}
-	id = GetRealCode(id)
+	id = GetRealCode(id, bits)
 	switch id {
 	case I8:
 		return math.MinInt8
@@ -25,16 +25,16 @@ func MinOfType(id uint8) int64 {
 	return 0
 }
 
-// MaxOfType returns maximum value of integer type.
+// MaxOfType returns maximum value of integer type for bit size of architecture.
 //
 // Special case is;
-//  MaxOfType(id) -> returns 0 if type id is not integer type.
-//  MaxOfType(id) -> returns 0 if type id is not supported.
-func MaxOfType(id uint8) uint64 {
+//  MaxOfType(id, bits) -> returns 0 if type id is not integer type.
+//  MaxOfType(id, bits) -> returns 0 if type id is not supported.
+func MaxOfType(id uint8, bits int) uint64 {
 	if !IsInteger(id) {
 		return 0
 	}
-	id = GetRealCode(id)
+	id = GetRealCode(id, bits)
 	switch id {
 	case I8:
 		return math.MaxInt8
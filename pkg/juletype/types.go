@@ -1,71 +1,56 @@
 package juletype
 
-import (
-	"strconv"
-
-	"github.com/julelang/jule/pkg/juleapi"
-)
-
-// INT_CODE is integer type code of current platform architecture.
-// Is equavalent to "int", but specific bit-sized integer type code.
-var INT_CODE uint8
-
-// UINT_CODE is integer type code of current platform architecture.
-// Is equavalent to "uint", but specific bit-sized integer type code.
-var UINT_CODE uint8
-
-// BIT_SIZE is bit size of architecture.
-var BIT_SIZE int
+import "github.com/julelang/jule/pkg/juleapi"
 
 const NUM_TYPE_STR = "<numeric>"
 const NIL_TYPE_STR = "<nil>"
 const VOID_TYPE_STR = "<void>"
 
-// GetRealCode returns real type code of code.
+// GetRealCode returns real type code of code for bit size of architecture.
 // If types is "int" or "uint", set to bit-specific type code.
-func GetRealCode(t uint8) uint8 {
+func GetRealCode(t uint8, bits int) uint8 {
 	switch t {
 	case INT:
-		t = INT_CODE
+		t = IntFromBits(uint64(bits))
 	case UINT, UINTPTR:
-		t = UINT_CODE
+		t = UIntFromBits(uint64(bits))
 	}
 	return t
 }
 
 // I16GreaterThan reports I16 is greater or not data-type than specified type.
-func I16GreaterThan(t uint8) bool {
-	t = GetRealCode(t)
+func I16GreaterThan(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == U8
 }
 
 // I32GreaterThan reports I32 is greater or not data-type than specified type.
-func I32GreaterThan(t uint8) bool {
-	t = GetRealCode(t)
+func I32GreaterThan(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == I8 || t == I16
 }
 
 // I64GreaterThan reports I64 is greater or not data-type than specified type.
-func I64GreaterThan(t uint8) bool {
-	t = GetRealCode(t)
+func I64GreaterThan(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == I8 || t == I16 || t == I32
 }
 
 // U16GreaterThan reports U16 is greater or not data-type than specified type.
-func U16GreaterThan(t uint8) bool {
-	t = GetRealCode(t)
+func U16GreaterThan(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == U8
 }
 
 // U32GreaterThan reports U32 is greater or not data-type than specified type.
-func U32GreaterThan(t uint8) bool {
-	t = GetRealCode(t)
+func U32GreaterThan(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == U8 || t == U16
 }
 
 // U64GreaterThan reports U64 is greater or not data-type than specified type.
-func U64GreaterThan(t uint8) bool {
-	t = GetRealCode(t)
+func U64GreaterThan(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == U8 || t == U16 || t == U32
 }
 
@@ -80,21 +65,21 @@ func F64GreaterThan(t uint8) bool {
 }
 
 // TypeGreaterThan reports type one is greater than type two or not.
-func TypeGreaterThan(t1, t2 uint8) bool {
-	t1 = GetRealCode(t1)
+func TypeGreaterThan(t1, t2 uint8, bits int) bool {
+	t1 = GetRealCode(t1, bits)
 	switch t1 {
 	case I16:
-		return I16GreaterThan(t2)
+		return I16GreaterThan(t2, bits)
 	case I32:
-		return I32GreaterThan(t2)
+		return I32GreaterThan(t2, bits)
 	case I64:
-		return I64GreaterThan(t2)
+		return I64GreaterThan(t2, bits)
 	case U16:
-		return U16GreaterThan(t2)
+		return U16GreaterThan(t2, bits)
 	case U32:
-		return U32GreaterThan(t2)
+		return U32GreaterThan(t2, bits)
 	case U64:
-		return U64GreaterThan(t2)
+		return U64GreaterThan(t2, bits)
 	case F32:
 		return F32GreaterThan(t2)
 	case F64:
@@ -106,26 +91,26 @@ func TypeGreaterThan(t1, t2 uint8) bool {
 }
 
 // I8CompatibleWith reports i8 is compatible or not with data-type specified type.
-func I8CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func I8CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == I8
 }
 
 // I16CompatibleWith reports i16 is compatible or not with data-type specified type.
-func I16CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func I16CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == I8 || t == I16 || t == U8
 }
 
 // I32CompatibleWith reports i32 is compatible or not with data-type specified type.
-func I32CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func I32CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == I8 || t == I16 || t == I32 || t == U8 || t == U16
 }
 
 // I64CompatibleWith reports i64 is compatible or not with data-type specified type.
-func I64CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func I64CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	switch t {
 	case I8, I16, I32, I64, U8, U16, U32:
 		return true
@@ -135,32 +120,32 @@ func I64CompatibleWith(t uint8) bool {
 }
 
 // U8CompatibleWith reports u8 is compatible or not with data-type specified type.
-func U8CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func U8CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == U8
 }
 
 // U16CompatibleWith reports u16 is compatible or not with data-type specified type.
-func U16CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func U16CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == U8 || t == U16
 }
 
 // U32CompatibleWith reports u32 is compatible or not with data-type specified type.
-func U32CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func U32CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == U8 || t == U16 || t == U32
 }
 
 // U16CompatibleWith reports u64 is compatible or not with data-type specified type.
-func U64CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func U64CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	return t == U8 || t == U16 || t == U32 || t == U64
 }
 
 // F32CompatibleWith reports f32 is compatible or not with data-type specified type.
-func F32CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func F32CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	switch t {
 	case F32, I8, I16, I32, I64, U8, U16, U32, U64:
 		return true
@@ -170,8 +155,8 @@ func F32CompatibleWith(t uint8) bool {
 }
 
 // F64CompatibleWith reports f64 is compatible or not with data-type specified type.
-func F64CompatibleWith(t uint8) bool {
-	t = GetRealCode(t)
+func F64CompatibleWith(t uint8, bits int) bool {
+	t = GetRealCode(t, bits)
 	switch t {
 	case F64, F32, I8, I16, I32, I64, U8, U16, U32, U64:
 		return true
@@ -181,35 +166,35 @@ func F64CompatibleWith(t uint8) bool {
 }
 
 // TypeAreCompatible reports type one and type two is compatible or not.
-func TypesAreCompatible(t1, t2 uint8, ignoreany bool) bool {
-	t1 = GetRealCode(t1)
+func TypesAreCompatible(t1, t2 uint8, ignoreany bool, bits int) bool {
+	t1 = GetRealCode(t1, bits)
 	switch t1 {
 	case ANY:
 		return !ignoreany
 	case I8:
-		return I8CompatibleWith(t2)
+		return I8CompatibleWith(t2, bits)
 	case I16:
-		return I16CompatibleWith(t2)
+		return I16CompatibleWith(t2, bits)
 	case I32:
-		return I32CompatibleWith(t2)
+		return I32CompatibleWith(t2, bits)
 	case I64:
-		return I64CompatibleWith(t2)
+		return I64CompatibleWith(t2, bits)
 	case U8:
-		return U8CompatibleWith(t2)
+		return U8CompatibleWith(t2, bits)
 	case U16:
-		return U16CompatibleWith(t2)
+		return U16CompatibleWith(t2, bits)
 	case U32:
-		return U32CompatibleWith(t2)
+		return U32CompatibleWith(t2, bits)
 	case U64:
-		return U64CompatibleWith(t2)
+		return U64CompatibleWith(t2, bits)
 	case BOOL:
 		return t2 == BOOL
 	case STR:
 		return t2 == STR
 	case F32:
-		return F32CompatibleWith(t2)
+		return F32CompatibleWith(t2, bits)
 	case F64:
-		return F64CompatibleWith(t2)
+		return F64CompatibleWith(t2, bits)
 	case NIL:
 		return t2 == NIL
 	}
@@ -238,7 +223,6 @@ func IsSignedNumeric(t uint8) bool {
 
 // IsSignedInteger reports type is signed integer or not.
 func IsSignedInteger(t uint8) bool {
-	t = GetRealCode(t)
 	switch t {
 	case I8, I16, I32, I64, INT:
 		return true
@@ -249,7 +233,6 @@ func IsSignedInteger(t uint8) bool {
 
 // IsUnsignedInteger reports type is unsigned integer or not.
 func IsUnsignedInteger(t uint8) bool {
-	t = GetRealCode(t)
 	switch t {
 	case U8, U16, U32, U64, UINT, UINTPTR:
 		return true
@@ -287,7 +270,6 @@ func CppId(t uint8) string {
 //  DefaultValOfType(t) = "nil" if t is invalid
 //  DefaultValOfType(t) = "nil" if t is not have default value
 func DefaultValOfType(t uint8) string {
-	t = GetRealCode(t)
 	if IsNumeric(t) || t == ENUM {
 		return "0"
 	}
@@ -337,15 +319,3 @@ func FloatFromBits(bits uint64) uint8 {
 		return F64
 	}
 }
-
-func init() {
-	BIT_SIZE = strconv.IntSize
-	switch BIT_SIZE {
-	case 32:
-		INT_CODE = I32
-		UINT_CODE = U32
-	case 64:
-		INT_CODE = I64
-		UINT_CODE = U64
-	}
-}
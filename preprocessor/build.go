@@ -0,0 +1,154 @@
+package preprocessor
+
+import (
+	"strings"
+
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/julelog"
+)
+
+// buildExpr is evaluator of build constraint expressions.
+//
+// Grammar is;
+//
+//	expr  = and { "||" and }
+//	and   = unary { "&&" unary }
+//	unary = "!" unary | "(" expr ")" | tag
+type buildExpr struct {
+	env *jule.Env
+	s   string
+	pos int
+	ok  bool
+}
+
+func (e *buildExpr) skipSpace() {
+	for e.pos < len(e.s) && lex.IsSpace(e.s[e.pos]) {
+		e.pos++
+	}
+}
+
+func (e *buildExpr) eat(s string) bool {
+	e.skipSpace()
+	if strings.HasPrefix(e.s[e.pos:], s) {
+		e.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (e *buildExpr) or() bool {
+	x := e.and()
+	for e.eat("||") {
+		y := e.and()
+		x = x || y
+	}
+	return x
+}
+
+func (e *buildExpr) and() bool {
+	x := e.unary()
+	for e.eat("&&") {
+		y := e.unary()
+		x = x && y
+	}
+	return x
+}
+
+func (e *buildExpr) unary() bool {
+	switch {
+	case e.eat("!"):
+		return !e.unary()
+	case e.eat("("):
+		x := e.or()
+		if !e.eat(")") {
+			e.ok = false
+		}
+		return x
+	}
+	return e.tag()
+}
+
+func (e *buildExpr) tag() bool {
+	e.skipSpace()
+	start := e.pos
+	for e.pos < len(e.s) {
+		b := e.s[e.pos]
+		if b != '_' && !lex.IsLetter(rune(b)) && !lex.IsDecimal(b) {
+			break
+		}
+		e.pos++
+	}
+	if start == e.pos {
+		e.ok = false
+		return false
+	}
+	return hasTag(e.env, e.s[start:e.pos])
+}
+
+func hasTag(env *jule.Env, tag string) bool {
+	switch tag {
+	case env.TargetOS, env.TargetArch:
+		return true
+	case jule.OS_UNIX:
+		return env.TargetOS == jule.OS_LINUX || env.TargetOS == jule.OS_DARWIN
+	case jule.ARCH_64Bit:
+		return jule.BitSizeOfArch(env.TargetArch) == 64
+	case jule.ARCH_32Bit:
+		return jule.BitSizeOfArch(env.TargetArch) == 32
+	}
+	for _, t := range env.Tags {
+		if tag == t {
+			return true
+		}
+	}
+	return false
+}
+
+// EvalBuildExpr evaluates build constraint expression for environment.
+// Reports false as ok if expression is invalid.
+func EvalBuildExpr(env *jule.Env, expr string) (result bool, ok bool) {
+	e := buildExpr{env: env, s: expr, ok: true}
+	result = e.or()
+	e.skipSpace()
+	if e.pos < len(e.s) {
+		e.ok = false
+	}
+	return result, e.ok
+}
+
+// IsBuildable reports file of tree is satisfies build
+// constraints of build directives at top of file or not.
+func IsBuildable(env *jule.Env, tree Tree) (bool, []julelog.CompilerLog) {
+	var errs []julelog.CompilerLog
+	ok := true
+	for _, obj := range tree {
+		c, is_comment := obj.Data.(models.Comment)
+		if !is_comment {
+			break
+		}
+		if !IsPreprocessorPragma(c.Content) {
+			continue
+		}
+		directive, expr := splitDirective(getDirective(c.Content))
+		if directive != jule.PREPROCESSOR_DIRECTIVE_BUILD {
+			continue
+		}
+		result, valid := EvalBuildExpr(env, expr)
+		if !valid {
+			errs = append(errs, julelog.CompilerLog{
+				Type:    julelog.ERR,
+				Row:     c.Token.Row,
+				Column:  c.Token.Column,
+				Path:    c.Token.File.Path(),
+				Message: env.GetError("invalid_build_expr", expr),
+				Key:     "invalid_build_expr",
+				Args:    []any{expr},
+			})
+			continue
+		}
+		ok = ok && result
+	}
+	return ok, errs
+}
@@ -0,0 +1,134 @@
+package preprocessor
+
+import (
+	"testing"
+
+	"github.com/julelang/jule/ast/models"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/pkg/jule"
+	"github.com/julelang/jule/pkg/juleio"
+)
+
+func test_env(os, arch string, tags ...string) *jule.Env {
+	env := jule.NewEnv()
+	env.TargetOS = os
+	env.TargetArch = arch
+	env.Tags = tags
+	return env
+}
+
+func TestEvalBuildExpr(t *testing.T) {
+	linux := test_env(jule.OS_LINUX, jule.ARCH_AMD64, "foo", "bar_2")
+	windows := test_env(jule.OS_WINDOWS, jule.ARCH_I386)
+	tests := []struct {
+		env    *jule.Env
+		expr   string
+		result bool
+		ok     bool
+	}{
+		{linux, "linux", true, true},
+		{linux, "windows", false, true},
+		{linux, "amd64", true, true},
+		{linux, "unix", true, true},
+		{linux, "64bit", true, true},
+		{linux, "32bit", false, true},
+		{windows, "unix", false, true},
+		{windows, "32bit", true, true},
+		{windows, "i386", true, true},
+
+		// User defined tags.
+		{linux, "foo", true, true},
+		{linux, "bar_2", true, true},
+		{linux, "baz", false, true},
+		{windows, "foo", false, true},
+
+		// Operators.
+		{linux, "!windows", true, true},
+		{linux, "!!linux", true, true},
+		{linux, "linux && foo", true, true},
+		{linux, "linux && baz", false, true},
+		{linux, "windows || foo", true, true},
+		{linux, "windows || baz", false, true},
+		{linux, "windows || linux && foo", true, true},
+		{linux, "(windows || linux) && !baz", true, true},
+		{linux, "!(linux && foo)", false, true},
+		{linux, "  linux&&foo  ", true, true},
+
+		// Invalid expressions.
+		{linux, "", false, false},
+		{linux, "linux &&", false, false},
+		{linux, "|| linux", false, false},
+		{linux, "(linux", true, false},
+		{linux, "linux)", true, false},
+		{linux, "linux foo", true, false},
+		{linux, "linux & foo", true, false},
+		{linux, "linux-gnu", true, false},
+	}
+	for _, test := range tests {
+		result, ok := EvalBuildExpr(test.env, test.expr)
+		if ok != test.ok || (ok && result != test.result) {
+			t.Errorf("EvalBuildExpr(%s/%s, %q) = %v, %v; want %v, %v",
+				test.env.TargetOS, test.env.TargetArch, test.expr,
+				result, ok, test.result, test.ok)
+		}
+	}
+}
+
+// build_tree returns tree of comments.
+func build_tree(comments ...string) Tree {
+	f := &juleio.File{Name: "test.jule"}
+	tree := make(Tree, len(comments))
+	for i, c := range comments {
+		tree[i].Data = models.Comment{
+			Token:   lex.Token{File: f, Row: i + 1, Column: 1},
+			Content: c,
+		}
+	}
+	return tree
+}
+
+func TestIsBuildable(t *testing.T) {
+	env := test_env(jule.OS_LINUX, jule.ARCH_AMD64, "foo")
+	tests := []struct {
+		name      string
+		comments  []string
+		buildable bool
+		errs      int
+	}{
+		{"no directives", nil, true, 0},
+		{"satisfied", []string{"jule:build linux"}, true, 0},
+		{"not satisfied", []string{"jule:build windows"}, false, 0},
+		{"user tag", []string{"jule:build foo && !bar"}, true, 0},
+		{"all directives", []string{"jule:build linux", "jule:build !foo"}, false, 0},
+		{"other comments", []string{"doc comment", "jule:build linux"}, true, 0},
+		{"invalid", []string{"jule:build linux &&"}, true, 1},
+		{"invalid and not satisfied", []string{"jule:build (", "jule:build windows"}, false, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buildable, errs := IsBuildable(env, build_tree(test.comments...))
+			if buildable != test.buildable {
+				t.Errorf("buildable is %v, want %v", buildable, test.buildable)
+			}
+			if len(errs) != test.errs {
+				t.Fatalf("got %d errors, want %d", len(errs), test.errs)
+			}
+			for _, err := range errs {
+				if err.Key != "invalid_build_expr" {
+					t.Errorf("error key is %q, want %q", err.Key, "invalid_build_expr")
+				}
+			}
+		})
+	}
+}
+
+func TestIsBuildableStopsAtCode(t *testing.T) {
+	env := test_env(jule.OS_LINUX, jule.ARCH_AMD64)
+	tree := build_tree("jule:build linux")
+	tree = append(tree, models.Object{Data: models.Fn{}})
+	tree = append(tree, build_tree("jule:build windows")...)
+	buildable, _ := IsBuildable(env, tree)
+	if !buildable {
+		t.Error("directives after code are not constraints of file")
+	}
+}
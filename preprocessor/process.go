@@ -15,8 +15,10 @@ func IsPreprocessorPragma(s string) bool {
 	if !strings.HasPrefix(s, jule.PRAGMA_COMMENT_PREFIX) {
 		return false
 	}
-	switch getDirective(s) {
-	case jule.PREPROCESSOR_DIRECTIVE_ENOFI:
+	directive, _ := splitDirective(getDirective(s))
+	switch directive {
+	case jule.PREPROCESSOR_DIRECTIVE_ENOFI,
+		jule.PREPROCESSOR_DIRECTIVE_BUILD:
 		return true
 	default:
 		return false
@@ -27,6 +29,15 @@ func getDirective(s string) string {
 	return s[len(jule.PRAGMA_COMMENT_PREFIX):]
 }
 
+// splitDirective returns directive name and arguments of directive.
+func splitDirective(s string) (directive string, args string) {
+	i := strings.IndexByte(s, ' ')
+	if i == -1 {
+		return s, ""
+	}
+	return s[:i], strings.TrimSpace(s[i+1:])
+}
+
 // Process all preprocessor directives and commands.
 func Process(tree *Tree, includeEnofi bool) {
 	if includeEnofi {